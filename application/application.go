@@ -1,10 +1,14 @@
 package application
 
 import (
+	"context"
 	"errors"
 	"log"
 	"os"
+	"os/signal"
 	"path"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/gobble"
@@ -55,6 +59,56 @@ func (a Application) mailClient() *mail.Client {
 	})
 }
 
+func (a Application) mailTransport() mail.Transport {
+	transport, err := mail.NewTransport(a.env.MailTransport, mail.TransportConfig{
+		SMTP: mail.Config{
+			User:              a.env.SMTPUser,
+			Pass:              a.env.SMTPPass,
+			Host:              a.env.SMTPHost,
+			Port:              a.env.SMTPPort,
+			Secret:            a.env.SMTPCRAMMD5Secret,
+			TestMode:          a.env.TestMode,
+			SkipVerifySSL:     !a.env.VerifySSL,
+			DisableTLS:        !a.env.SMTPTLS,
+			LoggingEnabled:    a.env.SMTPLoggingEnabled,
+			SMTPAuthMechanism: a.env.SMTPAuthMechanism,
+			DKIMSigner:        a.dkimSigner(),
+		},
+		SMTPPool: mail.PoolConfig{
+			MaxConnections:           a.env.SMTPPoolMaxConnections,
+			IdleTimeout:              time.Duration(a.env.SMTPPoolIdleTimeout) * time.Millisecond,
+			MaxMessagesPerConnection: a.env.SMTPPoolMaxMessages,
+		},
+		Maildir: mail.MaildirConfig{
+			Path: a.env.MaildirPath,
+		},
+		Webhook: mail.WebhookConfig{
+			URL:           a.env.MailWebhookURL,
+			Authorization: a.env.MailWebhookAuthorization,
+			Timeout:       time.Duration(a.env.MailWebhookTimeout) * time.Millisecond,
+			SkipVerifySSL: !a.env.VerifySSL,
+		},
+	})
+	if err != nil {
+		a.logger.Fatal("mail-transport-errored", err)
+	}
+
+	return transport
+}
+
+func (a Application) dkimSigner() *mail.DKIMSigner {
+	if a.env.DKIMPrivateKey == "" {
+		return nil
+	}
+
+	signer, err := mail.NewDKIMSigner(a.env.dkimConfig())
+	if err != nil {
+		a.logger.Fatal("dkim-signer-errored", err)
+	}
+
+	return signer
+}
+
 func (a Application) Run() {
 
 	a.VerifySMTPConfiguration()
@@ -73,14 +127,59 @@ func (a Application) Run() {
 	a.migrator.Migrate()
 
 	a.StartQueueGauge()
-	a.StartWorkers(validator)
+	workers := a.StartWorkers(validator)
 	a.StartMessageGC()
 	a.StartKeyRefresher(validator)
-	a.StartServer(a.logger, validator)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	server := web.NewServer()
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- a.StartServer(server, a.logger, validator)
+	}()
+
+	select {
+	case err := <-serverErrors:
+		if err != nil {
+			a.logger.Error("server-errored", err)
+		}
+	case sig := <-signals:
+		a.logger.Info("shutting-down", lager.Data{"signal": sig.String()})
+		a.Shutdown(server, workers)
+	}
+}
+
+// Shutdown stops the HTTP server and the workers, giving in-flight requests
+// and deliveries until SHUTDOWN_TIMEOUT to finish.
+func (a Application) Shutdown(server web.Server, workers postal.Workers) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(a.env.ShutdownTimeout)*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		if err := server.Shutdown(ctx); err != nil {
+			a.logger.Error("server-shutdown-errored", err)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		if err := workers.Shutdown(ctx); err != nil {
+			a.logger.Error("workers-shutdown-errored", err)
+		}
+	}()
+
+	wg.Wait()
+	a.logger.Info("shut-down")
 }
 
 func (a Application) VerifySMTPConfiguration() {
-	if a.env.TestMode {
+	if a.env.TestMode || a.env.MailTransport != mail.TransportSMTP {
 		return
 	}
 
@@ -134,8 +233,17 @@ func (a Application) StartKeyRefresher(validator *uaa.TokenValidator) {
 	}()
 }
 
-func (a Application) StartWorkers(validator *uaa.TokenValidator) {
-	postal.Boot(a.mailClient, a.dbProvider.sqlDB, postal.Config{
+func (a Application) StartWorkers(validator *uaa.TokenValidator) postal.Workers {
+	mailTransport := a.mailTransport
+	var pool mail.Transport
+	if a.env.MailTransport == mail.TransportSMTP && a.env.SMTPPoolMaxConnections > 0 {
+		pool = a.mailTransport()
+		mailTransport = func() mail.Transport {
+			return pool
+		}
+	}
+
+	workers := postal.Boot(mailTransport, a.dbProvider.sqlDB, postal.Config{
 		UAAClientID:          a.env.UAAClientID,
 		UAAClientSecret:      a.env.UAAClientSecret,
 		UAATokenValidator:    validator,
@@ -143,31 +251,52 @@ func (a Application) StartWorkers(validator *uaa.TokenValidator) {
 		VerifySSL:            a.env.VerifySSL,
 		InstanceIndex:        a.env.VCAPApplication.InstanceIndex,
 		WorkerCount:          WorkerCount,
+		CriticalWorkerCount:  a.env.CriticalWorkerCount,
 		RootPath:             a.env.RootPath,
 		EncryptionKey:        a.env.EncryptionKey,
 		DBLoggingEnabled:     a.env.DBLoggingEnabled,
 		Sender:               a.env.Sender,
 		Domain:               a.env.Domain,
+		PublicURL:            a.env.NotificationsPublicURL,
 		QueueWaitMaxDuration: a.env.GobbleWaitMaxDuration,
 		MaxQueueLength:       a.env.GobbleMaxQueueLength,
+		ClientWeights:        a.env.GobbleClientWeights,
 		MaxRetries:           a.env.MaxRetries,
 		CCHost:               a.env.CCHost,
 	})
+
+	if pool, ok := pool.(*mail.Pool); ok {
+		workers = workers.CloseAfterHalt(pool)
+	}
+
+	return workers
 }
 
 func (a Application) StartMessageGC() {
-	messageLifetime := 24 * time.Hour
+	if a.env.VCAPApplication.InstanceIndex != 0 {
+		return
+	}
+
+	retention := postal.MessageRetention{
+		Lifetime:          time.Duration(a.env.MessageRetention) * time.Millisecond,
+		StatusLifetimes:   map[string]time.Duration{},
+		DeletionBatchSize: a.env.MessageGCBatchSize,
+	}
+	for status, lifetime := range a.env.MessageStatusRetention {
+		retention.StatusLifetimes[status] = time.Duration(lifetime) * time.Millisecond
+	}
+
 	db := a.dbProvider.Database()
 	messagesRepo := a.dbProvider.MessagesRepo()
-	pollingInterval := 1 * time.Hour
+	pollingInterval := time.Duration(a.env.MessageGCInterval) * time.Millisecond
 
 	logger := log.New(os.Stdout, "", 0)
-	messageGC := postal.NewMessageGC(messageLifetime, db, messagesRepo, pollingInterval, logger)
+	messageGC := postal.NewMessageGC(retention, db, messagesRepo, pollingInterval, logger)
 	messageGC.Run()
 }
 
-func (a Application) StartServer(logger lager.Logger, validator *uaa.TokenValidator) {
-	web.NewServer().Run(web.Config{
+func (a Application) StartServer(server web.Server, logger lager.Logger, validator *uaa.TokenValidator) error {
+	return server.Run(web.Config{
 		DBLoggingEnabled:     a.env.DBLoggingEnabled,
 		SkipVerifySSL:        !a.env.VerifySSL,
 		Port:                 a.env.Port,
@@ -177,6 +306,11 @@ func (a Application) StartServer(logger lager.Logger, validator *uaa.TokenValida
 		Queue:                a.dbProvider.Queue(),
 		QueueWaitMaxDuration: a.env.GobbleWaitMaxDuration,
 		MaxQueueLength:       a.env.GobbleMaxQueueLength,
+		IdempotencyKeyTTL:    a.env.IdempotencyKeyTTL,
+		EncryptionKey:        a.env.EncryptionKey,
+
+		RateLimitRequestsPerMinute: a.env.RateLimitRequestsPerMinute,
+		RateLimitRecipientsPerHour: a.env.RateLimitRecipientsPerHour,
 
 		UAATokenValidator: validator,
 		UAAHost:           a.env.UAAHost,
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/cloudfoundry-incubator/notifications/mail"
@@ -14,26 +15,48 @@ import (
 type Environment struct {
 	CCHost                             string `env:"CC_HOST" env-required:"true"`
 	CORSOrigin                         string `env:"CORS_ORIGIN" env-default:"*"`
+	CriticalWorkerCount                int    `env:"CRITICAL_WORKER_COUNT" env-default:"1"`
 	DBLoggingEnabled                   bool   `env:"DB_LOGGING_ENABLED"`
 	DBMaxOpenConns                     int    `env:"DB_MAX_OPEN_CONNS"`
 	DatabaseURL                        string `env:"DATABASE_URL" env-required:"true"`
 	DefaultUAAScopesList               string `env:"DEFAULT_UAA_SCOPES"`
+	DKIMDomain                         string `env:"DKIM_DOMAIN"`
+	DKIMPrivateKey                     string `env:"DKIM_PRIVATE_KEY"`
+	DKIMSelector                       string `env:"DKIM_SELECTOR"`
 	Domain                             string `env:"DOMAIN" env-required:"true"`
 	EncryptionKey                      []byte `env:"ENCRYPTION_KEY" env-required:"true"`
+	GobbleClientWeightsList            string `env:"GOBBLE_CLIENT_WEIGHTS"`
 	GobbleWaitMaxDuration              int    `env:"GOBBLE_WAIT_MAX_DURATION" env-default:"5000"`
 	GobbleMaxQueueLength               int    `env:"GOBBLE_MAX_QUEUE_LENGTH" env-default:"5000"`
+	IdempotencyKeyTTL                  int    `env:"IDEMPOTENCY_KEY_TTL" env-default:"86400000"`
+	MailTransport                      string `env:"MAIL_TRANSPORT" env-default:"smtp"`
+	MailWebhookAuthorization           string `env:"MAIL_WEBHOOK_AUTHORIZATION"`
+	MailWebhookTimeout                 int    `env:"MAIL_WEBHOOK_TIMEOUT" env-default:"15000"`
+	MailWebhookURL                     string `env:"MAIL_WEBHOOK_URL"`
+	MaildirPath                        string `env:"MAILDIR_PATH"`
 	MaxRetries                         int    `env:"MAX_RETRIES" env-default:"5"`
+	MessageGCBatchSize                 int    `env:"MESSAGE_GC_BATCH_SIZE" env-default:"1000"`
+	MessageGCInterval                  int    `env:"MESSAGE_GC_INTERVAL" env-default:"3600000"`
+	MessageRetention                   int    `env:"MESSAGE_RETENTION" env-default:"86400000"`
+	MessageStatusRetentionList         string `env:"MESSAGE_STATUS_RETENTION"`
+	NotificationsPublicURL             string `env:"NOTIFICATIONS_PUBLIC_URL"`
 	Port                               int    `env:"PORT" env-default:"3000"`
+	RateLimitRecipientsPerHour         int    `env:"RATE_LIMIT_RECIPIENTS_PER_HOUR" env-default:"0"`
+	RateLimitRequestsPerMinute         int    `env:"RATE_LIMIT_REQUESTS_PER_MINUTE" env-default:"0"`
 	RootPath                           string `env:"ROOT_PATH"`
-	SMTPAuthMechanism                  string `env:"SMTP_AUTH_MECHANISM" env-required:"true"`
+	SMTPAuthMechanism                  string `env:"SMTP_AUTH_MECHANISM"`
 	SMTPCRAMMD5Secret                  string `env:"SMTP_CRAMMD5_SECRET"`
-	SMTPHost                           string `env:"SMTP_HOST" env-required:"true"`
+	SMTPHost                           string `env:"SMTP_HOST"`
 	SMTPLoggingEnabled                 bool   `env:"SMTP_LOGGING_ENABLED" env-default:"false"`
 	SMTPPass                           string `env:"SMTP_PASS"`
-	SMTPPort                           string `env:"SMTP_PORT" env-required:"true"`
+	SMTPPoolIdleTimeout                int    `env:"SMTP_POOL_IDLE_TIMEOUT" env-default:"30000"`
+	SMTPPoolMaxConnections             int    `env:"SMTP_POOL_MAX_CONNECTIONS" env-default:"0"`
+	SMTPPoolMaxMessages                int    `env:"SMTP_POOL_MAX_MESSAGES" env-default:"100"`
+	SMTPPort                           string `env:"SMTP_PORT"`
 	SMTPTLS                            bool   `env:"SMTP_TLS" env-default:"true"`
 	SMTPUser                           string `env:"SMTP_USER"`
 	Sender                             string `env:"SENDER" env-required:"true"`
+	ShutdownTimeout                    int    `env:"SHUTDOWN_TIMEOUT" env-default:"8000"`
 	TestMode                           bool   `env:"TEST_MODE" env-default:"false"`
 	UAAClientID                        string `env:"UAA_CLIENT_ID" env-required:"true"`
 	UAAClientSecret                    string `env:"UAA_CLIENT_SECRET" env-required:"true"`
@@ -48,9 +71,11 @@ type Environment struct {
 		InstanceIndex int `json:"instance_index"`
 	} `env:"VCAP_APPLICATION" env-required:"true"`
 
-	ModelMigrationsPath  string
-	GobbleMigrationsPath string
-	DefaultUAAScopes     []string
+	ModelMigrationsPath    string
+	GobbleMigrationsPath   string
+	GobbleClientWeights    map[string]int
+	DefaultUAAScopes       []string
+	MessageStatusRetention map[string]int
 }
 
 type EnvironmentError struct {
@@ -75,7 +100,27 @@ func NewEnvironment() (Environment, error) {
 
 	env.expandRoot()
 
-	err = env.validateSMTPAuthMechanism()
+	err = env.validateMailTransport()
+	if err != nil {
+		return env, EnvironmentError{err}
+	}
+
+	err = env.parseMessageStatusRetention()
+	if err != nil {
+		return env, EnvironmentError{err}
+	}
+
+	err = env.parseGobbleClientWeights()
+	if err != nil {
+		return env, EnvironmentError{err}
+	}
+
+	err = env.validateDKIM()
+	if err != nil {
+		return env, EnvironmentError{err}
+	}
+
+	err = env.parseNotificationsPublicURL()
 	if err != nil {
 		return env, EnvironmentError{err}
 	}
@@ -86,6 +131,103 @@ func NewEnvironment() (Environment, error) {
 	return env, nil
 }
 
+func (env *Environment) parseMessageStatusRetention() error {
+	if env.MessageGCBatchSize <= 0 {
+		return fmt.Errorf("Could not parse MESSAGE_GC_BATCH_SIZE %d, it must be a positive number of messages", env.MessageGCBatchSize)
+	}
+
+	env.MessageStatusRetention = map[string]int{}
+	if env.MessageStatusRetentionList == "" {
+		return nil
+	}
+
+	for _, entry := range strings.Split(env.MessageStatusRetentionList, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("Could not parse MESSAGE_STATUS_RETENTION %q, it does not fit format %q", env.MessageStatusRetentionList, "status:milliseconds,status:milliseconds")
+		}
+
+		retention, err := strconv.Atoi(parts[1])
+		if err != nil || retention <= 0 {
+			return fmt.Errorf("Could not parse MESSAGE_STATUS_RETENTION %q, the retention for %q must be a positive number of milliseconds", env.MessageStatusRetentionList, parts[0])
+		}
+
+		env.MessageStatusRetention[parts[0]] = retention
+	}
+
+	return nil
+}
+
+func (env *Environment) parseGobbleClientWeights() error {
+	env.GobbleClientWeights = map[string]int{}
+	if env.GobbleClientWeightsList == "" {
+		return nil
+	}
+
+	for _, entry := range strings.Split(env.GobbleClientWeightsList, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("Could not parse GOBBLE_CLIENT_WEIGHTS %q, it does not fit format %q", env.GobbleClientWeightsList, "client:weight,client:weight")
+		}
+
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight <= 0 {
+			return fmt.Errorf("Could not parse GOBBLE_CLIENT_WEIGHTS %q, the weight for %q must be a positive number", env.GobbleClientWeightsList, parts[0])
+		}
+
+		env.GobbleClientWeights[parts[0]] = weight
+	}
+
+	return nil
+}
+
+func (env *Environment) validateDKIM() error {
+	if env.DKIMPrivateKey == "" && env.DKIMDomain == "" && env.DKIMSelector == "" {
+		return nil
+	}
+
+	required := []struct{ name, value string }{
+		{"DKIM_PRIVATE_KEY", env.DKIMPrivateKey},
+		{"DKIM_DOMAIN", env.DKIMDomain},
+		{"DKIM_SELECTOR", env.DKIMSelector},
+	}
+
+	for _, field := range required {
+		if field.value == "" {
+			return viron.RequiredFieldError{Name: field.name}
+		}
+	}
+
+	_, err := mail.NewDKIMSigner(env.dkimConfig())
+	if err != nil {
+		return fmt.Errorf("Could not parse DKIM_PRIVATE_KEY: %s", err)
+	}
+
+	return nil
+}
+
+func (env Environment) dkimConfig() mail.DKIMConfig {
+	return mail.DKIMConfig{
+		Domain:     env.DKIMDomain,
+		Selector:   env.DKIMSelector,
+		PrivateKey: []byte(env.DKIMPrivateKey),
+	}
+}
+
+func (env *Environment) parseNotificationsPublicURL() error {
+	if env.NotificationsPublicURL == "" {
+		return nil
+	}
+
+	publicURL, err := url.Parse(env.NotificationsPublicURL)
+	if err != nil || (publicURL.Scheme != "http" && publicURL.Scheme != "https") || publicURL.Host == "" {
+		return fmt.Errorf("Could not parse NOTIFICATIONS_PUBLIC_URL %q, it does not fit format %q", env.NotificationsPublicURL, "https://host/path")
+	}
+
+	env.NotificationsPublicURL = strings.TrimSuffix(env.NotificationsPublicURL, "/")
+	return nil
+}
+
 func (env *Environment) parseDefaultUAAScopes() {
 	env.DefaultUAAScopes = strings.Split(env.DefaultUAAScopesList, ",")
 }
@@ -116,6 +258,43 @@ func (env *Environment) parseDatabaseURL() error {
 	return nil
 }
 
+func (env *Environment) validateMailTransport() error {
+	switch env.MailTransport {
+	case mail.TransportSMTP:
+		required := []struct{ name, value string }{
+			{"SMTP_AUTH_MECHANISM", env.SMTPAuthMechanism},
+			{"SMTP_HOST", env.SMTPHost},
+			{"SMTP_PORT", env.SMTPPort},
+		}
+
+		for _, field := range required {
+			if field.value == "" {
+				return viron.RequiredFieldError{Name: field.name}
+			}
+		}
+
+		return env.validateSMTPAuthMechanism()
+	case mail.TransportMaildir:
+		if env.MaildirPath == "" {
+			return viron.RequiredFieldError{Name: "MAILDIR_PATH"}
+		}
+	case mail.TransportWebhook:
+		if env.MailWebhookURL == "" {
+			return viron.RequiredFieldError{Name: "MAIL_WEBHOOK_URL"}
+		}
+	default:
+		for _, name := range mail.TransportNames() {
+			if name == env.MailTransport {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Could not parse MAIL_TRANSPORT %q, it is not one of the allowed values: %+v", env.MailTransport, mail.TransportNames())
+	}
+
+	return nil
+}
+
 func (env *Environment) validateSMTPAuthMechanism() error {
 	for _, mechanism := range mail.SMTPAuthMechanisms {
 		if mechanism == env.SMTPAuthMechanism {
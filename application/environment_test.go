@@ -1,6 +1,10 @@
 package application_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"os"
 
@@ -16,21 +20,43 @@ var _ = Describe("Environment", func() {
 	var envVars = []string{
 		"CC_HOST",
 		"CORS_ORIGIN",
+		"CRITICAL_WORKER_COUNT",
 		"DATABASE_URL",
 		"DB_LOGGING_ENABLED",
 		"DB_MAX_OPEN_CONNS",
 		"DEFAULT_UAA_SCOPES",
+		"DKIM_DOMAIN",
+		"DKIM_PRIVATE_KEY",
+		"DKIM_SELECTOR",
 		"DOMAIN",
 		"ENCRYPTION_KEY",
+		"GOBBLE_CLIENT_WEIGHTS",
 		"GOBBLE_WAIT_MAX_DURATION",
+		"IDEMPOTENCY_KEY_TTL",
+		"MAIL_TRANSPORT",
+		"MAIL_WEBHOOK_AUTHORIZATION",
+		"MAIL_WEBHOOK_TIMEOUT",
+		"MAIL_WEBHOOK_URL",
+		"MAILDIR_PATH",
+		"MESSAGE_GC_BATCH_SIZE",
+		"MESSAGE_GC_INTERVAL",
+		"MESSAGE_RETENTION",
+		"MESSAGE_STATUS_RETENTION",
+		"NOTIFICATIONS_PUBLIC_URL",
 		"PORT",
+		"RATE_LIMIT_RECIPIENTS_PER_HOUR",
+		"RATE_LIMIT_REQUESTS_PER_MINUTE",
 		"ROOT_PATH",
 		"SENDER",
+		"SHUTDOWN_TIMEOUT",
 		"SMTP_AUTH_MECHANISM",
 		"SMTP_CRAMMD5_SECRET",
 		"SMTP_HOST",
 		"SMTP_LOGGING_ENABLED",
 		"SMTP_PASS",
+		"SMTP_POOL_IDLE_TIMEOUT",
+		"SMTP_POOL_MAX_CONNECTIONS",
+		"SMTP_POOL_MAX_MESSAGES",
 		"SMTP_PORT",
 		"SMTP_USER",
 		"TEST_MODE",
@@ -266,6 +292,257 @@ var _ = Describe("Environment", func() {
 		})
 	})
 
+	Describe("Mail transport configuration", func() {
+		It("defaults to the smtp transport", func() {
+			os.Setenv("MAIL_TRANSPORT", "")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.MailTransport).To(Equal("smtp"))
+		})
+
+		It("loads the maildir configuration without requiring SMTP values", func() {
+			os.Setenv("MAIL_TRANSPORT", "maildir")
+			os.Setenv("MAILDIR_PATH", "/var/vcap/store/maildir")
+			os.Setenv("SMTP_HOST", "")
+			os.Setenv("SMTP_PORT", "")
+			os.Setenv("SMTP_AUTH_MECHANISM", "")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.MailTransport).To(Equal("maildir"))
+			Expect(env.MaildirPath).To(Equal("/var/vcap/store/maildir"))
+		})
+
+		It("errors when the maildir path is missing", func() {
+			os.Setenv("MAIL_TRANSPORT", "maildir")
+			os.Setenv("MAILDIR_PATH", "")
+
+			_, err := application.NewEnvironment()
+			Expect(err).To(MatchError(application.EnvironmentError{Err: viron.RequiredFieldError{Name: "MAILDIR_PATH"}}))
+		})
+
+		It("loads the webhook configuration", func() {
+			os.Setenv("MAIL_TRANSPORT", "webhook")
+			os.Setenv("MAIL_WEBHOOK_URL", "https://mail.example.com/send")
+			os.Setenv("MAIL_WEBHOOK_AUTHORIZATION", "Bearer some-token")
+			os.Setenv("MAIL_WEBHOOK_TIMEOUT", "2500")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.MailWebhookURL).To(Equal("https://mail.example.com/send"))
+			Expect(env.MailWebhookAuthorization).To(Equal("Bearer some-token"))
+			Expect(env.MailWebhookTimeout).To(Equal(2500))
+		})
+
+		It("errors when the webhook URL is missing", func() {
+			os.Setenv("MAIL_TRANSPORT", "webhook")
+			os.Setenv("MAIL_WEBHOOK_URL", "")
+
+			_, err := application.NewEnvironment()
+			Expect(err).To(MatchError(application.EnvironmentError{Err: viron.RequiredFieldError{Name: "MAIL_WEBHOOK_URL"}}))
+		})
+
+		It("errors when the transport is unknown", func() {
+			os.Setenv("MAIL_TRANSPORT", "carrier-pigeon")
+
+			_, err := application.NewEnvironment()
+			Expect(err).To(MatchError(application.EnvironmentError{Err: errors.New("Could not parse MAIL_TRANSPORT \"carrier-pigeon\", it is not one of the allowed values: [maildir smtp webhook]")}))
+		})
+	})
+
+	Describe("DKIM configuration", func() {
+		var privateKey string
+
+		BeforeEach(func() {
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			Expect(err).NotTo(HaveOccurred())
+
+			privateKey = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+		})
+
+		It("disables signing by default", func() {
+			os.Setenv("DKIM_PRIVATE_KEY", "")
+			os.Setenv("DKIM_DOMAIN", "")
+			os.Setenv("DKIM_SELECTOR", "")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.DKIMPrivateKey).To(BeEmpty())
+		})
+
+		It("loads the DKIM values when they are present", func() {
+			os.Setenv("DKIM_PRIVATE_KEY", privateKey)
+			os.Setenv("DKIM_DOMAIN", "example.com")
+			os.Setenv("DKIM_SELECTOR", "notifications")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.DKIMPrivateKey).To(Equal(privateKey))
+			Expect(env.DKIMDomain).To(Equal("example.com"))
+			Expect(env.DKIMSelector).To(Equal("notifications"))
+		})
+
+		It("errors when only some of the DKIM values are present", func() {
+			os.Setenv("DKIM_PRIVATE_KEY", privateKey)
+			os.Setenv("DKIM_DOMAIN", "example.com")
+			os.Setenv("DKIM_SELECTOR", "")
+
+			_, err := application.NewEnvironment()
+			Expect(err).To(MatchError(application.EnvironmentError{Err: viron.RequiredFieldError{Name: "DKIM_SELECTOR"}}))
+		})
+
+		It("errors when the private key cannot be parsed", func() {
+			os.Setenv("DKIM_PRIVATE_KEY", "not a key")
+			os.Setenv("DKIM_DOMAIN", "example.com")
+			os.Setenv("DKIM_SELECTOR", "notifications")
+
+			_, err := application.NewEnvironment()
+			Expect(err).To(MatchError(application.EnvironmentError{Err: errors.New("Could not parse DKIM_PRIVATE_KEY: DKIM private key is not PEM encoded")}))
+		})
+	})
+
+	Describe("SMTP pool configuration", func() {
+		It("disables pooling by default", func() {
+			os.Setenv("SMTP_POOL_MAX_CONNECTIONS", "")
+			os.Setenv("SMTP_POOL_IDLE_TIMEOUT", "")
+			os.Setenv("SMTP_POOL_MAX_MESSAGES", "")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.SMTPPoolMaxConnections).To(Equal(0))
+			Expect(env.SMTPPoolIdleTimeout).To(Equal(30000))
+			Expect(env.SMTPPoolMaxMessages).To(Equal(100))
+		})
+
+		It("loads the pool values when they are present", func() {
+			os.Setenv("SMTP_POOL_MAX_CONNECTIONS", "8")
+			os.Setenv("SMTP_POOL_IDLE_TIMEOUT", "5000")
+			os.Setenv("SMTP_POOL_MAX_MESSAGES", "250")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.SMTPPoolMaxConnections).To(Equal(8))
+			Expect(env.SMTPPoolIdleTimeout).To(Equal(5000))
+			Expect(env.SMTPPoolMaxMessages).To(Equal(250))
+		})
+	})
+
+	Describe("Critical worker configuration", func() {
+		It("starts one critical worker by default", func() {
+			os.Setenv("CRITICAL_WORKER_COUNT", "")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.CriticalWorkerCount).To(Equal(1))
+		})
+
+		It("loads the count when it is present", func() {
+			os.Setenv("CRITICAL_WORKER_COUNT", "3")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.CriticalWorkerCount).To(Equal(3))
+		})
+	})
+
+	Describe("Idempotency key configuration", func() {
+		It("remembers idempotency keys for a day by default", func() {
+			os.Setenv("IDEMPOTENCY_KEY_TTL", "")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.IdempotencyKeyTTL).To(Equal(86400000))
+		})
+
+		It("loads the TTL when it is present", func() {
+			os.Setenv("IDEMPOTENCY_KEY_TTL", "3600000")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.IdempotencyKeyTTL).To(Equal(3600000))
+		})
+	})
+
+	Describe("Rate limit configuration", func() {
+		It("does not limit clients by default", func() {
+			os.Setenv("RATE_LIMIT_REQUESTS_PER_MINUTE", "")
+			os.Setenv("RATE_LIMIT_RECIPIENTS_PER_HOUR", "")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.RateLimitRequestsPerMinute).To(Equal(0))
+			Expect(env.RateLimitRecipientsPerHour).To(Equal(0))
+		})
+
+		It("loads the default limits when they are present", func() {
+			os.Setenv("RATE_LIMIT_REQUESTS_PER_MINUTE", "120")
+			os.Setenv("RATE_LIMIT_RECIPIENTS_PER_HOUR", "5000")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.RateLimitRequestsPerMinute).To(Equal(120))
+			Expect(env.RateLimitRecipientsPerHour).To(Equal(5000))
+		})
+	})
+
+	Describe("Message retention configuration", func() {
+		It("keeps messages for a day and collects them hourly by default", func() {
+			os.Setenv("MESSAGE_RETENTION", "")
+			os.Setenv("MESSAGE_STATUS_RETENTION", "")
+			os.Setenv("MESSAGE_GC_INTERVAL", "")
+			os.Setenv("MESSAGE_GC_BATCH_SIZE", "")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.MessageRetention).To(Equal(86400000))
+			Expect(env.MessageStatusRetention).To(BeEmpty())
+			Expect(env.MessageGCInterval).To(Equal(3600000))
+			Expect(env.MessageGCBatchSize).To(Equal(1000))
+		})
+
+		It("loads the retention values when they are present", func() {
+			os.Setenv("MESSAGE_RETENTION", "3600000")
+			os.Setenv("MESSAGE_STATUS_RETENTION", "failed:604800000, undeliverable:172800000")
+			os.Setenv("MESSAGE_GC_INTERVAL", "60000")
+			os.Setenv("MESSAGE_GC_BATCH_SIZE", "250")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.MessageRetention).To(Equal(3600000))
+			Expect(env.MessageStatusRetention).To(Equal(map[string]int{
+				"failed":        604800000,
+				"undeliverable": 172800000,
+			}))
+			Expect(env.MessageGCInterval).To(Equal(60000))
+			Expect(env.MessageGCBatchSize).To(Equal(250))
+		})
+
+		It("errors when the status retention is not in the expected format", func() {
+			os.Setenv("MESSAGE_STATUS_RETENTION", "failed=604800000")
+
+			_, err := application.NewEnvironment()
+			Expect(err).To(MatchError(application.EnvironmentError{Err: errors.New(`Could not parse MESSAGE_STATUS_RETENTION "failed=604800000", it does not fit format "status:milliseconds,status:milliseconds"`)}))
+		})
+
+		It("errors when a status retention is not a positive number", func() {
+			os.Setenv("MESSAGE_STATUS_RETENTION", "failed:forever")
+
+			_, err := application.NewEnvironment()
+			Expect(err).To(MatchError(application.EnvironmentError{Err: errors.New(`Could not parse MESSAGE_STATUS_RETENTION "failed:forever", the retention for "failed" must be a positive number of milliseconds`)}))
+		})
+
+		It("errors when the batch size is not a positive number", func() {
+			os.Setenv("MESSAGE_GC_BATCH_SIZE", "0")
+
+			_, err := application.NewEnvironment()
+			Expect(err).To(MatchError(application.EnvironmentError{Err: errors.New(`Could not parse MESSAGE_GC_BATCH_SIZE 0, it must be a positive number of messages`)}))
+		})
+	})
+
 	Describe("SMTP logging", func() {
 		It("loads the SMTP_LOGGING_ENABLED variable when it is present", func() {
 			os.Setenv("SMTP_LOGGING_ENABLED", "true")
@@ -301,6 +578,24 @@ var _ = Describe("Environment", func() {
 		})
 	})
 
+	Describe("Shutdown configuration", func() {
+		It("gives in-flight work 8 seconds to finish by default", func() {
+			os.Setenv("SHUTDOWN_TIMEOUT", "")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.ShutdownTimeout).To(Equal(8000))
+		})
+
+		It("loads the timeout when it is present", func() {
+			os.Setenv("SHUTDOWN_TIMEOUT", "20000")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.ShutdownTimeout).To(Equal(20000))
+		})
+	})
+
 	Describe("CloudController configuration", func() {
 		It("loads the values when they are present", func() {
 			os.Setenv("CC_HOST", "https://api.example.com")
@@ -476,6 +771,41 @@ var _ = Describe("Environment", func() {
 		})
 	})
 
+	Describe("Gobble client weights", func() {
+		It("defaults to no weights", func() {
+			os.Setenv("GOBBLE_CLIENT_WEIGHTS", "")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.GobbleClientWeights).To(BeEmpty())
+		})
+
+		It("loads the weights when they are present", func() {
+			os.Setenv("GOBBLE_CLIENT_WEIGHTS", "cc-service-dashboards:5, autoscaler:2")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.GobbleClientWeights).To(Equal(map[string]int{
+				"cc-service-dashboards": 5,
+				"autoscaler":            2,
+			}))
+		})
+
+		It("errors when the weights are not in the expected format", func() {
+			os.Setenv("GOBBLE_CLIENT_WEIGHTS", "autoscaler=2")
+
+			_, err := application.NewEnvironment()
+			Expect(err).To(MatchError(application.EnvironmentError{Err: errors.New(`Could not parse GOBBLE_CLIENT_WEIGHTS "autoscaler=2", it does not fit format "client:weight,client:weight"`)}))
+		})
+
+		It("errors when a weight is not a positive number", func() {
+			os.Setenv("GOBBLE_CLIENT_WEIGHTS", "autoscaler:0")
+
+			_, err := application.NewEnvironment()
+			Expect(err).To(MatchError(application.EnvironmentError{Err: errors.New(`Could not parse GOBBLE_CLIENT_WEIGHTS "autoscaler:0", the weight for "autoscaler" must be a positive number`)}))
+		})
+	})
+
 	Describe("Default UAA scopes", func() {
 		It("sets the value if present", func() {
 			os.Setenv("DEFAULT_UAA_SCOPES", "my-scope,banana,foo,bar")
@@ -491,6 +821,31 @@ var _ = Describe("Environment", func() {
 		})
 	})
 
+	Describe("Public URL configuration", func() {
+		It("is empty by default", func() {
+			os.Setenv("NOTIFICATIONS_PUBLIC_URL", "")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.NotificationsPublicURL).To(BeEmpty())
+		})
+
+		It("sets the public URL without a trailing slash", func() {
+			os.Setenv("NOTIFICATIONS_PUBLIC_URL", "https://notifications.example.com/")
+
+			env, err := application.NewEnvironment()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(env.NotificationsPublicURL).To(Equal("https://notifications.example.com"))
+		})
+
+		It("errors when the public URL is not an absolute http or https URL", func() {
+			os.Setenv("NOTIFICATIONS_PUBLIC_URL", "notifications.example.com")
+
+			_, err := application.NewEnvironment()
+			Expect(err).To(MatchError(application.EnvironmentError{Err: errors.New(`Could not parse NOTIFICATIONS_PUBLIC_URL "notifications.example.com", it does not fit format "https://host/path"`)}))
+		})
+	})
+
 	Describe("Domain", func() {
 		It("sets the Domain", func() {
 			os.Setenv("DOMAIN", "example.com")
@@ -0,0 +1,23 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE `messages` ADD `client_id` varchar(255) DEFAULT NULL;
+ALTER TABLE `messages` ADD `kind_id` varchar(255) DEFAULT NULL;
+ALTER TABLE `messages` ADD `user_guid` varchar(255) DEFAULT NULL;
+ALTER TABLE `messages` ADD `email` varchar(255) DEFAULT NULL;
+ALTER TABLE `messages` ADD `vcap_request_id` varchar(255) DEFAULT NULL;
+ALTER TABLE `messages` ADD `created_at` datetime DEFAULT NULL;
+ALTER TABLE `messages` ADD KEY `client_id_kind_id` (`client_id`, `kind_id`);
+ALTER TABLE `messages` ADD KEY `user_guid` (`user_guid`);
+ALTER TABLE `messages` ADD KEY `created_at` (`created_at`);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE `messages` DROP KEY `created_at`;
+ALTER TABLE `messages` DROP KEY `user_guid`;
+ALTER TABLE `messages` DROP KEY `client_id_kind_id`;
+ALTER TABLE `messages` DROP COLUMN `created_at`;
+ALTER TABLE `messages` DROP COLUMN `vcap_request_id`;
+ALTER TABLE `messages` DROP COLUMN `email`;
+ALTER TABLE `messages` DROP COLUMN `user_guid`;
+ALTER TABLE `messages` DROP COLUMN `kind_id`;
+ALTER TABLE `messages` DROP COLUMN `client_id`;
@@ -0,0 +1,24 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE `templates` ADD COLUMN `version` int(11) NOT NULL DEFAULT 1;
+CREATE TABLE IF NOT EXISTS `template_versions` (
+      `primary` int(11) NOT NULL AUTO_INCREMENT,
+      `template_id` varchar(255) NOT NULL,
+      `version` int(11) NOT NULL,
+      `name` varchar(255),
+      `subject` varchar(255),
+      `text` longtext,
+      `html` longtext,
+      `metadata` longtext,
+      `overridden` bool NOT NULL DEFAULT false,
+      `created_at` datetime DEFAULT NULL,
+      PRIMARY KEY (`primary`),
+      UNIQUE KEY `template_id_version` (`template_id`, `version`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+INSERT INTO `template_versions` (`template_id`, `version`, `name`, `subject`, `text`, `html`, `metadata`, `overridden`, `created_at`)
+      SELECT `id`, `version`, `name`, `subject`, `text`, `html`, `metadata`, `overridden`, `updated_at` FROM `templates`;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE `template_versions`;
+ALTER TABLE `templates` DROP COLUMN `version`;
@@ -0,0 +1,11 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE `templates` ADD `localizations` longtext;
+ALTER TABLE `template_versions` ADD `localizations` longtext;
+UPDATE `templates` SET `localizations` = "{}";
+UPDATE `template_versions` SET `localizations` = "{}";
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE `template_versions` DROP COLUMN `localizations`;
+ALTER TABLE `templates` DROP COLUMN `localizations`;
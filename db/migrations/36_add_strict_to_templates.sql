@@ -0,0 +1,9 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE `templates` ADD `strict` bool NOT NULL DEFAULT false;
+ALTER TABLE `template_versions` ADD `strict` bool NOT NULL DEFAULT false;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE `template_versions` DROP COLUMN `strict`;
+ALTER TABLE `templates` DROP COLUMN `strict`;
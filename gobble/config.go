@@ -1,8 +1,15 @@
 package gobble
 
-import "time"
+import (
+	"time"
+
+	"github.com/pivotal-golang/lager"
+)
 
 type Config struct {
 	WaitMaxDuration time.Duration
 	MaxQueueLength  int
+	ClientWeights   map[string]int
+	MinPriority     int
+	Logger          lager.Logger
 }
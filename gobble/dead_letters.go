@@ -0,0 +1,157 @@
+package gobble
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type DeadLetter struct {
+	ID           int       `db:"id"`
+	JobID        int       `db:"job_id"`
+	WorkerID     string    `db:"worker_id"`
+	Payload      string    `db:"payload"`
+	LastError    string    `db:"last_error"`
+	RetryCount   int       `db:"retry_count"`
+	RetryHistory string    `db:"retry_history"`
+	ClientID     string    `db:"client_id"`
+	Priority     int       `db:"priority"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+func NewDeadLetter(job *Job, createdAt time.Time) *DeadLetter {
+	return &DeadLetter{
+		JobID:        job.ID,
+		WorkerID:     job.WorkerID,
+		Payload:      job.Payload,
+		LastError:    job.LastError(),
+		RetryCount:   job.RetryCount,
+		RetryHistory: job.RetryHistory,
+		ClientID:     job.ClientID,
+		Priority:     job.Priority,
+		CreatedAt:    createdAt,
+	}
+}
+
+func (letter DeadLetter) History() []RetryAttempt {
+	return Job{RetryHistory: letter.RetryHistory}.History()
+}
+
+type DeadLetterNotFoundError struct {
+	ID int
+}
+
+func (e DeadLetterNotFoundError) Error() string {
+	return fmt.Sprintf("Dead letter with ID %d could not be found", e.ID)
+}
+
+type enqueuer interface {
+	Enqueue(*Job, ConnectionInterface) (*Job, error)
+}
+
+type DeadLetters struct {
+	database *DB
+	queue    enqueuer
+}
+
+func NewDeadLetters(database DatabaseInterface, queue enqueuer) DeadLetters {
+	return DeadLetters{
+		database: database.(*DB),
+		queue:    queue,
+	}
+}
+
+func (letters DeadLetters) List() ([]DeadLetter, error) {
+	deadLetters := []DeadLetter{}
+	_, err := letters.database.Connection.Select(&deadLetters, "SELECT * FROM `dead_letters` ORDER BY `id`")
+	if err != nil {
+		return []DeadLetter{}, err
+	}
+
+	return deadLetters, nil
+}
+
+func (letters DeadLetters) Find(id int) (DeadLetter, error) {
+	deadLetter := DeadLetter{}
+	err := letters.database.Connection.SelectOne(&deadLetter, "SELECT * FROM `dead_letters` WHERE `id` = ?", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return DeadLetter{}, DeadLetterNotFoundError{ID: id}
+		}
+		return DeadLetter{}, err
+	}
+
+	return deadLetter, nil
+}
+
+// Replay moves the dead letter back onto the queue. The dead letter is locked
+// and removed before the job is enqueued, so that concurrent replays of the
+// same dead letter enqueue it only once.
+func (letters DeadLetters) Replay(id int) (*Job, error) {
+	transaction, err := letters.database.Connection.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetter := DeadLetter{}
+	err = transaction.SelectOne(&deadLetter, "SELECT * FROM `dead_letters` WHERE `id` = ? FOR UPDATE", id)
+	if err != nil {
+		transaction.Rollback()
+		if err == sql.ErrNoRows {
+			return nil, DeadLetterNotFoundError{ID: id}
+		}
+		return nil, err
+	}
+
+	count, err := transaction.Delete(&deadLetter)
+	if err != nil {
+		transaction.Rollback()
+		return nil, err
+	}
+
+	if count != 1 {
+		transaction.Rollback()
+		return nil, DeadLetterNotFoundError{ID: id}
+	}
+
+	job, err := letters.queue.Enqueue(&Job{
+		Payload:  deadLetter.Payload,
+		ClientID: deadLetter.ClientID,
+		Priority: deadLetter.Priority,
+	}, transaction)
+	if err != nil {
+		transaction.Rollback()
+		return nil, err
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (letters DeadLetters) Purge(id int) error {
+	deadLetter, err := letters.Find(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = letters.database.Connection.Delete(&deadLetter)
+	return err
+}
+
+func (letters DeadLetters) PurgeAll() (int, error) {
+	result, err := letters.database.Connection.Exec("DELETE FROM `dead_letters`")
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
@@ -0,0 +1,152 @@
+package gobble_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeadLetters", func() {
+	var (
+		queue       *gobble.Queue
+		database    *gobble.DB
+		deadLetters gobble.DeadLetters
+		letter      *gobble.DeadLetter
+	)
+
+	BeforeEach(func() {
+		TruncateTables()
+		database = gobble.NewDatabase(sqlDB)
+		clock := &mocks.Clock{}
+		clock.NowCall.Returns.Time = time.Now().UTC().Truncate(time.Second)
+
+		queue = gobble.NewQueue(database, clock, gobble.Config{
+			WaitMaxDuration: 50 * time.Millisecond,
+			MaxQueueLength:  1000,
+		})
+		deadLetters = gobble.NewDeadLetters(database, queue)
+
+		letter = &gobble.DeadLetter{
+			JobID:        42,
+			WorkerID:     "worker-1",
+			Payload:      `{"message":"hello"}`,
+			LastError:    "smtp is down",
+			RetryCount:   5,
+			RetryHistory: "[]",
+			ClientID:     "some-client",
+			Priority:     gobble.PriorityCritical,
+			CreatedAt:    time.Now().UTC().Truncate(time.Second),
+		}
+		err := database.Connection.Insert(letter)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		queue.Close()
+	})
+
+	Describe("List", func() {
+		It("returns all of the dead letters", func() {
+			letters, err := deadLetters.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(letters).To(HaveLen(1))
+			Expect(letters[0].ID).To(Equal(letter.ID))
+			Expect(letters[0].LastError).To(Equal("smtp is down"))
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns the dead letter with the given id", func() {
+			found, err := deadLetters.Find(letter.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.JobID).To(Equal(42))
+		})
+
+		It("returns a not found error when the dead letter does not exist", func() {
+			_, err := deadLetters.Find(letter.ID + 1)
+			Expect(err).To(MatchError(gobble.DeadLetterNotFoundError{ID: letter.ID + 1}))
+		})
+	})
+
+	Describe("Replay", func() {
+		It("enqueues a fresh job with the payload and removes the dead letter", func() {
+			job, err := deadLetters.Replay(letter.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job.Payload).To(Equal(`{"message":"hello"}`))
+			Expect(job.RetryCount).To(Equal(0))
+			Expect(job.ClientID).To(Equal("some-client"))
+			Expect(job.Priority).To(Equal(gobble.PriorityCritical))
+
+			length, err := queue.Len()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(length).To(Equal(1))
+
+			_, err = deadLetters.Find(letter.ID)
+			Expect(err).To(BeAssignableToTypeOf(gobble.DeadLetterNotFoundError{}))
+		})
+
+		It("returns a not found error when the dead letter does not exist", func() {
+			_, err := deadLetters.Replay(letter.ID + 1)
+			Expect(err).To(BeAssignableToTypeOf(gobble.DeadLetterNotFoundError{}))
+		})
+
+		It("enqueues the job only once when the dead letter is replayed concurrently", func() {
+			errs := make(chan error, 2)
+			for i := 0; i < 2; i++ {
+				go func() {
+					defer GinkgoRecover()
+
+					_, err := deadLetters.Replay(letter.ID)
+					errs <- err
+				}()
+			}
+
+			var failures []error
+			for i := 0; i < 2; i++ {
+				if err := <-errs; err != nil {
+					failures = append(failures, err)
+				}
+			}
+			Expect(failures).To(HaveLen(1))
+			Expect(failures[0]).To(BeAssignableToTypeOf(gobble.DeadLetterNotFoundError{}))
+
+			length, err := queue.Len()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(length).To(Equal(1))
+		})
+	})
+
+	Describe("Purge", func() {
+		It("deletes the dead letter", func() {
+			err := deadLetters.Purge(letter.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			letters, err := deadLetters.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(letters).To(BeEmpty())
+		})
+	})
+
+	Describe("PurgeAll", func() {
+		It("deletes every dead letter", func() {
+			err := database.Connection.Insert(&gobble.DeadLetter{
+				Payload:      "{}",
+				RetryHistory: "[]",
+				CreatedAt:    time.Now().UTC().Truncate(time.Second),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := deadLetters.PurgeAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+
+			letters, err := deadLetters.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(letters).To(BeEmpty())
+		})
+	})
+})
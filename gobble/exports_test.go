@@ -0,0 +1,11 @@
+package gobble
+
+type Candidate = candidate
+
+func NewClientScheduler(weights map[string]int) *clientScheduler {
+	return newClientScheduler(weights)
+}
+
+func (scheduler *clientScheduler) Next(candidates []Candidate) Candidate {
+	return scheduler.next(candidates)
+}
@@ -5,14 +5,29 @@ import (
 	"time"
 )
 
+const (
+	PriorityDefault  = 0
+	PriorityCritical = 1
+)
+
 type Job struct {
-	ID          int       `db:"id"`
-	WorkerID    string    `db:"worker_id"`
-	Payload     string    `db:"payload"`
-	Version     int64     `db:"version"`
-	RetryCount  int       `db:"retry_count"`
-	ActiveAt    time.Time `db:"active_at"`
-	ShouldRetry bool      `db:"-"`
+	ID               int       `db:"id"`
+	WorkerID         string    `db:"worker_id"`
+	Payload          string    `db:"payload"`
+	Version          int64     `db:"version"`
+	RetryCount       int       `db:"retry_count"`
+	ActiveAt         time.Time `db:"active_at"`
+	RetryHistory     string    `db:"retry_history"`
+	ClientID         string    `db:"client_id"`
+	Priority         int       `db:"priority"`
+	ShouldRetry      bool      `db:"-"`
+	ShouldDeadLetter bool      `db:"-"`
+}
+
+type RetryAttempt struct {
+	RetryCount int       `json:"retry_count"`
+	Error      string    `json:"error"`
+	FailedAt   time.Time `json:"failed_at"`
 }
 
 func NewJob(data interface{}) *Job {
@@ -37,6 +52,62 @@ func (job *Job) Retry(duration time.Duration) {
 	job.ShouldRetry = true
 }
 
+// Defer puts the job back on the queue to run later without counting it as a
+// retry, for when the job could not run rather than failed.
+func (job *Job) Defer(duration time.Duration) {
+	job.WorkerID = ""
+	job.ActiveAt = time.Now().Add(duration)
+	job.ShouldRetry = true
+}
+
+func (job *Job) Fail(reason error) {
+	message := "unknown error"
+	if reason != nil {
+		message = reason.Error()
+	}
+
+	history := append(job.History(), RetryAttempt{
+		RetryCount: job.RetryCount,
+		Error:      message,
+		FailedAt:   time.Now().UTC(),
+	})
+
+	encoded, err := json.Marshal(history)
+	if err != nil {
+		panic(err)
+	}
+
+	job.RetryHistory = string(encoded)
+}
+
+func (job *Job) GiveUp() {
+	job.ShouldRetry = false
+	job.ShouldDeadLetter = true
+}
+
+func (job Job) History() []RetryAttempt {
+	history := []RetryAttempt{}
+	if job.RetryHistory == "" {
+		return history
+	}
+
+	err := json.Unmarshal([]byte(job.RetryHistory), &history)
+	if err != nil {
+		return []RetryAttempt{}
+	}
+
+	return history
+}
+
+func (job Job) LastError() string {
+	history := job.History()
+	if len(history) == 0 {
+		return ""
+	}
+
+	return history[len(history)-1].Error
+}
+
 func (job *Job) State() (int, time.Time) {
 	return job.RetryCount, job.ActiveAt
 }
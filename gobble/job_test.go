@@ -1,6 +1,7 @@
 package gobble_test
 
 import (
+	"errors"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/gobble"
@@ -57,6 +58,51 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("Defer", func() {
+		It("sets up the job to run later without counting a retry", func() {
+			job := gobble.NewJob("the data")
+			job.RetryCount = 1
+			job.WorkerID = "my-id"
+
+			job.Defer(10 * time.Minute)
+
+			Expect(job.WorkerID).To(Equal(""))
+			Expect(job.RetryCount).To(Equal(1))
+			Expect(job.ActiveAt).To(BeTemporally("~", time.Now().Add(10*time.Minute), 10*time.Second))
+			Expect(job.ShouldRetry).To(BeTrue())
+		})
+	})
+
+	Describe("Fail", func() {
+		It("records the failure in the retry history", func() {
+			job := gobble.NewJob("the data")
+			job.RetryCount = 3
+
+			job.Fail(errors.New("smtp is down"))
+			job.Fail(nil)
+
+			history := job.History()
+			Expect(history).To(HaveLen(2))
+			Expect(history[0].RetryCount).To(Equal(3))
+			Expect(history[0].Error).To(Equal("smtp is down"))
+			Expect(history[0].FailedAt).To(BeTemporally("~", time.Now(), 10*time.Second))
+			Expect(history[1].Error).To(Equal("unknown error"))
+			Expect(job.LastError()).To(Equal("unknown error"))
+		})
+	})
+
+	Describe("GiveUp", func() {
+		It("marks the job to be dead-lettered instead of retried", func() {
+			job := gobble.NewJob("the data")
+			job.Retry(1 * time.Minute)
+
+			job.GiveUp()
+
+			Expect(job.ShouldRetry).To(BeFalse())
+			Expect(job.ShouldDeadLetter).To(BeTrue())
+		})
+	})
+
 	Describe("State", func() {
 		It("returns the current retry count and active at values", func() {
 			expectedActiveAt := time.Now().Add(-5 * time.Minute)
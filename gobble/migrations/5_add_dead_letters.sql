@@ -0,0 +1,18 @@
+-- +migrate Up
+ALTER TABLE `jobs` ADD `retry_history` longtext NOT NULL;
+
+CREATE TABLE IF NOT EXISTS `dead_letters` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `job_id` int(11) NOT NULL,
+  `worker_id` varchar(255) NOT NULL DEFAULT '',
+  `payload` longtext NOT NULL,
+  `last_error` text NOT NULL,
+  `retry_count` int(11) NOT NULL DEFAULT '0',
+  `retry_history` longtext NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+DROP TABLE `dead_letters`;
+ALTER TABLE `jobs` DROP COLUMN `retry_history`;
@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE `jobs` ADD `client_id` varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE `jobs` ADD `priority` int(11) NOT NULL DEFAULT '0';
+ALTER TABLE `dead_letters` ADD `client_id` varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE `dead_letters` ADD `priority` int(11) NOT NULL DEFAULT '0';
+
+-- +migrate Down
+ALTER TABLE `dead_letters` DROP COLUMN `priority`;
+ALTER TABLE `dead_letters` DROP COLUMN `client_id`;
+ALTER TABLE `jobs` DROP COLUMN `priority`;
+ALTER TABLE `jobs` DROP COLUMN `client_id`;
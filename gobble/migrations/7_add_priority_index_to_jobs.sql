@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE `jobs` ADD INDEX `client_id_priority_active_at_worker_id` (`client_id`, `priority`, `active_at`, `worker_id`);
+
+-- +migrate Down
+ALTER TABLE `jobs` DROP INDEX `client_id_priority_active_at_worker_id`;
@@ -2,10 +2,13 @@ package gobble
 
 import (
 	"database/sql"
+	"fmt"
 	"math/rand"
-	"strings"
+	"sync"
 	"time"
 
+	"github.com/pivotal-golang/lager"
+	"github.com/rcrowley/go-metrics"
 	"gopkg.in/gorp.v1"
 )
 
@@ -14,9 +17,20 @@ var WaitMaxDuration = 5 * time.Second
 type QueueInterface interface {
 	Enqueue(*Job, ConnectionInterface) (*Job, error)
 	Reserve(string) <-chan *Job
-	Dequeue(*Job)
-	Requeue(*Job)
+	Dequeue(*Job) error
+	Requeue(*Job) error
+	DeadLetter(*Job) error
 	Len() (int, error)
+	Logger() lager.Logger
+}
+
+type QueueFullError struct {
+	Length    int
+	MaxLength int
+}
+
+func (e QueueFullError) Error() string {
+	return fmt.Sprintf("the queue is full (%d of %d jobs), try again later", e.Length, e.MaxLength)
 }
 
 type clock interface {
@@ -24,10 +38,12 @@ type clock interface {
 }
 
 type Queue struct {
-	config   Config
-	database *DB
-	clock    clock
-	closed   bool
+	config    Config
+	database  *DB
+	clock     clock
+	scheduler *clientScheduler
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 func NewQueue(database DatabaseInterface, clock clock, config Config) *Queue {
@@ -35,10 +51,16 @@ func NewQueue(database DatabaseInterface, clock clock, config Config) *Queue {
 		config.WaitMaxDuration = WaitMaxDuration
 	}
 
+	if config.Logger == nil {
+		config.Logger = lager.NewLogger("gobble")
+	}
+
 	return &Queue{
-		database: database.(*DB),
-		clock:    clock,
-		config:   config,
+		database:  database.(*DB),
+		clock:     clock,
+		config:    config,
+		scheduler: newClientScheduler(config.ClientWeights),
+		done:      make(chan struct{}),
 	}
 }
 
@@ -46,12 +68,12 @@ func (queue *Queue) Enqueue(job *Job, connection ConnectionInterface) (*Job, err
 	if (job.ActiveAt == time.Time{}) {
 		job.ActiveAt = queue.clock.Now()
 	}
-	len, err := queue.Len()
+	length, err := queue.Len()
 	if err != nil {
-		panic(err)
+		return job, err
 	}
-	if len >= queue.config.MaxQueueLength {
-		return nil, nil
+	if length >= queue.config.MaxQueueLength {
+		return nil, QueueFullError{Length: length, MaxLength: queue.config.MaxQueueLength}
 	}
 
 	err = connection.Insert(job)
@@ -62,22 +84,44 @@ func (queue *Queue) Enqueue(job *Job, connection ConnectionInterface) (*Job, err
 	return job, nil
 }
 
-func (queue *Queue) Requeue(job *Job) {
-	len, err := queue.Len()
+// EnqueueAll inserts the jobs with a single check of the queue length. An
+// empty queue always accepts the batch, so that a batch larger than
+// MaxQueueLength can still be enqueued.
+func (queue *Queue) EnqueueAll(jobs []*Job, connection ConnectionInterface) ([]*Job, error) {
+	length, err := queue.Len()
 	if err != nil {
-		panic(err)
+		return jobs, err
 	}
-	if len >= queue.config.MaxQueueLength {
-		_, err = queue.database.Connection.Delete(job)
-		if err != nil {
-			panic(err)
+	if length > 0 && length+len(jobs) > queue.config.MaxQueueLength {
+		return nil, QueueFullError{Length: length, MaxLength: queue.config.MaxQueueLength}
+	}
+
+	records := make([]interface{}, 0, len(jobs))
+	for _, job := range jobs {
+		if (job.ActiveAt == time.Time{}) {
+			job.ActiveAt = queue.clock.Now()
 		}
-	} else {
-		_, err = queue.database.Connection.Update(job)
-		if err != nil {
-			panic(err)
+		records = append(records, job)
+	}
+
+	err = connection.Insert(records...)
+	if err != nil {
+		return jobs, err
+	}
+
+	return jobs, nil
+}
+
+func (queue *Queue) Requeue(job *Job) error {
+	_, err := queue.database.Connection.Update(job)
+	if err != nil {
+		if queue.lost("requeue", job, err) {
+			return nil
 		}
+		return queue.report("requeue", err)
 	}
+
+	return nil
 }
 
 func (queue *Queue) Len() (int, error) {
@@ -85,8 +129,22 @@ func (queue *Queue) Len() (int, error) {
 	return int(length), err
 }
 
+// Close stops the queue from reserving further jobs. Jobs that were reserved
+// but not yet handed to a worker are released so they can be picked up again
+// right away.
 func (queue *Queue) Close() {
-	queue.closed = true
+	queue.closeOnce.Do(func() {
+		close(queue.done)
+	})
+}
+
+func (queue *Queue) isClosed() bool {
+	select {
+	case <-queue.done:
+		return true
+	default:
+		return false
+	}
 }
 
 func (queue *Queue) Reserve(workerID string) <-chan *Job {
@@ -102,53 +160,103 @@ func (queue *Queue) reserve(channel chan *Job, workerID string) {
 		var err error
 
 		job = queue.findJob()
-		if queue.closed {
+		if job == nil {
 			return
 		}
 
 		job, err = queue.updateJob(job, workerID)
 		if err != nil {
-			if _, ok := err.(gorp.OptimisticLockError); ok {
-				job = nil
-				continue
-			} else {
-				panic(err)
+			if _, ok := err.(gorp.OptimisticLockError); !ok {
+				queue.report("reserve", err)
+				queue.waitUpTo(queue.config.WaitMaxDuration)
 			}
+			job = nil
 		}
 	}
 
-	if queue.closed {
-		queue.updateJob(job, "")
-		return
+	select {
+	case channel <- job:
+	case <-queue.done:
+		if _, err := queue.updateJob(job, ""); err != nil {
+			queue.report("release", err)
+		}
 	}
-
-	channel <- job
 }
 
-func (queue *Queue) Dequeue(job *Job) {
+func (queue *Queue) Dequeue(job *Job) error {
 	_, err := queue.database.Connection.Delete(job)
 	if err != nil {
-		if _, ok := err.(gorp.OptimisticLockError); ok && strings.Contains(err.Error(), "no row found") {
-			return
+		if queue.lost("dequeue", job, err) {
+			return nil
 		}
-		panic(err)
+		return queue.report("dequeue", err)
 	}
+
+	return nil
+}
+
+func (queue *Queue) DeadLetter(job *Job) error {
+	transaction, err := queue.database.Connection.Begin()
+	if err != nil {
+		return queue.report("dead-letter", err)
+	}
+
+	_, err = transaction.Delete(job)
+	if err != nil {
+		transaction.Rollback()
+		if queue.lost("dead-letter", job, err) {
+			return nil
+		}
+		return queue.report("dead-letter", err)
+	}
+
+	err = transaction.Insert(NewDeadLetter(job, queue.clock.Now()))
+	if err != nil {
+		transaction.Rollback()
+		return queue.report("dead-letter", err)
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return queue.report("dead-letter", err)
+	}
+
+	return nil
 }
 
 func (queue *Queue) findJob() *Job {
 	var job *Job
 	for job == nil {
-		job = &Job{}
+		if queue.isClosed() {
+			return nil
+		}
+
 		now := time.Now()
 		expired := now.Add(-2 * time.Minute)
-		err := queue.database.Connection.SelectOne(job, "SELECT * FROM `jobs` WHERE ( `worker_id` = \"\" AND `active_at` <= ? ) OR `active_at` <= ? LIMIT 1", now, expired)
+
+		var candidates []candidate
+		_, err := queue.database.Connection.Select(&candidates, "SELECT `client_id`, MAX(`priority`) AS `priority` FROM `jobs` WHERE `priority` >= ? AND ( ( `worker_id` = \"\" AND `active_at` <= ? ) OR `active_at` <= ? ) GROUP BY `client_id`", queue.config.MinPriority, now, expired)
 		if err != nil {
-			if err == sql.ErrNoRows {
-				job = nil
+			queue.report("find-job", err)
+			queue.waitUpTo(queue.config.WaitMaxDuration)
+			continue
+		}
+
+		if len(candidates) == 0 {
+			queue.waitUpTo(queue.config.WaitMaxDuration)
+			continue
+		}
+
+		next := queue.scheduler.next(candidates)
+
+		job = &Job{}
+		err = queue.database.Connection.SelectOne(job, "SELECT * FROM `jobs` WHERE `client_id` = ? AND `priority` = ? AND ( ( `worker_id` = \"\" AND `active_at` <= ? ) OR `active_at` <= ? ) ORDER BY `active_at`, `id` LIMIT 1", next.ClientID, next.Priority, now, expired)
+		if err != nil {
+			job = nil
+			if err != sql.ErrNoRows {
+				queue.report("find-job", err)
 				queue.waitUpTo(queue.config.WaitMaxDuration)
-				continue
 			}
-			panic(err)
 		}
 	}
 	return job
@@ -168,8 +276,45 @@ func (queue *Queue) updateJob(job *Job, workerID string) (*Job, error) {
 	return job, nil
 }
 
+// lost reports whether err means the job is no longer the worker's to finish,
+// either because it has already been removed or because its reservation
+// expired and another worker has since reserved it. Trying again can never
+// succeed, so the worker should move on.
+func (queue *Queue) lost(action string, job *Job, err error) bool {
+	if _, ok := err.(gorp.OptimisticLockError); !ok {
+		return false
+	}
+
+	queue.config.Logger.Info("job-lost", lager.Data{
+		"action": action,
+		"job_id": job.ID,
+		"reason": err.Error(),
+	})
+
+	return true
+}
+
+// Logger is the logger the queue reports through, shared with its workers.
+func (queue *Queue) Logger() lager.Logger {
+	return queue.config.Logger
+}
+
+// report records a database error encountered while working the queue so that
+// a transient outage shows up in the logs and metrics instead of crashing the
+// process.
+func (queue *Queue) report(action string, err error) error {
+	metrics.GetOrRegisterCounter("notifications.queue.errors", nil).Inc(1)
+	queue.config.Logger.Error("queue-errored", err, lager.Data{"action": action})
+
+	return err
+}
+
 func (queue *Queue) waitUpTo(max time.Duration) {
 	rand.Seed(time.Now().UnixNano())
 	waitTime := rand.Int63n(int64(max))
-	<-time.After(time.Duration(waitTime))
+
+	select {
+	case <-time.After(time.Duration(waitTime)):
+	case <-queue.done:
+	}
 }
@@ -1,6 +1,7 @@
 package gobble_test
 
 import (
+	"errors"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/db"
@@ -69,7 +70,7 @@ var _ = Describe("Queue", func() {
 			})
 
 			nilJob, err := queue.Enqueue(job, database.Connection)
-			Expect(err).NotTo(HaveOccurred())
+			Expect(err).To(MatchError(gobble.QueueFullError{Length: 1, MaxLength: 1}))
 
 			Expect(nilJob).To(BeNil())
 
@@ -107,6 +108,63 @@ var _ = Describe("Queue", func() {
 		})
 	})
 
+	Describe("EnqueueAll", func() {
+		It("inserts all of the jobs using the connection that is passed in", func() {
+			jobs, err := queue.EnqueueAll([]*gobble.Job{
+				gobble.NewJob("first"),
+				gobble.NewJob("second"),
+			}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobs).To(HaveLen(2))
+
+			length, err := queue.Len()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(length).To(Equal(2))
+
+			for _, job := range jobs {
+				Expect(job.ActiveAt).To(Equal(clock.NowCall.Returns.Time))
+			}
+		})
+
+		It("rejects the whole batch when it does not fit in the queue", func() {
+			err := database.Connection.Insert(&gobble.Job{Payload: "something", ActiveAt: time.Now().UTC().Truncate(time.Second)})
+			Expect(err).NotTo(HaveOccurred())
+
+			queue = gobble.NewQueue(database, clock, gobble.Config{
+				WaitMaxDuration: 50 * time.Millisecond,
+				MaxQueueLength:  2,
+			})
+
+			jobs, err := queue.EnqueueAll([]*gobble.Job{
+				gobble.NewJob("first"),
+				gobble.NewJob("second"),
+			}, database.Connection)
+			Expect(err).To(MatchError(gobble.QueueFullError{Length: 1, MaxLength: 2}))
+			Expect(jobs).To(BeNil())
+
+			length, err := queue.Len()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(length).To(Equal(1))
+		})
+
+		It("accepts a batch larger than the maximum length when the queue is empty", func() {
+			queue = gobble.NewQueue(database, clock, gobble.Config{
+				WaitMaxDuration: 50 * time.Millisecond,
+				MaxQueueLength:  1,
+			})
+
+			_, err := queue.EnqueueAll([]*gobble.Job{
+				gobble.NewJob("first"),
+				gobble.NewJob("second"),
+			}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			length, err := queue.Len()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(length).To(Equal(2))
+		})
+	})
+
 	Describe("Requeue", func() {
 		It("updates the queue in the database", func() {
 			job := gobble.NewJob(map[string]bool{
@@ -128,7 +186,7 @@ var _ = Describe("Queue", func() {
 			Expect(reloadedJob.RetryCount).To(Equal(5))
 		})
 
-		It("deletes the job if above max_queue_length", func() {
+		It("keeps the job even if above max_queue_length", func() {
 			queue = gobble.NewQueue(database, clock, gobble.Config{
 				WaitMaxDuration: 50 * time.Millisecond,
 				MaxQueueLength:  2,
@@ -154,10 +212,31 @@ var _ = Describe("Queue", func() {
 
 			reloadedJob := gobble.Job{}
 			err = database.Connection.SelectOne(&reloadedJob, "SELECT * FROM `jobs` where id = ?", job.ID)
-			Expect(err).To(HaveOccurred())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reloadedJob.RetryCount).To(Equal(5))
+
 			len, err := queue.Len()
 			Expect(err).ToNot(HaveOccurred())
-			Expect(len).To(Equal(1))
+			Expect(len).To(Equal(2))
+		})
+
+		It("leaves the job alone once another worker has reserved it", func() {
+			job, err := queue.Enqueue(&gobble.Job{}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			stale := *job
+			job.WorkerID = "another-worker"
+			_, err = database.Connection.Update(job)
+			Expect(err).NotTo(HaveOccurred())
+
+			stale.RetryCount = 5
+			Expect(queue.Requeue(&stale)).To(Succeed())
+
+			reloadedJob := gobble.Job{}
+			err = database.Connection.SelectOne(&reloadedJob, "SELECT * FROM `jobs` where id = ?", job.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reloadedJob.WorkerID).To(Equal("another-worker"))
+			Expect(reloadedJob.RetryCount).To(Equal(0))
 		})
 	})
 
@@ -233,6 +312,97 @@ var _ = Describe("Queue", func() {
 			Expect(job.ID).To(Equal(job2.ID))
 		})
 
+		Context("when several clients have jobs waiting", func() {
+			var reserve = func() *gobble.Job {
+				job := <-queue.Reserve("worker-id")
+				queue.Dequeue(job)
+				return job
+			}
+
+			It("serves critical jobs before any others", func() {
+				for i := 0; i < 3; i++ {
+					_, err := queue.Enqueue(&gobble.Job{ClientID: "big-client"}, database.Connection)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				critical, err := queue.Enqueue(&gobble.Job{
+					ClientID: "small-client",
+					Priority: gobble.PriorityCritical,
+				}, database.Connection)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(reserve().ID).To(Equal(critical.ID))
+			})
+
+			It("alternates between clients", func() {
+				for i := 0; i < 3; i++ {
+					_, err := queue.Enqueue(&gobble.Job{ClientID: "client-a"}, database.Connection)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				for i := 0; i < 3; i++ {
+					_, err := queue.Enqueue(&gobble.Job{ClientID: "client-b"}, database.Connection)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				var clients []string
+				for i := 0; i < 4; i++ {
+					clients = append(clients, reserve().ClientID)
+				}
+
+				Expect(clients).To(Equal([]string{"client-a", "client-b", "client-a", "client-b"}))
+			})
+
+			It("serves a client as many times in a row as its weight", func() {
+				queue = gobble.NewQueue(database, clock, gobble.Config{
+					WaitMaxDuration: 50 * time.Millisecond,
+					MaxQueueLength:  1000,
+					ClientWeights:   map[string]int{"client-a": 2},
+				})
+
+				for i := 0; i < 4; i++ {
+					_, err := queue.Enqueue(&gobble.Job{ClientID: "client-a"}, database.Connection)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = queue.Enqueue(&gobble.Job{ClientID: "client-b"}, database.Connection)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				var clients []string
+				for i := 0; i < 6; i++ {
+					clients = append(clients, reserve().ClientID)
+				}
+
+				Expect(clients).To(Equal([]string{"client-a", "client-a", "client-b", "client-a", "client-a", "client-b"}))
+			})
+		})
+
+		Context("when the queue has a minimum priority", func() {
+			BeforeEach(func() {
+				queue = gobble.NewQueue(database, clock, gobble.Config{
+					WaitMaxDuration: 50 * time.Millisecond,
+					MaxQueueLength:  1000,
+					MinPriority:     gobble.PriorityCritical,
+				})
+			})
+
+			It("does not reserve jobs below that priority", func() {
+				_, err := queue.Enqueue(&gobble.Job{Priority: gobble.PriorityDefault}, database.Connection)
+				Expect(err).NotTo(HaveOccurred())
+
+				Consistently(queue.Reserve("critical-worker")).ShouldNot(Receive())
+			})
+
+			It("reserves jobs at that priority", func() {
+				job, err := queue.Enqueue(&gobble.Job{Priority: gobble.PriorityCritical}, database.Connection)
+				Expect(err).NotTo(HaveOccurred())
+
+				var reservedJob *gobble.Job
+				Eventually(queue.Reserve("critical-worker")).Should(Receive(&reservedJob))
+				Expect(reservedJob.ID).To(Equal(job.ID))
+			})
+		})
+
 		Context("when the worker id is set", func() {
 			Context("when active_at is in the future", func() {
 				It("should not grab the job", func() {
@@ -291,6 +461,37 @@ var _ = Describe("Queue", func() {
 		})
 	})
 
+	Describe("Close", func() {
+		It("stops reserving jobs", func() {
+			queue.Close()
+
+			_, err := queue.Enqueue(&gobble.Job{}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			Consistently(queue.Reserve("worker-id")).ShouldNot(Receive())
+		})
+
+		It("releases jobs that were reserved but never handed to a worker", func() {
+			job, err := queue.Enqueue(&gobble.Job{}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			queue.Reserve("worker-id")
+
+			reserved := &gobble.Job{}
+			Eventually(func() (string, error) {
+				err := database.Connection.SelectOne(reserved, "SELECT * FROM `jobs` WHERE `id` = ?", job.ID)
+				return reserved.WorkerID, err
+			}).Should(Equal("worker-id"))
+
+			queue.Close()
+
+			Eventually(func() (string, error) {
+				err := database.Connection.SelectOne(reserved, "SELECT * FROM `jobs` WHERE `id` = ?", job.ID)
+				return reserved.WorkerID, err
+			}).Should(Equal(""))
+		})
+	})
+
 	Describe("Dequeue", func() {
 		It("deletes the job from the queue", func() {
 			job, err := queue.Enqueue(&gobble.Job{}, database.Connection)
@@ -323,6 +524,82 @@ var _ = Describe("Queue", func() {
 				queue.Dequeue(job)
 			}).NotTo(Panic())
 		})
+
+		It("leaves the job alone once another worker has reserved it", func() {
+			job, err := queue.Enqueue(&gobble.Job{}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			stale := *job
+			job.WorkerID = "another-worker"
+			_, err = database.Connection.Update(job)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.Dequeue(&stale)).To(Succeed())
+
+			results, err := database.Connection.Select(gobble.Job{}, "SELECT * FROM `jobs`")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+		})
+	})
+
+	Describe("DeadLetter", func() {
+		It("moves the job into the dead letters table", func() {
+			job, err := queue.Enqueue(&gobble.Job{
+				Payload:  "the-payload",
+				WorkerID: "worker-1",
+			}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			job.RetryCount = 5
+			job.Fail(errors.New("smtp is down"))
+
+			queue.DeadLetter(job)
+
+			results, err := database.Connection.Select(gobble.Job{}, "SELECT * FROM `jobs`")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(0))
+
+			deadLetter := gobble.DeadLetter{}
+			err = database.Connection.SelectOne(&deadLetter, "SELECT * FROM `dead_letters` WHERE `job_id` = ?", job.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deadLetter.Payload).To(Equal("the-payload"))
+			Expect(deadLetter.WorkerID).To(Equal("worker-1"))
+			Expect(deadLetter.RetryCount).To(Equal(5))
+			Expect(deadLetter.LastError).To(Equal("smtp is down"))
+			Expect(deadLetter.History()).To(HaveLen(1))
+		})
+
+		It("ignores jobs that have already been removed", func() {
+			job, err := queue.Enqueue(&gobble.Job{}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			queue.Dequeue(job)
+
+			Expect(func() {
+				queue.DeadLetter(job)
+			}).NotTo(Panic())
+
+			results, err := database.Connection.Select(gobble.DeadLetter{}, "SELECT * FROM `dead_letters`")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(0))
+		})
+
+		It("leaves the job alone once another worker has reserved it", func() {
+			job, err := queue.Enqueue(&gobble.Job{}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			stale := *job
+			job.WorkerID = "another-worker"
+			_, err = database.Connection.Update(job)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.DeadLetter(&stale)).To(Succeed())
+
+			results, err := database.Connection.Select(gobble.DeadLetter{}, "SELECT * FROM `dead_letters`")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(0))
+		})
 	})
 
 	Describe("Len", func() {
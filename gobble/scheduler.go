@@ -0,0 +1,78 @@
+package gobble
+
+import (
+	"sort"
+	"sync"
+)
+
+type candidate struct {
+	ClientID string `db:"client_id"`
+	Priority int    `db:"priority"`
+}
+
+// clientScheduler picks which client's job is reserved next. Only clients
+// with jobs at the highest ready priority are considered, and those are served
+// in weighted round-robin order so that one busy client cannot starve others.
+type clientScheduler struct {
+	mutex   sync.Mutex
+	weights map[string]int
+	current string
+	served  int
+}
+
+func newClientScheduler(weights map[string]int) *clientScheduler {
+	return &clientScheduler{
+		weights: weights,
+	}
+}
+
+func (scheduler *clientScheduler) next(candidates []candidate) candidate {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+
+	highest := candidates[0].Priority
+	for _, c := range candidates {
+		if c.Priority > highest {
+			highest = c.Priority
+		}
+	}
+
+	var eligible []candidate
+	for _, c := range candidates {
+		if c.Priority == highest {
+			eligible = append(eligible, c)
+		}
+	}
+
+	sort.Slice(eligible, func(i, j int) bool {
+		return eligible[i].ClientID < eligible[j].ClientID
+	})
+
+	for _, c := range eligible {
+		if c.ClientID == scheduler.current && scheduler.served < scheduler.weight(c.ClientID) {
+			scheduler.served++
+			return c
+		}
+	}
+
+	chosen := eligible[0]
+	for _, c := range eligible {
+		if c.ClientID > scheduler.current {
+			chosen = c
+			break
+		}
+	}
+
+	scheduler.current = chosen.ClientID
+	scheduler.served = 1
+
+	return chosen
+}
+
+func (scheduler *clientScheduler) weight(clientID string) int {
+	if weight, ok := scheduler.weights[clientID]; ok && weight > 0 {
+		return weight
+	}
+
+	return 1
+}
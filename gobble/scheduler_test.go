@@ -0,0 +1,83 @@
+package gobble_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("clientScheduler", func() {
+	serve := func(weights map[string]int, candidates []gobble.Candidate, count int) []string {
+		scheduler := gobble.NewClientScheduler(weights)
+
+		var served []string
+		for i := 0; i < count; i++ {
+			served = append(served, scheduler.Next(candidates).ClientID)
+		}
+
+		return served
+	}
+
+	It("only serves clients with jobs at the highest priority", func() {
+		candidates := []gobble.Candidate{
+			{ClientID: "client-a", Priority: gobble.PriorityDefault},
+			{ClientID: "client-b", Priority: gobble.PriorityCritical},
+			{ClientID: "client-c", Priority: gobble.PriorityDefault},
+		}
+
+		Expect(serve(nil, candidates, 3)).To(Equal([]string{"client-b", "client-b", "client-b"}))
+	})
+
+	It("serves clients in turn when they have no weights", func() {
+		candidates := []gobble.Candidate{
+			{ClientID: "client-c"},
+			{ClientID: "client-a"},
+			{ClientID: "client-b"},
+		}
+
+		Expect(serve(nil, candidates, 6)).To(Equal([]string{
+			"client-a", "client-b", "client-c",
+			"client-a", "client-b", "client-c",
+		}))
+	})
+
+	It("serves a client as many jobs in a row as its weight", func() {
+		candidates := []gobble.Candidate{
+			{ClientID: "client-a"},
+			{ClientID: "client-b"},
+			{ClientID: "client-c"},
+		}
+		weights := map[string]int{
+			"client-a": 3,
+			"client-c": 2,
+		}
+
+		Expect(serve(weights, candidates, 12)).To(Equal([]string{
+			"client-a", "client-a", "client-a", "client-b", "client-c", "client-c",
+			"client-a", "client-a", "client-a", "client-b", "client-c", "client-c",
+		}))
+	})
+
+	It("treats weights that are not positive as a weight of one", func() {
+		candidates := []gobble.Candidate{
+			{ClientID: "client-a"},
+			{ClientID: "client-b"},
+		}
+		weights := map[string]int{
+			"client-a": 0,
+			"client-b": -4,
+		}
+
+		Expect(serve(weights, candidates, 4)).To(Equal([]string{"client-a", "client-b", "client-a", "client-b"}))
+	})
+
+	It("moves on to the next client when the current one has no more jobs", func() {
+		scheduler := gobble.NewClientScheduler(map[string]int{"client-b": 5})
+
+		Expect(scheduler.Next([]gobble.Candidate{{ClientID: "client-a"}, {ClientID: "client-b"}}).ClientID).To(Equal("client-a"))
+		Expect(scheduler.Next([]gobble.Candidate{{ClientID: "client-a"}, {ClientID: "client-b"}}).ClientID).To(Equal("client-b"))
+		Expect(scheduler.Next([]gobble.Candidate{{ClientID: "client-a"}, {ClientID: "client-c"}}).ClientID).To(Equal("client-c"))
+		Expect(scheduler.Next([]gobble.Candidate{{ClientID: "client-a"}, {ClientID: "client-c"}}).ClientID).To(Equal("client-a"))
+	})
+})
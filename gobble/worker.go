@@ -2,7 +2,19 @@ package gobble
 
 import (
 	"fmt"
+	"math/rand"
 	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+	"github.com/rcrowley/go-metrics"
+)
+
+var (
+	RetryBaseDelay   = 100 * time.Millisecond
+	RetryMaxDelay    = 10 * time.Second
+	RetryMaxAttempts = 10
 )
 
 type heartbeater interface {
@@ -15,6 +27,7 @@ type Worker struct {
 	queue    QueueInterface
 	callback func(*Job)
 	beater   heartbeater
+	logger   lager.Logger
 	halt     chan bool
 }
 
@@ -24,6 +37,7 @@ func NewWorker(id int, queue QueueInterface, callback func(*Job), beater heartbe
 		queue:    queue,
 		callback: callback,
 		beater:   beater,
+		logger:   queue.Logger(),
 		halt:     make(chan bool),
 	}
 }
@@ -33,29 +47,109 @@ func (worker *Worker) Perform() int {
 	case job := <-worker.queue.Reserve(worker.ID):
 		go worker.beater.Beat(job)
 		defer worker.beater.Halt()
-		worker.callback(job)
+		worker.perform(job)
 
-		if job.ShouldRetry {
-			worker.queue.Requeue(job)
-		} else {
-			worker.queue.Dequeue(job)
-		}
+		worker.retry(job, func() error {
+			switch {
+			case job.ShouldRetry:
+				return worker.queue.Requeue(job)
+			case job.ShouldDeadLetter:
+				return worker.queue.DeadLetter(job)
+			default:
+				return worker.queue.Dequeue(job)
+			}
+		})
 		return 0
 	case <-worker.halt:
 		return 1
 	}
 }
 
+// perform runs the callback for the job. A job whose callback panics is given
+// up on and dead-lettered, so that it is not reserved again once its
+// reservation expires only to crash the next worker in the same way.
+func (worker *Worker) perform(job *Job) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err := fmt.Errorf("panic: %v", recovered)
+			worker.logger.Error("job-panicked", err, lager.Data{
+				"worker_id": worker.ID,
+				"job_id":    job.ID,
+				"stack":     string(debug.Stack()),
+			})
+			metrics.GetOrRegisterCounter("notifications.worker.panicked", nil).Inc(1)
+
+			job.Fail(err)
+			job.GiveUp()
+		}
+	}()
+
+	worker.callback(job)
+}
+
+// Work performs jobs until the worker is halted. A panic outside of a job's
+// callback does not take the worker down; it is restarted after a short delay.
 func (worker *Worker) Work() {
 	go func() {
-		for {
-			if worker.Perform() != 0 {
-				return
-			}
+		for attempt := 0; !worker.run(); attempt++ {
+			metrics.GetOrRegisterCounter("notifications.worker.restarted", nil).Inc(1)
+			<-time.After(backoff(attempt))
+		}
+	}()
+}
+
+func (worker *Worker) run() (halted bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			worker.logger.Error("worker-panicked", fmt.Errorf("panic: %v", recovered), lager.Data{
+				"worker_id": worker.ID,
+				"stack":     string(debug.Stack()),
+			})
+			halted = false
 		}
 	}()
+
+	for {
+		if worker.Perform() != 0 {
+			return true
+		}
+	}
 }
 
 func (worker *Worker) Halt() {
 	worker.halt <- true
 }
+
+// retry keeps finishing the job until the queue accepts the update, so that
+// a database blip does not leave the job to be delivered a second time. After
+// RetryMaxAttempts it gives up and leaves the job to be reserved again once
+// its reservation expires, rather than keeping the worker from other jobs.
+func (worker *Worker) retry(job *Job, operation func() error) {
+	for attempt := 1; ; attempt++ {
+		err := operation()
+		if err == nil {
+			return
+		}
+
+		if attempt >= RetryMaxAttempts {
+			worker.logger.Error("job-finish-abandoned", err, lager.Data{
+				"worker_id": worker.ID,
+				"job_id":    job.ID,
+				"attempts":  attempt,
+			})
+			metrics.GetOrRegisterCounter("notifications.worker.finish-abandoned", nil).Inc(1)
+			return
+		}
+
+		<-time.After(backoff(attempt - 1))
+	}
+}
+
+func backoff(attempt int) time.Duration {
+	delay := RetryMaxDelay
+	if attempt < 16 && RetryBaseDelay<<uint(attempt) < RetryMaxDelay {
+		delay = RetryBaseDelay << uint(attempt)
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
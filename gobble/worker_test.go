@@ -1,6 +1,8 @@
 package gobble_test
 
 import (
+	"errors"
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/gobble"
@@ -11,6 +13,8 @@ import (
 )
 
 type MockHeartbeater struct {
+	mutex sync.Mutex
+
 	BeatCall struct {
 		Receives struct {
 			Job gobble.Job
@@ -23,13 +27,33 @@ type MockHeartbeater struct {
 }
 
 func (b *MockHeartbeater) Beat(job *gobble.Job) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
 	b.BeatCall.Receives.Job = *job
 }
 
 func (b *MockHeartbeater) Halt() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
 	b.HaltCall.WasCalled = true
 }
 
+func (b *MockHeartbeater) BeatJobID() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.BeatCall.Receives.Job.ID
+}
+
+func (b *MockHeartbeater) Halted() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.HaltCall.WasCalled
+}
+
 var _ = Describe("Worker", func() {
 	var (
 		queue                 *gobble.Queue
@@ -97,6 +121,33 @@ var _ = Describe("Worker", func() {
 			Expect(retriedJob.ActiveAt).To(BeTemporally("~", time.Now().Add(1*time.Minute), 1*time.Minute))
 		})
 
+		It("moves jobs that have given up into the dead letters table", func() {
+			callback = func(job *gobble.Job) {
+				job.Fail(errors.New("smtp is down"))
+				job.GiveUp()
+			}
+			worker = gobble.NewWorker(1, queue, callback, heartbeater)
+
+			job, err := queue.Enqueue(&gobble.Job{
+				Payload: "the-payload",
+			}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			worker.Perform()
+
+			results, err := database.Connection.Select(gobble.Job{}, "SELECT * FROM `jobs`")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(0))
+
+			deadLetters := []gobble.DeadLetter{}
+			_, err = database.Connection.Select(&deadLetters, "SELECT * FROM `dead_letters`")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadLetters).To(HaveLen(1))
+			Expect(deadLetters[0].JobID).To(Equal(job.ID))
+			Expect(deadLetters[0].WorkerID).To(Equal(worker.ID))
+			Expect(deadLetters[0].LastError).To(Equal("smtp is down"))
+		})
+
 		It("heartbeats for job ownership while the job executes", func() {
 			job, err := queue.Enqueue(&gobble.Job{
 				Payload: "the-payload",
@@ -112,15 +163,99 @@ var _ = Describe("Worker", func() {
 
 			go worker.Perform()
 
-			Eventually(func() int {
-				return heartbeater.BeatCall.Receives.Job.ID
-			}).Should(Equal(job.ID))
+			Eventually(heartbeater.BeatJobID).Should(Equal(job.ID))
 
 			hold <- struct{}{}
 
-			Eventually(func() bool {
-				return heartbeater.HaltCall.WasCalled
-			}).Should(BeTrue())
+			Eventually(heartbeater.Halted).Should(BeTrue())
+		})
+	})
+
+	Context("when the queue returns errors", func() {
+		var (
+			mockQueue   *mocks.Queue
+			reserveChan chan *gobble.Job
+		)
+
+		BeforeEach(func() {
+			gobble.RetryBaseDelay = time.Millisecond
+			gobble.RetryMaxDelay = 5 * time.Millisecond
+			gobble.RetryMaxAttempts = 10
+
+			reserveChan = make(chan *gobble.Job, 1)
+			mockQueue = mocks.NewQueue()
+			mockQueue.ReserveCall.Returns.Chan = reserveChan
+		})
+
+		AfterEach(func() {
+			gobble.RetryBaseDelay = 100 * time.Millisecond
+			gobble.RetryMaxDelay = 10 * time.Second
+			gobble.RetryMaxAttempts = 10
+		})
+
+		It("keeps trying to finish the job until the queue recovers", func() {
+			mockQueue.DequeueCall.Returns.Error = errors.New("database is down")
+			mockQueue.DequeueCall.Hook = func() {
+				if mockQueue.DequeueCall.CallCount == 3 {
+					mockQueue.DequeueCall.Returns.Error = nil
+				}
+			}
+
+			worker = gobble.NewWorker(1, mockQueue, func(*gobble.Job) {}, heartbeater)
+			reserveChan <- &gobble.Job{ID: 42}
+
+			done := make(chan struct{})
+			go func() {
+				worker.Perform()
+				close(done)
+			}()
+
+			Eventually(done).Should(BeClosed())
+			Expect(mockQueue.DequeueCall.CallCount).To(Equal(3))
+			Expect(mockQueue.DequeueCall.Receives.Job.ID).To(Equal(42))
+		})
+
+		It("gives up finishing the job after RetryMaxAttempts so the worker can move on", func() {
+			gobble.RetryMaxAttempts = 4
+			mockQueue.DequeueCall.Returns.Error = errors.New("database is down")
+
+			worker = gobble.NewWorker(1, mockQueue, func(*gobble.Job) {}, heartbeater)
+			reserveChan <- &gobble.Job{ID: 42}
+
+			done := make(chan struct{})
+			go func() {
+				worker.Perform()
+				close(done)
+			}()
+
+			Eventually(done).Should(BeClosed())
+			Expect(mockQueue.DequeueCall.CallCount).To(Equal(4))
+		})
+
+		It("dead-letters a job whose callback panics and keeps working", func() {
+			performed := make(chan int, 2)
+			callback = func(job *gobble.Job) {
+				performed <- job.ID
+				if job.ID == 1 {
+					panic("something went wrong")
+				}
+			}
+
+			worker = gobble.NewWorker(1, mockQueue, callback, heartbeater)
+			worker.Work()
+
+			reserveChan <- &gobble.Job{ID: 1}
+			Eventually(performed).Should(Receive(Equal(1)))
+
+			reserveChan <- &gobble.Job{ID: 2}
+			Eventually(performed).Should(Receive(Equal(2)))
+
+			worker.Halt()
+
+			deadLettered := mockQueue.DeadLetterCall.Receives.Job
+			Expect(deadLettered.ID).To(Equal(1))
+			Expect(deadLettered.ShouldRetry).To(BeFalse())
+			Expect(deadLettered.LastError()).To(Equal("panic: something went wrong"))
 		})
 	})
 
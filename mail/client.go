@@ -40,6 +40,7 @@ type Config struct {
 	DisableTLS        bool
 	ConnectTimeout    time.Duration
 	LoggingEnabled    bool
+	DKIMSigner        *DKIMSigner
 }
 
 type connection struct {
@@ -122,55 +123,73 @@ func (c *Client) Send(msg Message, logger lager.Logger) error {
 		return c.Error(logger, err)
 	}
 
-	c.PrintLog(logger, "hello-initiating")
-	err = c.Hello()
+	err = c.Handshake(logger)
+	if err != nil {
+		return c.Error(logger, err)
+	}
+
+	err = c.Deliver(msg, logger)
+	if err != nil {
+		return c.Error(logger, err)
+	}
+
+	c.PrintLog(logger, "quiting")
+	err = c.Quit()
 	if err != nil {
 		return c.Error(logger, err)
 	}
+	c.PrintLog(logger, "disconnected")
+
+	return nil
+}
+
+func (c *Client) Handshake(logger lager.Logger) error {
+	c.PrintLog(logger, "hello-initiating")
+	err := c.Hello()
+	if err != nil {
+		return err
+	}
 	c.PrintLog(logger, "hello-complete")
 
 	if !c.config.DisableTLS {
 		c.PrintLog(logger, "tls-starting")
 		err = c.StartTLS()
 		if err != nil {
-			return c.Error(logger, err)
+			return err
 		}
 		c.PrintLog(logger, "tls-connected")
 
 		c.PrintLog(logger, "authentication-starting")
 		err = c.Auth(logger)
 		if err != nil {
-			return c.Error(logger, err)
+			return err
 		}
 		c.PrintLog(logger, "authenticated")
 	}
 
+	return nil
+}
+
+func (c *Client) Deliver(msg Message, logger lager.Logger) error {
 	c.PrintLog(logger, "setting-msg-from", lager.Data{"from": msg.From})
-	err = c.client.Mail(msg.From)
+	err := c.client.Mail(msg.From)
 	if err != nil {
-		return c.Error(logger, err)
+		return err
 	}
 
 	c.PrintLog(logger, "setting-msg-to", lager.Data{"to": msg.To})
 	err = c.client.Rcpt(msg.To)
 	if err != nil {
-		return c.Error(logger, err)
+		return RecipientError{Recipient: msg.To, Err: err}
 	}
 
 	c.PrintLog(logger, "setting-msg-data", lager.Data{"message-data": base64.StdEncoding.EncodeToString([]byte(msg.Data()))})
 	err = c.Data(msg)
 	if err != nil {
-		return c.Error(logger, err)
+		return err
 	}
 	c.PrintLog(logger, "msg-data-sent")
 
-	c.PrintLog(logger, "quiting")
-	err = c.Quit()
-	if err != nil {
-		return c.Error(logger, err)
-	}
-	c.PrintLog(logger, "disconnected")
-
 	return nil
 }
 
@@ -234,7 +253,15 @@ func (c *Client) Data(msg Message) error {
 		return err
 	}
 
-	data := strings.Replace(string(msg.Data()), "%", "%%", -1)
+	data := msg.Data()
+	if c.config.DKIMSigner != nil {
+		data, err = c.config.DKIMSigner.Sign(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	data = strings.Replace(data, "%", "%%", -1)
 	_, err = fmt.Fprintf(wc, data)
 	if err != nil {
 		return err
@@ -248,6 +275,25 @@ func (c *Client) Data(msg Message) error {
 	return nil
 }
 
+func (c *Client) Reset() error {
+	return c.client.Reset()
+}
+
+func (c *Client) Noop() error {
+	return c.client.Noop()
+}
+
+func (c *Client) Close() error {
+	if c.client == nil {
+		return nil
+	}
+
+	err := c.client.Close()
+	c.client = nil
+
+	return err
+}
+
 func (c *Client) Quit() error {
 	err := c.client.Quit()
 	c.client = nil
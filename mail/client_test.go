@@ -2,7 +2,11 @@ package mail_test
 
 import (
 	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"net"
 	"net/smtp"
@@ -207,6 +211,61 @@ var _ = Describe("Mail", func() {
 			Expect(delivery.UsedTLS).To(BeTrue())
 		})
 
+		It("signs the message when configured with a DKIM signer", func() {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+
+			config.DKIMSigner, err = mail.NewDKIMSigner(mail.DKIMConfig{
+				Domain:     "example.com",
+				Selector:   "notifications",
+				PrivateKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			client = mail.NewClient(config)
+
+			msg := mail.Message{
+				From:    "me@example.com",
+				To:      "you@example.com",
+				Subject: "Urgent! Read now!",
+				Body: []mail.Part{
+					{
+						ContentType: "text/plain",
+						Content:     "This email is signed.",
+					},
+				},
+			}
+
+			err = client.Send(msg, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() int {
+				return len(mailServer.Deliveries)
+			}).Should(Equal(1))
+			delivery := mailServer.Deliveries[0]
+
+			Expect(delivery.Data[0]).To(HavePrefix("DKIM-Signature: v=1; a=rsa-sha256; c=relaxed/relaxed; d=example.com; s=notifications;"))
+			Expect(delivery.Data[4:]).To(Equal(strings.Split(msg.Data(), "\n")))
+		})
+
+		It("returns a permanent recipient error when the server rejects the recipient", func() {
+			mailServer.RcptReply = "550 5.1.1 mailbox unavailable"
+
+			err := client.Send(mail.Message{From: "me@example.com", To: "nobody@example.com"}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(err).To(BeAssignableToTypeOf(mail.RecipientError{}))
+			Expect(err.Error()).To(Equal(`550 "5.1.1 mailbox unavailable"`))
+			Expect(mail.IsRecipientRejected(err)).To(BeTrue())
+		})
+
+		It("returns a transient recipient error when the server defers the recipient", func() {
+			mailServer.RcptReply = "451 4.7.1 try again later"
+
+			err := client.Send(mail.Message{From: "me@example.com", To: "you@example.com"}, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(mail.IsTransientFailure(err)).To(BeTrue())
+			Expect(mail.IsRecipientRejected(err)).To(BeFalse())
+		})
+
 		It("can make multiple requests", func() {
 			firstMsg := mail.Message{
 				From:    "me@example.com",
@@ -0,0 +1,225 @@
+package mail
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	DKIMAlgorithmRSASHA256     = "rsa-sha256"
+	DKIMAlgorithmED25519SHA256 = "ed25519-sha256"
+)
+
+var DKIMSignedHeaders = []string{
+	"From",
+	"Reply-To",
+	"To",
+	"Subject",
+	"Date",
+	"Mime-Version",
+	"Content-Type",
+	"Content-Transfer-Encoding",
+	"List-Unsubscribe",
+	"List-Unsubscribe-Post",
+}
+
+type DKIMConfig struct {
+	Domain     string
+	Selector   string
+	PrivateKey []byte
+}
+
+type DKIMSigner struct {
+	domain    string
+	selector  string
+	algorithm string
+	key       crypto.Signer
+}
+
+func NewDKIMSigner(config DKIMConfig) (*DKIMSigner, error) {
+	if config.Domain == "" || config.Selector == "" {
+		return nil, errors.New("DKIM signing requires a domain and a selector")
+	}
+
+	key, err := parseDKIMPrivateKey(config.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := &DKIMSigner{
+		domain:   config.Domain,
+		selector: config.Selector,
+		key:      key,
+	}
+
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		signer.algorithm = DKIMAlgorithmRSASHA256
+	case ed25519.PrivateKey:
+		signer.algorithm = DKIMAlgorithmED25519SHA256
+	default:
+		return nil, fmt.Errorf("DKIM private key type %T is not supported, use an RSA or Ed25519 key", key)
+	}
+
+	return signer, nil
+}
+
+func parseDKIMPrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("DKIM private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("DKIM private key could not be parsed: %s", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("DKIM private key type %T is not supported, use an RSA or Ed25519 key", key)
+	}
+
+	return signer, nil
+}
+
+func (s DKIMSigner) Algorithm() string {
+	return s.algorithm
+}
+
+// Sign prepends a DKIM-Signature header to the rendered message using
+// relaxed/relaxed canonicalization. Lines may end in either LF or CRLF.
+func (s DKIMSigner) Sign(data string) (string, error) {
+	header, body := splitMessage(data)
+	fields := splitHeaderFields(header)
+
+	bodyHash := sha256.Sum256([]byte(canonicalizeBodyRelaxed(body)))
+
+	var names []string
+	var signedFields []string
+	for _, name := range DKIMSignedHeaders {
+		if field, ok := findHeaderField(fields, name); ok {
+			names = append(names, strings.ToLower(name))
+			signedFields = append(signedFields, canonicalizeHeaderRelaxed(field)+"\r\n")
+		}
+	}
+
+	signatureField := fmt.Sprintf("DKIM-Signature: v=1; a=%s; c=relaxed/relaxed; d=%s; s=%s;\n h=%s;\n bh=%s;\n b=",
+		s.algorithm, s.domain, s.selector, strings.Join(names, ":"), base64.StdEncoding.EncodeToString(bodyHash[:]))
+
+	digest := sha256.Sum256([]byte(strings.Join(signedFields, "") + canonicalizeHeaderRelaxed(signatureField)))
+
+	var opts crypto.SignerOpts = crypto.SHA256
+	if s.algorithm == DKIMAlgorithmED25519SHA256 {
+		opts = crypto.Hash(0)
+	}
+
+	signature, err := s.key.Sign(rand.Reader, digest[:], opts)
+	if err != nil {
+		return "", err
+	}
+
+	return signatureField + base64.StdEncoding.EncodeToString(signature) + "\n" + data, nil
+}
+
+func splitMessage(data string) (string, string) {
+	data = strings.Replace(data, "\r\n", "\n", -1)
+
+	if index := strings.Index(data, "\n\n"); index >= 0 {
+		return data[:index+1], data[index+2:]
+	}
+
+	return data, ""
+}
+
+func splitHeaderFields(header string) []string {
+	var fields []string
+	for _, line := range strings.SplitAfter(header, "\n") {
+		if line == "" {
+			continue
+		}
+
+		if (line[0] == ' ' || line[0] == '\t') && len(fields) > 0 {
+			fields[len(fields)-1] += line
+			continue
+		}
+
+		fields = append(fields, line)
+	}
+
+	return fields
+}
+
+func findHeaderField(fields []string, name string) (string, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		parts := strings.SplitN(fields[i], ":", 2)
+		if len(parts) == 2 && strings.EqualFold(strings.TrimSpace(parts[0]), name) {
+			return fields[i], true
+		}
+	}
+
+	return "", false
+}
+
+func canonicalizeHeaderRelaxed(field string) string {
+	parts := strings.SplitN(field, ":", 2)
+	name := strings.ToLower(strings.TrimRight(parts[0], " \t"))
+
+	var value string
+	if len(parts) == 2 {
+		value = strings.Replace(parts[1], "\r\n", "", -1)
+		value = strings.Replace(value, "\n", "", -1)
+		value = strings.TrimSpace(compressWhitespace(value))
+	}
+
+	return name + ":" + value
+}
+
+func canonicalizeBodyRelaxed(body string) string {
+	lines := strings.Split(strings.Replace(body, "\r\n", "\n", -1), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(compressWhitespace(line), " ")
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func compressWhitespace(s string) string {
+	var result strings.Builder
+	inWhitespace := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' {
+			if !inWhitespace {
+				result.WriteByte(' ')
+			}
+			inWhitespace = true
+			continue
+		}
+
+		inWhitespace = false
+		result.WriteRune(r)
+	}
+
+	return result.String()
+}
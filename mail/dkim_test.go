@@ -0,0 +1,140 @@
+package mail_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"regexp"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DKIMSigner", func() {
+	const data = "From: me@example.com\nTo:  you@example.com \nSubject: Hello\n  World\nX-Other: ignored\n\nHello  there \t\n\n\n"
+
+	var (
+		canonicalBody = "Hello there\r\n"
+		bodyHash      string
+	)
+
+	BeforeEach(func() {
+		sum := sha256.Sum256([]byte(canonicalBody))
+		bodyHash = base64.StdEncoding.EncodeToString(sum[:])
+	})
+
+	signatureOf := func(signed string) []byte {
+		matches := regexp.MustCompile(`\n b=([A-Za-z0-9+/=]+)\n`).FindStringSubmatch(signed)
+		Expect(matches).To(HaveLen(2))
+
+		signature, err := base64.StdEncoding.DecodeString(matches[1])
+		Expect(err).NotTo(HaveOccurred())
+
+		return signature
+	}
+
+	signedDigest := func(algorithm string) []byte {
+		canonical := "from:me@example.com\r\n" +
+			"to:you@example.com\r\n" +
+			"subject:Hello World\r\n" +
+			"dkim-signature:v=1; a=" + algorithm + "; c=relaxed/relaxed; d=example.com; s=selector; h=from:to:subject; bh=" + bodyHash + "; b="
+
+		sum := sha256.Sum256([]byte(canonical))
+		return sum[:]
+	}
+
+	Context("with an RSA key", func() {
+		var key *rsa.PrivateKey
+
+		BeforeEach(func() {
+			var err error
+			key, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("prepends an rsa-sha256 signature over the relaxed canonical message", func() {
+			signer, err := mail.NewDKIMSigner(mail.DKIMConfig{
+				Domain:     "example.com",
+				Selector:   "selector",
+				PrivateKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signer.Algorithm()).To(Equal(mail.DKIMAlgorithmRSASHA256))
+
+			signed, err := signer.Sign(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signed).To(HavePrefix("DKIM-Signature: v=1; a=rsa-sha256; c=relaxed/relaxed; d=example.com; s=selector;\n h=from:to:subject;\n bh=" + bodyHash + ";\n b="))
+			Expect(signed).To(HaveSuffix("\n" + data))
+
+			err = rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, signedDigest(mail.DKIMAlgorithmRSASHA256), signatureOf(signed))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("accepts PKCS8 encoded keys", func() {
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			Expect(err).NotTo(HaveOccurred())
+
+			signer, err := mail.NewDKIMSigner(mail.DKIMConfig{
+				Domain:     "example.com",
+				Selector:   "selector",
+				PrivateKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signer.Algorithm()).To(Equal(mail.DKIMAlgorithmRSASHA256))
+		})
+	})
+
+	Context("with an Ed25519 key", func() {
+		It("prepends an ed25519-sha256 signature over the relaxed canonical message", func() {
+			publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			signer, err := mail.NewDKIMSigner(mail.DKIMConfig{
+				Domain:     "example.com",
+				Selector:   "selector",
+				PrivateKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signer.Algorithm()).To(Equal(mail.DKIMAlgorithmED25519SHA256))
+
+			signed, err := signer.Sign(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signed).To(HavePrefix("DKIM-Signature: v=1; a=ed25519-sha256;"))
+
+			Expect(ed25519.Verify(publicKey, signedDigest(mail.DKIMAlgorithmED25519SHA256), signatureOf(signed))).To(BeTrue())
+		})
+	})
+
+	Context("when the configuration is invalid", func() {
+		It("requires a domain and selector", func() {
+			_, err := mail.NewDKIMSigner(mail.DKIMConfig{Selector: "selector"})
+			Expect(err).To(MatchError("DKIM signing requires a domain and a selector"))
+		})
+
+		It("requires a PEM encoded key", func() {
+			_, err := mail.NewDKIMSigner(mail.DKIMConfig{Domain: "example.com", Selector: "selector", PrivateKey: []byte("not a key")})
+			Expect(err).To(MatchError("DKIM private key is not PEM encoded"))
+		})
+
+		It("rejects keys it cannot parse", func() {
+			_, err := mail.NewDKIMSigner(mail.DKIMConfig{
+				Domain:     "example.com",
+				Selector:   "selector",
+				PrivateKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("garbage")}),
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(strings.HasPrefix(err.Error(), "DKIM private key could not be parsed")).To(BeTrue())
+		})
+	})
+})
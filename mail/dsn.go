@@ -0,0 +1,170 @@
+package mail
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+const NotificationIDHeader = "X-CF-Notification-ID"
+
+// DSN is a delivery status notification as described in RFC 3464.
+type DSN struct {
+	MessageID  string
+	Recipients []DSNRecipient
+}
+
+type DSNRecipient struct {
+	Recipient      string
+	Action         string
+	Status         string
+	DiagnosticCode string
+}
+
+func (r DSNRecipient) IsFailure() bool {
+	return strings.EqualFold(r.Action, "failed")
+}
+
+func (r DSNRecipient) IsPermanentFailure() bool {
+	return r.IsFailure() && strings.HasPrefix(r.Status, "5")
+}
+
+// Reason describes the failure, preferring the remote server's diagnostic.
+func (r DSNRecipient) Reason() string {
+	if r.DiagnosticCode != "" {
+		return r.DiagnosticCode
+	}
+
+	return r.Status
+}
+
+type DSNParseError struct {
+	Err error
+}
+
+func (e DSNParseError) Error() string {
+	return fmt.Sprintf("delivery status notification could not be parsed: %s", e.Err)
+}
+
+func ParseDSN(reader io.Reader) (DSN, error) {
+	message, err := mail.ReadMessage(reader)
+	if err != nil {
+		return DSN{}, DSNParseError{err}
+	}
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil {
+		return DSN{}, DSNParseError{err}
+	}
+
+	if mediaType != "multipart/report" || !strings.EqualFold(params["report-type"], "delivery-status") {
+		return DSN{}, DSNParseError{fmt.Errorf("expected a multipart/report with report-type=delivery-status, got %q", mediaType)}
+	}
+
+	var dsn DSN
+	var foundStatus bool
+
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return DSN{}, DSNParseError{err}
+		}
+
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+
+		switch contentType {
+		case "message/delivery-status":
+			dsn.Recipients, err = parseDeliveryStatus(part)
+			if err != nil {
+				return DSN{}, DSNParseError{err}
+			}
+			foundStatus = true
+		case "message/rfc822", "text/rfc822-headers":
+			header, err := readHeaderBlock(part)
+			if err != nil {
+				return DSN{}, DSNParseError{err}
+			}
+			dsn.MessageID = strings.TrimSpace(header.Get(NotificationIDHeader))
+		}
+	}
+
+	if !foundStatus {
+		return DSN{}, DSNParseError{errors.New("missing message/delivery-status part")}
+	}
+
+	return dsn, nil
+}
+
+func parseDeliveryStatus(reader io.Reader) ([]DSNRecipient, error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := strings.Split(strings.Replace(string(data), "\r\n", "\n", -1), "\n\n")
+
+	var recipients []DSNRecipient
+	// The per-message block and any stray blank lines are skipped: a
+	// per-recipient block is the one that carries a Final-Recipient or an
+	// Action field, wherever it appears.
+	for _, block := range blocks {
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+
+		header, err := readHeaderBlock(strings.NewReader(block))
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Get("Final-Recipient") == "" && header.Get("Action") == "" {
+			continue
+		}
+
+		recipient := header.Get("Final-Recipient")
+		if recipient == "" {
+			recipient = header.Get("Original-Recipient")
+		}
+
+		recipients = append(recipients, DSNRecipient{
+			Recipient:      stripAddressType(recipient),
+			Action:         strings.ToLower(strings.TrimSpace(header.Get("Action"))),
+			Status:         strings.TrimSpace(header.Get("Status")),
+			DiagnosticCode: stripAddressType(header.Get("Diagnostic-Code")),
+		})
+	}
+
+	return recipients, nil
+}
+
+func readHeaderBlock(reader io.Reader) (textproto.MIMEHeader, error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	data = append(bytes.TrimLeft(data, "\r\n"), "\r\n\r\n"...)
+
+	return textproto.NewReader(bufio.NewReader(bytes.NewReader(data))).ReadMIMEHeader()
+}
+
+// stripAddressType drops the "rfc822;" or "smtp;" type prefix from a DSN field.
+func stripAddressType(value string) string {
+	if index := strings.Index(value, ";"); index >= 0 {
+		value = value[index+1:]
+	}
+
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,120 @@
+package mail_test
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const bounceDSN = "From: MAILER-DAEMON@example.com\r\n" +
+	"To: no-reply@notifications.example.com\r\n" +
+	"Subject: Undelivered Mail Returned to Sender\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/report; report-type=delivery-status; boundary=\"BOUNDARY\"\r\n" +
+	"\r\n" +
+	"--BOUNDARY\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"\r\n" +
+	"Your message could not be delivered.\r\n" +
+	"--BOUNDARY\r\n" +
+	"Content-Type: message/delivery-status\r\n" +
+	"\r\n" +
+	"Reporting-MTA: dns; mx.example.com\r\n" +
+	"\r\n" +
+	"Final-Recipient: rfc822; gone@example.com\r\n" +
+	"Action: failed\r\n" +
+	"Status: 5.1.1\r\n" +
+	"Diagnostic-Code: smtp; 550 5.1.1 mailbox unavailable\r\n" +
+	"\r\n" +
+	"Final-Recipient: rfc822; slow@example.com\r\n" +
+	"Action: delayed\r\n" +
+	"Status: 4.4.1\r\n" +
+	"\r\n" +
+	"--BOUNDARY\r\n" +
+	"Content-Type: text/rfc822-headers\r\n" +
+	"\r\n" +
+	"X-CF-Client-ID: some-client\r\n" +
+	"X-CF-Notification-ID: some-message-id\r\n" +
+	"Subject: Hello\r\n" +
+	"--BOUNDARY--\r\n"
+
+var _ = Describe("ParseDSN", func() {
+	It("parses the recipients and the notification ID of the original message", func() {
+		dsn, err := mail.ParseDSN(strings.NewReader(bounceDSN))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(dsn.MessageID).To(Equal("some-message-id"))
+		Expect(dsn.Recipients).To(Equal([]mail.DSNRecipient{
+			{
+				Recipient:      "gone@example.com",
+				Action:         "failed",
+				Status:         "5.1.1",
+				DiagnosticCode: "550 5.1.1 mailbox unavailable",
+			},
+			{
+				Recipient: "slow@example.com",
+				Action:    "delayed",
+				Status:    "4.4.1",
+			},
+		}))
+	})
+
+	It("finds the recipients when the delivery status has stray blank lines", func() {
+		message := strings.Replace(bounceDSN, "Reporting-MTA", "\r\n\r\nReporting-MTA", 1)
+		message = strings.Replace(message, "Final-Recipient: rfc822; slow", "\r\n\r\nFinal-Recipient: rfc822; slow", 1)
+
+		dsn, err := mail.ParseDSN(strings.NewReader(message))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(dsn.Recipients).To(Equal([]mail.DSNRecipient{
+			{
+				Recipient:      "gone@example.com",
+				Action:         "failed",
+				Status:         "5.1.1",
+				DiagnosticCode: "550 5.1.1 mailbox unavailable",
+			},
+			{
+				Recipient: "slow@example.com",
+				Action:    "delayed",
+				Status:    "4.4.1",
+			},
+		}))
+	})
+
+	It("reads the notification ID from a returned message/rfc822 part", func() {
+		message := strings.Replace(bounceDSN, "text/rfc822-headers", "message/rfc822", 1)
+		message = strings.Replace(message, "Subject: Hello\r\n", "Subject: Hello\r\n\r\nHello there\r\n", 1)
+
+		dsn, err := mail.ParseDSN(strings.NewReader(message))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dsn.MessageID).To(Equal("some-message-id"))
+	})
+
+	It("classifies recipient failures", func() {
+		dsn, err := mail.ParseDSN(strings.NewReader(bounceDSN))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(dsn.Recipients[0].IsFailure()).To(BeTrue())
+		Expect(dsn.Recipients[0].IsPermanentFailure()).To(BeTrue())
+		Expect(dsn.Recipients[0].Reason()).To(Equal("550 5.1.1 mailbox unavailable"))
+
+		Expect(dsn.Recipients[1].IsFailure()).To(BeFalse())
+		Expect(dsn.Recipients[1].IsPermanentFailure()).To(BeFalse())
+		Expect(dsn.Recipients[1].Reason()).To(Equal("4.4.1"))
+	})
+
+	It("rejects messages that are not delivery status reports", func() {
+		_, err := mail.ParseDSN(strings.NewReader("Content-Type: text/plain\r\n\r\nhello\r\n"))
+		Expect(err).To(BeAssignableToTypeOf(mail.DSNParseError{}))
+	})
+
+	It("rejects reports without a delivery-status part", func() {
+		message := strings.Replace(bounceDSN, "message/delivery-status", "text/plain", 1)
+
+		_, err := mail.ParseDSN(strings.NewReader(message))
+		Expect(err).To(MatchError(ContainSubstring("missing message/delivery-status part")))
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -86,6 +87,11 @@ type SMTPServer struct {
 	halt            chan bool
 	ConnectionState string
 	FailsHello      bool
+	FailsNoop       bool
+	RcptReply       string
+	Connections     int
+	Resets          int
+	lock            sync.Mutex
 }
 
 type Delivery struct {
@@ -155,13 +161,21 @@ func (server *SMTPServer) Respond(conn net.Conn) {
 	<-time.After(server.ConnectWait)
 	server.ConnectionState = StateConnected
 
+	server.lock.Lock()
+	server.Connections++
+	server.lock.Unlock()
+
 	input := bufio.NewReader(conn)
 	output := bufio.NewWriter(conn)
 	server.Broadcast(output)
 
 Loop:
 	for {
-		msg, _ := input.ReadString('\n')
+		msg, err := input.ReadString('\n')
+		if err != nil {
+			return
+		}
+
 		switch {
 		case strings.Contains(msg, "EHLO"):
 			server.RespondToEHLO(output)
@@ -176,15 +190,64 @@ Loop:
 		case strings.Contains(msg, "DATA"):
 			server.RespondToData(output)
 			server.RecordData(output, input)
+		case strings.Contains(msg, "RSET"):
+			server.RespondToReset(output)
+		case strings.Contains(msg, "NOOP"):
+			server.RespondToNoop(output)
 		case strings.Contains(msg, "QUIT"):
 			server.RespondToQuit(output)
 			break Loop
 		}
 	}
+	server.RecordDelivery()
+}
+
+func (server *SMTPServer) RecordDelivery() {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
 	server.Deliveries = append(server.Deliveries, server.CurrentDelivery)
 	server.CurrentDelivery = Delivery{}
 }
 
+func (server *SMTPServer) ConnectionCount() int {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	return server.Connections
+}
+
+func (server *SMTPServer) DeliveryCount() int {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	return len(server.Deliveries)
+}
+
+func (server *SMTPServer) RespondToReset(output *bufio.Writer) {
+	server.lock.Lock()
+	server.Resets++
+	server.lock.Unlock()
+
+	if len(server.CurrentDelivery.Data) > 0 {
+		server.RecordDelivery()
+	}
+
+	output.WriteString("250 OK\r\n")
+	output.Flush()
+}
+
+func (server *SMTPServer) RespondToNoop(output *bufio.Writer) {
+	if server.FailsNoop {
+		output.WriteString("421 Service not available\r\n")
+		output.Flush()
+		return
+	}
+
+	output.WriteString("250 OK\r\n")
+	output.Flush()
+}
+
 func (server *SMTPServer) Broadcast(output *bufio.Writer) {
 	output.WriteString("220 localhost\r\n")
 	output.Flush()
@@ -246,6 +309,12 @@ func (server *SMTPServer) RespondToRcptTo(output *bufio.Writer, msg string) {
 	recipient = strings.Trim(recipient, "<>")
 	server.CurrentDelivery.Recipient = recipient
 
+	if server.RcptReply != "" {
+		output.WriteString(server.RcptReply + "\r\n")
+		output.Flush()
+		return
+	}
+
 	output.WriteString("250 OK\r\n")
 	output.Flush()
 }
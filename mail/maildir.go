@@ -0,0 +1,89 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+)
+
+var maildirDeliveries uint64
+
+type MaildirConfig struct {
+	Path string
+}
+
+type MaildirTransport struct {
+	path     string
+	hostname string
+}
+
+func NewMaildirTransport(config MaildirConfig) (*MaildirTransport, error) {
+	if config.Path == "" {
+		return nil, errors.New("maildir transport requires a path")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	return &MaildirTransport{
+		path:     config.Path,
+		hostname: hostname,
+	}, nil
+}
+
+func (t *MaildirTransport) Connect(logger lager.Logger) error {
+	for _, dir := range []string{"tmp", "new", "cur"} {
+		err := os.MkdirAll(filepath.Join(t.path, dir), 0700)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *MaildirTransport) Send(msg Message, logger lager.Logger) error {
+	logger = logger.Session("maildir")
+
+	err := t.Connect(logger)
+	if err != nil {
+		logger.Error("failed", err)
+		return err
+	}
+
+	filename := t.uniqueName()
+	tmpPath := filepath.Join(t.path, "tmp", filename)
+	newPath := filepath.Join(t.path, "new", filename)
+
+	err = ioutil.WriteFile(tmpPath, []byte(msg.Data()), 0600)
+	if err != nil {
+		logger.Error("failed", err)
+		return err
+	}
+
+	err = os.Rename(tmpPath, newPath)
+	if err != nil {
+		os.Remove(tmpPath)
+		logger.Error("failed", err)
+		return err
+	}
+
+	logger.Info("delivered", lager.Data{"path": newPath})
+
+	return nil
+}
+
+func (t *MaildirTransport) uniqueName() string {
+	now := time.Now()
+	count := atomic.AddUint64(&maildirDeliveries, 1)
+
+	return fmt.Sprintf("%d.M%dP%dQ%d.%s", now.Unix(), now.Nanosecond()/1000, os.Getpid(), count, t.hostname)
+}
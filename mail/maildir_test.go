@@ -0,0 +1,79 @@
+package mail_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/pivotal-golang/lager"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MaildirTransport", func() {
+	var (
+		transport *mail.MaildirTransport
+		path      string
+		logger    lager.Logger
+	)
+
+	BeforeEach(func() {
+		var err error
+		path, err = ioutil.TempDir("", "maildir")
+		Expect(err).NotTo(HaveOccurred())
+
+		logger = lager.NewLogger("notifications")
+
+		transport, err = mail.NewMaildirTransport(mail.MaildirConfig{Path: path})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(path)
+	})
+
+	It("requires a path", func() {
+		_, err := mail.NewMaildirTransport(mail.MaildirConfig{})
+		Expect(err).To(MatchError("maildir transport requires a path"))
+	})
+
+	It("creates the maildir structure on connect", func() {
+		Expect(transport.Connect(logger)).To(Succeed())
+
+		for _, dir := range []string{"tmp", "new", "cur"} {
+			info, err := os.Stat(filepath.Join(path, dir))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.IsDir()).To(BeTrue())
+		}
+	})
+
+	It("delivers each message into its own file in new", func() {
+		msg := mail.Message{
+			From:    "me@example.com",
+			To:      "you@example.com",
+			Subject: "Urgent! Read now!",
+			Body: []mail.Part{
+				{ContentType: "text/plain", Content: "This email is the most important thing you will read all day!"},
+			},
+		}
+
+		Expect(transport.Send(msg, logger)).To(Succeed())
+		Expect(transport.Send(msg, logger)).To(Succeed())
+
+		files, err := ioutil.ReadDir(filepath.Join(path, "new"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(2))
+
+		tmpFiles, err := ioutil.ReadDir(filepath.Join(path, "tmp"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tmpFiles).To(BeEmpty())
+
+		contents, err := ioutil.ReadFile(filepath.Join(path, "new", files[0].Name()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(ContainSubstring("To: you@example.com"))
+		Expect(string(contents)).To(ContainSubstring("Subject: Urgent! Read now!"))
+		Expect(string(contents)).To(ContainSubstring("This email is the most important thing you will read all day!"))
+	})
+})
@@ -0,0 +1,203 @@
+package mail
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+	"github.com/rcrowley/go-metrics"
+)
+
+type PoolConfig struct {
+	MaxConnections           int
+	IdleTimeout              time.Duration
+	MaxMessagesPerConnection int
+}
+
+type pooledConnection struct {
+	client   *Client
+	messages int
+	lastUsed time.Time
+}
+
+type Pool struct {
+	config     Config
+	poolConfig PoolConfig
+	slots      chan struct{}
+
+	mutex sync.Mutex
+	idle  []*pooledConnection
+	open  int
+}
+
+func NewPool(config Config, poolConfig PoolConfig) *Pool {
+	if poolConfig.MaxConnections < 1 {
+		poolConfig.MaxConnections = 1
+	}
+
+	if poolConfig.IdleTimeout == 0 {
+		poolConfig.IdleTimeout = 30 * time.Second
+	}
+
+	return &Pool{
+		config:     config,
+		poolConfig: poolConfig,
+		slots:      make(chan struct{}, poolConfig.MaxConnections),
+	}
+}
+
+func (p *Pool) Connect(logger lager.Logger) error {
+	return nil
+}
+
+func (p *Pool) Send(msg Message, logger lager.Logger) error {
+	logger = logger.Session("smtp")
+
+	if p.config.TestMode {
+		logger.Info("test-mode")
+		return nil
+	}
+
+	p.slots <- struct{}{}
+	defer func() { <-p.slots }()
+
+	conn, err := p.acquire(logger)
+	if err != nil {
+		logger.Error("failed", err)
+		return err
+	}
+
+	err = conn.client.Deliver(msg, logger)
+	if err != nil {
+		logger.Error("failed", err)
+		p.release(conn, logger)
+		return err
+	}
+
+	conn.messages++
+	p.release(conn, logger)
+
+	return nil
+}
+
+func (p *Pool) Close() {
+	p.mutex.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.mutex.Unlock()
+
+	for _, conn := range idle {
+		conn.client.Quit()
+		p.discard()
+	}
+}
+
+func (p *Pool) Stats() (open, idle int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.open, len(p.idle)
+}
+
+func (p *Pool) acquire(logger lager.Logger) (*pooledConnection, error) {
+	for {
+		conn := p.popIdle()
+		if conn == nil {
+			break
+		}
+
+		if time.Since(conn.lastUsed) > p.poolConfig.IdleTimeout {
+			conn.client.PrintLog(logger, "pool-connection-expired")
+			conn.client.Quit()
+			p.discard()
+			continue
+		}
+
+		err := conn.client.Noop()
+		if err != nil {
+			conn.client.PrintLog(logger, "pool-connection-unhealthy", lager.Data{"error": err.Error()})
+			conn.client.Close()
+			p.discard()
+			continue
+		}
+
+		metrics.GetOrRegisterCounter("notifications.smtp.pool.reused", nil).Inc(1)
+		return conn, nil
+	}
+
+	client := NewClient(p.config)
+	err := client.Connect(logger)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	err = client.Handshake(logger)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	p.open++
+	p.updateGauges()
+	p.mutex.Unlock()
+
+	metrics.GetOrRegisterCounter("notifications.smtp.pool.dialed", nil).Inc(1)
+
+	return &pooledConnection{client: client}, nil
+}
+
+func (p *Pool) release(conn *pooledConnection, logger lager.Logger) {
+	if p.poolConfig.MaxMessagesPerConnection > 0 && conn.messages >= p.poolConfig.MaxMessagesPerConnection {
+		conn.client.PrintLog(logger, "pool-connection-retired", lager.Data{"messages": conn.messages})
+		conn.client.Quit()
+		p.discard()
+		return
+	}
+
+	err := conn.client.Reset()
+	if err != nil {
+		conn.client.PrintLog(logger, "pool-connection-reset-failed", lager.Data{"error": err.Error()})
+		conn.client.Close()
+		p.discard()
+		return
+	}
+
+	conn.lastUsed = time.Now()
+
+	p.mutex.Lock()
+	p.idle = append(p.idle, conn)
+	p.updateGauges()
+	p.mutex.Unlock()
+}
+
+func (p *Pool) popIdle() *pooledConnection {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(p.idle) == 0 {
+		return nil
+	}
+
+	conn := p.idle[len(p.idle)-1]
+	p.idle = p.idle[:len(p.idle)-1]
+	p.updateGauges()
+
+	return conn
+}
+
+func (p *Pool) discard() {
+	p.mutex.Lock()
+	p.open--
+	p.updateGauges()
+	p.mutex.Unlock()
+
+	metrics.GetOrRegisterCounter("notifications.smtp.pool.discarded", nil).Inc(1)
+}
+
+func (p *Pool) updateGauges() {
+	metrics.GetOrRegisterGauge("notifications.smtp.pool.open", nil).Update(int64(p.open))
+	metrics.GetOrRegisterGauge("notifications.smtp.pool.idle", nil).Update(int64(len(p.idle)))
+	metrics.GetOrRegisterGauge("notifications.smtp.pool.in-use", nil).Update(int64(p.open - len(p.idle)))
+}
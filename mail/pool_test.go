@@ -0,0 +1,169 @@
+package mail_test
+
+import (
+	"net"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/pivotal-golang/lager"
+	"github.com/rcrowley/go-metrics"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pool", func() {
+	var (
+		mailServer *SMTPServer
+		pool       *mail.Pool
+		logger     lager.Logger
+		config     mail.Config
+		poolConfig mail.PoolConfig
+		msg        mail.Message
+	)
+
+	deliveredMessages := func() int {
+		mailServer.lock.Lock()
+		defer mailServer.lock.Unlock()
+
+		count := 0
+		for _, delivery := range mailServer.Deliveries {
+			if len(delivery.Data) > 0 {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	BeforeEach(func() {
+		var err error
+
+		logger = lager.NewLogger("notifications")
+		mailServer = NewSMTPServer("user", "pass")
+
+		config = mail.Config{
+			User:          "user",
+			Pass:          "pass",
+			SkipVerifySSL: true,
+			DisableTLS:    true,
+		}
+
+		config.Host, config.Port, err = net.SplitHostPort(mailServer.URL.Host)
+		Expect(err).NotTo(HaveOccurred())
+
+		poolConfig = mail.PoolConfig{
+			MaxConnections: 2,
+			IdleTimeout:    time.Minute,
+		}
+
+		msg = mail.Message{
+			From:    "me@example.com",
+			To:      "you@example.com",
+			Subject: "Urgent! Read now!",
+			Body: []mail.Part{
+				{ContentType: "text/plain", Content: "This email is the most important thing you will read all day!"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		pool = mail.NewPool(config, poolConfig)
+	})
+
+	AfterEach(func() {
+		pool.Close()
+		mailServer.Close()
+	})
+
+	It("reuses a single connection for sequential messages, resetting between them", func() {
+		Expect(pool.Send(msg, logger)).To(Succeed())
+		Expect(pool.Send(msg, logger)).To(Succeed())
+		Expect(pool.Send(msg, logger)).To(Succeed())
+
+		Expect(mailServer.ConnectionCount()).To(Equal(1))
+		Eventually(deliveredMessages).Should(Equal(3))
+		Expect(mailServer.Resets).To(Equal(3))
+
+		open, idle := pool.Stats()
+		Expect(open).To(Equal(1))
+		Expect(idle).To(Equal(1))
+	})
+
+	It("records pool metrics", func() {
+		Expect(pool.Send(msg, logger)).To(Succeed())
+
+		Expect(metrics.GetOrRegisterGauge("notifications.smtp.pool.open", nil).Value()).To(Equal(int64(1)))
+		Expect(metrics.GetOrRegisterGauge("notifications.smtp.pool.idle", nil).Value()).To(Equal(int64(1)))
+		Expect(metrics.GetOrRegisterGauge("notifications.smtp.pool.in-use", nil).Value()).To(Equal(int64(0)))
+	})
+
+	Context("when a connection has delivered the maximum number of messages", func() {
+		BeforeEach(func() {
+			poolConfig.MaxMessagesPerConnection = 2
+		})
+
+		It("retires the connection and dials a new one", func() {
+			Expect(pool.Send(msg, logger)).To(Succeed())
+			Expect(pool.Send(msg, logger)).To(Succeed())
+			Expect(pool.Send(msg, logger)).To(Succeed())
+
+			Eventually(mailServer.ConnectionCount).Should(Equal(2))
+			Eventually(deliveredMessages).Should(Equal(3))
+		})
+	})
+
+	Context("when an idle connection has expired", func() {
+		BeforeEach(func() {
+			poolConfig.IdleTimeout = time.Millisecond
+		})
+
+		It("closes it and dials a new one", func() {
+			Expect(pool.Send(msg, logger)).To(Succeed())
+			time.Sleep(10 * time.Millisecond)
+			Expect(pool.Send(msg, logger)).To(Succeed())
+
+			Eventually(mailServer.ConnectionCount).Should(Equal(2))
+			Eventually(deliveredMessages).Should(Equal(2))
+		})
+	})
+
+	Context("when an idle connection fails its health check", func() {
+		It("discards it and dials a new one", func() {
+			Expect(pool.Send(msg, logger)).To(Succeed())
+
+			mailServer.FailsNoop = true
+			Expect(pool.Send(msg, logger)).To(Succeed())
+
+			Eventually(mailServer.ConnectionCount).Should(Equal(2))
+
+			open, _ := pool.Stats()
+			Expect(open).To(Equal(1))
+		})
+	})
+
+	Context("when the server cannot be reached", func() {
+		BeforeEach(func() {
+			config.Port = "1"
+		})
+
+		It("returns the error without leaking a connection", func() {
+			Expect(pool.Send(msg, logger)).NotTo(Succeed())
+
+			open, idle := pool.Stats()
+			Expect(open).To(Equal(0))
+			Expect(idle).To(Equal(0))
+		})
+	})
+
+	Context("when in test mode", func() {
+		BeforeEach(func() {
+			config.TestMode = true
+		})
+
+		It("does not connect to the server", func() {
+			Expect(pool.Send(msg, logger)).To(Succeed())
+			Expect(mailServer.ConnectionCount()).To(Equal(0))
+		})
+	})
+})
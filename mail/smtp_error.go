@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"errors"
+	"net/textproto"
+)
+
+type RecipientError struct {
+	Recipient string
+	Err       error
+}
+
+func (e RecipientError) Error() string {
+	return e.Err.Error()
+}
+
+func (e RecipientError) Unwrap() error {
+	return e.Err
+}
+
+func SMTPReplyCode(err error) (int, bool) {
+	var protocolError *textproto.Error
+	if errors.As(err, &protocolError) {
+		return protocolError.Code, true
+	}
+
+	return 0, false
+}
+
+func IsPermanentFailure(err error) bool {
+	code, ok := SMTPReplyCode(err)
+	return ok && code >= 500 && code < 600
+}
+
+func IsTransientFailure(err error) bool {
+	code, ok := SMTPReplyCode(err)
+	return ok && code >= 400 && code < 500
+}
+
+// IsRecipientRejected reports whether the mail server permanently rejected the
+// recipient itself. Other permanent replies, such as to AUTH or MAIL FROM, are
+// about the session rather than the recipient and are worth retrying.
+func IsRecipientRejected(err error) bool {
+	var recipientError RecipientError
+	return errors.As(err, &recipientError) && IsPermanentFailure(err)
+}
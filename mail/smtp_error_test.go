@@ -0,0 +1,55 @@
+package mail_test
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SMTP reply classification", func() {
+	It("classifies 5xx replies as permanent failures", func() {
+		err := &textproto.Error{Code: 554, Msg: "5.7.1 message rejected"}
+
+		Expect(mail.IsPermanentFailure(err)).To(BeTrue())
+		Expect(mail.IsTransientFailure(err)).To(BeFalse())
+	})
+
+	It("classifies 4xx replies as transient failures", func() {
+		err := &textproto.Error{Code: 421, Msg: "4.3.2 service shutting down"}
+
+		Expect(mail.IsPermanentFailure(err)).To(BeFalse())
+		Expect(mail.IsTransientFailure(err)).To(BeTrue())
+	})
+
+	It("does not classify errors without a reply code", func() {
+		err := errors.New("server timeout")
+
+		_, ok := mail.SMTPReplyCode(err)
+		Expect(ok).To(BeFalse())
+		Expect(mail.IsPermanentFailure(err)).To(BeFalse())
+		Expect(mail.IsTransientFailure(err)).To(BeFalse())
+	})
+
+	It("finds the reply code through wrapped errors", func() {
+		err := fmt.Errorf("delivery failed: %w", &textproto.Error{Code: 550, Msg: "5.1.1 mailbox unavailable"})
+
+		code, ok := mail.SMTPReplyCode(err)
+		Expect(ok).To(BeTrue())
+		Expect(code).To(Equal(550))
+	})
+
+	It("only reports permanently rejected recipients as rejected", func() {
+		rejected := mail.RecipientError{Recipient: "nobody@example.com", Err: &textproto.Error{Code: 550, Msg: "5.1.1 mailbox unavailable"}}
+		deferred := mail.RecipientError{Recipient: "you@example.com", Err: &textproto.Error{Code: 450, Msg: "4.2.1 mailbox busy"}}
+		content := &textproto.Error{Code: 554, Msg: "5.7.1 message rejected"}
+
+		Expect(mail.IsRecipientRejected(rejected)).To(BeTrue())
+		Expect(mail.IsRecipientRejected(deferred)).To(BeFalse())
+		Expect(mail.IsRecipientRejected(content)).To(BeFalse())
+	})
+})
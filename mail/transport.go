@@ -0,0 +1,90 @@
+package mail
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pivotal-golang/lager"
+)
+
+const (
+	TransportSMTP    = "smtp"
+	TransportMaildir = "maildir"
+	TransportWebhook = "webhook"
+)
+
+type Transport interface {
+	Connect(lager.Logger) error
+	Send(Message, lager.Logger) error
+}
+
+type TransportConfig struct {
+	SMTP     Config
+	SMTPPool PoolConfig
+	Maildir  MaildirConfig
+	Webhook  WebhookConfig
+}
+
+type TransportFactory func(TransportConfig) (Transport, error)
+
+type UnknownTransportError struct {
+	Name string
+}
+
+func (e UnknownTransportError) Error() string {
+	return fmt.Sprintf("Unknown mail transport %q, it is not one of the registered transports: %+v", e.Name, TransportNames())
+}
+
+var transports = struct {
+	sync.RWMutex
+	factories map[string]TransportFactory
+}{
+	factories: map[string]TransportFactory{
+		TransportSMTP: func(config TransportConfig) (Transport, error) {
+			if config.SMTPPool.MaxConnections > 0 {
+				return NewPool(config.SMTP, config.SMTPPool), nil
+			}
+
+			return NewClient(config.SMTP), nil
+		},
+		TransportMaildir: func(config TransportConfig) (Transport, error) {
+			return NewMaildirTransport(config.Maildir)
+		},
+		TransportWebhook: func(config TransportConfig) (Transport, error) {
+			return NewWebhookTransport(config.Webhook)
+		},
+	},
+}
+
+func RegisterTransport(name string, factory TransportFactory) {
+	transports.Lock()
+	defer transports.Unlock()
+
+	transports.factories[name] = factory
+}
+
+func TransportNames() []string {
+	transports.RLock()
+	defer transports.RUnlock()
+
+	var names []string
+	for name := range transports.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func NewTransport(name string, config TransportConfig) (Transport, error) {
+	transports.RLock()
+	factory, ok := transports.factories[name]
+	transports.RUnlock()
+
+	if !ok {
+		return nil, UnknownTransportError{Name: name}
+	}
+
+	return factory(config)
+}
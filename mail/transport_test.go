@@ -0,0 +1,78 @@
+package mail_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/pivotal-golang/lager"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeTransport struct {
+	config mail.TransportConfig
+}
+
+func (t fakeTransport) Connect(lager.Logger) error            { return nil }
+func (t fakeTransport) Send(mail.Message, lager.Logger) error { return nil }
+
+var _ = Describe("Transport registry", func() {
+	It("builds an SMTP client for the smtp transport", func() {
+		transport, err := mail.NewTransport("smtp", mail.TransportConfig{
+			SMTP: mail.Config{Host: "smtp.example.com", Port: "25"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport).To(BeAssignableToTypeOf(&mail.Client{}))
+	})
+
+	It("builds an SMTP pool when pooling is configured", func() {
+		transport, err := mail.NewTransport("smtp", mail.TransportConfig{
+			SMTP:     mail.Config{Host: "smtp.example.com", Port: "25"},
+			SMTPPool: mail.PoolConfig{MaxConnections: 5},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport).To(BeAssignableToTypeOf(&mail.Pool{}))
+	})
+
+	It("builds a maildir transport", func() {
+		transport, err := mail.NewTransport("maildir", mail.TransportConfig{
+			Maildir: mail.MaildirConfig{Path: "/tmp/maildir"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport).To(BeAssignableToTypeOf(&mail.MaildirTransport{}))
+	})
+
+	It("builds a webhook transport", func() {
+		transport, err := mail.NewTransport("webhook", mail.TransportConfig{
+			Webhook: mail.WebhookConfig{URL: "https://mail.example.com/send"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport).To(BeAssignableToTypeOf(&mail.WebhookTransport{}))
+	})
+
+	It("returns the error when a transport cannot be configured", func() {
+		_, err := mail.NewTransport("webhook", mail.TransportConfig{})
+		Expect(err).To(MatchError(errors.New("webhook transport requires a URL")))
+	})
+
+	It("errors when the transport is unknown", func() {
+		_, err := mail.NewTransport("carrier-pigeon", mail.TransportConfig{})
+		Expect(err).To(MatchError(mail.UnknownTransportError{Name: "carrier-pigeon"}))
+		Expect(err.Error()).To(ContainSubstring(`Unknown mail transport "carrier-pigeon"`))
+	})
+
+	It("allows additional transports to be registered", func() {
+		mail.RegisterTransport("fake", func(config mail.TransportConfig) (mail.Transport, error) {
+			return fakeTransport{config: config}, nil
+		})
+
+		Expect(mail.TransportNames()).To(ContainElement("fake"))
+
+		transport, err := mail.NewTransport("fake", mail.TransportConfig{
+			Maildir: mail.MaildirConfig{Path: "/some/path"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport.(fakeTransport).config.Maildir.Path).To(Equal("/some/path"))
+	})
+})
@@ -0,0 +1,137 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+)
+
+type WebhookConfig struct {
+	URL           string
+	Authorization string
+	Timeout       time.Duration
+	SkipVerifySSL bool
+}
+
+type WebhookTransport struct {
+	config WebhookConfig
+	client *http.Client
+}
+
+type WebhookError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e WebhookError) Error() string {
+	return fmt.Sprintf("mail webhook responded with status %d: %s", e.StatusCode, e.Body)
+}
+
+type webhookPayload struct {
+	From    string        `json:"from"`
+	To      string        `json:"to"`
+	ReplyTo string        `json:"reply_to,omitempty"`
+	Subject string        `json:"subject"`
+	Headers []string      `json:"headers"`
+	Parts   []webhookPart `json:"parts"`
+	Raw     string        `json:"raw"`
+}
+
+type webhookPart struct {
+	ContentType string `json:"content_type"`
+	Content     string `json:"content"`
+}
+
+func NewWebhookTransport(config WebhookConfig) (*WebhookTransport, error) {
+	if config.URL == "" {
+		return nil, errors.New("webhook transport requires a URL")
+	}
+
+	if config.Timeout == 0 {
+		config.Timeout = 15 * time.Second
+	}
+
+	return &WebhookTransport{
+		config: config,
+		client: &http.Client{
+			Timeout: config.Timeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: config.SkipVerifySSL},
+			},
+		},
+	}, nil
+}
+
+func (t *WebhookTransport) Connect(logger lager.Logger) error {
+	return nil
+}
+
+func (t *WebhookTransport) Send(msg Message, logger lager.Logger) error {
+	logger = logger.Session("webhook")
+
+	payload := webhookPayload{
+		From:    msg.From,
+		To:      msg.To,
+		ReplyTo: msg.ReplyTo,
+		Subject: msg.Subject,
+		Headers: msg.Headers,
+		Parts:   []webhookPart{},
+		Raw:     msg.Data(),
+	}
+
+	if payload.Headers == nil {
+		payload.Headers = []string{}
+	}
+
+	for _, part := range msg.Body {
+		payload.Parts = append(payload.Parts, webhookPart{
+			ContentType: part.ContentType,
+			Content:     part.Content,
+		})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", t.config.URL, bytes.NewReader(body))
+	if err != nil {
+		logger.Error("failed", err)
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	if t.config.Authorization != "" {
+		request.Header.Set("Authorization", t.config.Authorization)
+	}
+
+	response, err := t.client.Do(request)
+	if err != nil {
+		logger.Error("failed", err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		err = WebhookError{
+			StatusCode: response.StatusCode,
+			Body:       string(responseBody),
+		}
+		logger.Error("failed", err)
+		return err
+	}
+
+	logger.Info("delivered", lager.Data{"status": response.StatusCode})
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package mail_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/pivotal-golang/lager"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhookTransport", func() {
+	var (
+		server     *httptest.Server
+		transport  *mail.WebhookTransport
+		logger     lager.Logger
+		msg        mail.Message
+		request    *http.Request
+		body       []byte
+		statusCode int
+	)
+
+	BeforeEach(func() {
+		statusCode = http.StatusAccepted
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var err error
+			body, err = ioutil.ReadAll(req.Body)
+			if err != nil {
+				panic(err)
+			}
+			request = req
+
+			w.WriteHeader(statusCode)
+			w.Write([]byte("some response"))
+		}))
+
+		logger = lager.NewLogger("notifications")
+
+		var err error
+		transport, err = mail.NewWebhookTransport(mail.WebhookConfig{
+			URL:           server.URL + "/deliveries",
+			Authorization: "Bearer some-token",
+			Timeout:       time.Second,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		msg = mail.Message{
+			From:    "me@example.com",
+			ReplyTo: "reply@example.com",
+			To:      "you@example.com",
+			Subject: "Urgent! Read now!",
+			Headers: []string{"X-CF-Notification-ID: some-id"},
+			Body: []mail.Part{
+				{ContentType: "text/plain", Content: "plain body"},
+				{ContentType: "text/html", Content: "<p>html body</p>"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("requires a URL", func() {
+		_, err := mail.NewWebhookTransport(mail.WebhookConfig{})
+		Expect(err).To(MatchError("webhook transport requires a URL"))
+	})
+
+	It("posts the message as JSON to the webhook", func() {
+		Expect(transport.Send(msg, logger)).To(Succeed())
+
+		Expect(request.Method).To(Equal("POST"))
+		Expect(request.URL.Path).To(Equal("/deliveries"))
+		Expect(request.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(request.Header.Get("Authorization")).To(Equal("Bearer some-token"))
+
+		var payload map[string]interface{}
+		Expect(json.Unmarshal(body, &payload)).To(Succeed())
+
+		Expect(payload["from"]).To(Equal("me@example.com"))
+		Expect(payload["reply_to"]).To(Equal("reply@example.com"))
+		Expect(payload["to"]).To(Equal("you@example.com"))
+		Expect(payload["subject"]).To(Equal("Urgent! Read now!"))
+		Expect(payload["headers"]).To(Equal([]interface{}{"X-CF-Notification-ID: some-id"}))
+		Expect(payload["parts"]).To(Equal([]interface{}{
+			map[string]interface{}{"content_type": "text/plain", "content": "plain body"},
+			map[string]interface{}{"content_type": "text/html", "content": "<p>html body</p>"},
+		}))
+		Expect(payload["raw"]).To(ContainSubstring("Subject: Urgent! Read now!"))
+	})
+
+	It("returns a webhook error when the response is not successful", func() {
+		statusCode = http.StatusBadGateway
+
+		err := transport.Send(msg, logger)
+		Expect(err).To(MatchError(mail.WebhookError{
+			StatusCode: http.StatusBadGateway,
+			Body:       "some response",
+		}))
+	})
+
+	It("returns an error when the webhook cannot be reached", func() {
+		server.Close()
+
+		err := transport.Send(msg, logger)
+		Expect(err).To(HaveOccurred())
+	})
+})
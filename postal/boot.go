@@ -28,13 +28,16 @@ type Config struct {
 	VerifySSL            bool
 	InstanceIndex        int
 	WorkerCount          int
+	CriticalWorkerCount  int
 	EncryptionKey        []byte
 	DBLoggingEnabled     bool
 	RootPath             string
 	Sender               string
 	Domain               string
+	PublicURL            string
 	QueueWaitMaxDuration int
 	MaxQueueLength       int
+	ClientWeights        map[string]int
 	MaxRetries           int
 	CCHost               string
 }
@@ -51,7 +54,7 @@ func database(db *sql.DB, dbLoggingEnabled bool, rootPath string) db.DatabaseInt
 	return database
 }
 
-func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
+func Boot(mailTransport func() mail.Transport, db *sql.DB, config Config) Workers {
 	uaaClient := uaa.NewZonedUAAClient(config.UAAClientID, config.UAAClientSecret, config.VerifySSL, config.UAATokenValidator)
 
 	logger := lager.NewLogger("notifications")
@@ -65,6 +68,15 @@ func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
 	gobbleQueue := gobble.NewQueue(gobbleDatabase, clock, gobble.Config{
 		WaitMaxDuration: time.Duration(config.QueueWaitMaxDuration) * time.Millisecond,
 		MaxQueueLength:  config.MaxQueueLength,
+		ClientWeights:   config.ClientWeights,
+		Logger:          logger.Session("queue"),
+	})
+	criticalQueue := gobble.NewQueue(gobbleDatabase, clock, gobble.Config{
+		WaitMaxDuration: time.Duration(config.QueueWaitMaxDuration) * time.Millisecond,
+		MaxQueueLength:  config.MaxQueueLength,
+		ClientWeights:   config.ClientWeights,
+		MinPriority:     gobble.PriorityCritical,
+		Logger:          logger.Session("critical-queue"),
 	})
 
 	cloak, err := conceal.NewCloak(config.EncryptionKey)
@@ -85,23 +97,41 @@ func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
 	v1TemplateLoader := v1.NewTemplatesLoader(database, clientsRepo, kindsRepo, templatesRepo)
 	deliveryFailureHandler := common.NewDeliveryFailureHandler(config.MaxRetries)
 	messageStatusUpdater := v1.NewMessageStatusUpdater(messagesRepo)
+	messageEventRecorder := v1.NewMessageEventRecorder(v1models.NewMessageEventsRepo())
 	userLoader := common.NewUserLoader(uaaClient)
 	tokenLoader := uaa.NewTokenLoader(uaaClient)
 	packager := common.NewPackager(v1TemplateLoader, cloak)
+	campaignJobProcessor := v1.NewCampaignJobProcessor(v1.CampaignJobProcessorConfig{
+		UAAHost: config.UAAHost,
 
-	WorkerGenerator{
+		Database:          database,
+		TokenLoader:       tokenLoader,
+		UserLoader:        userLoader,
+		Queue:             gobbleQueue,
+		GobbleInitializer: gobble.Initializer{},
+
+		DeliveryFailureHandler: deliveryFailureHandler,
+	})
+
+	workers := WorkerGenerator{
 		InstanceIndex: config.InstanceIndex,
 		Count:         config.WorkerCount,
-	}.Work(func(index int) Worker {
+		CriticalCount: config.CriticalWorkerCount,
+	}.Work(func(index int, critical bool) Worker {
+		queue := gobbleQueue
+		if critical {
+			queue = criticalQueue
+		}
 
 		v1DeliveryJobProcessor := v1.NewDeliveryJobProcessor(v1.DeliveryJobProcessorConfig{
-			DBTrace: config.DBLoggingEnabled,
-			UAAHost: config.UAAHost,
-			Sender:  config.Sender,
-			Domain:  config.Domain,
+			DBTrace:   config.DBLoggingEnabled,
+			UAAHost:   config.UAAHost,
+			Sender:    config.Sender,
+			Domain:    config.Domain,
+			PublicURL: config.PublicURL,
 
 			Packager:    packager,
-			MailClient:  mailClient(),
+			MailClient:  mailTransport(),
 			Database:    database,
 			TokenLoader: tokenLoader,
 			UserLoader:  userLoader,
@@ -110,7 +140,10 @@ func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
 			ReceiptsRepo:           receiptsRepo,
 			UnsubscribesRepo:       unsubscribesRepo,
 			GlobalUnsubscribesRepo: globalUnsubscribesRepo,
+			MessagesRepo:           messagesRepo,
+			SuppressionsRepo:       v1models.NewSuppressionsRepo(),
 			MessageStatusUpdater:   messageStatusUpdater,
+			MessageEventRecorder:   messageEventRecorder,
 			DeliveryFailureHandler: deliveryFailureHandler,
 		})
 
@@ -120,11 +153,14 @@ func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
 			DBTrace: config.DBLoggingEnabled,
 
 			DeliveryFailureHandler: deliveryFailureHandler,
+			CampaignJobProcessor:   campaignJobProcessor,
 
-			Logger: logger.Session("worker", lager.Data{"worker_id": index}),
-			Queue:  gobbleQueue,
+			Logger: logger.Session("worker", lager.Data{"worker_id": index, "critical": critical}),
+			Queue:  queue,
 		})
 
 		return &worker
 	})
+
+	return NewWorkers(workers, gobbleQueue, criticalQueue)
 }
@@ -0,0 +1,27 @@
+package common
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+)
+
+const CampaignJobType = "campaign"
+
+type Campaign struct {
+	JobType         string
+	Options         Options
+	Recipients      []CampaignRecipient
+	Space           cf.CloudControllerSpace
+	Organization    cf.CloudControllerOrganization
+	ClientID        string
+	UAAHost         string
+	Scope           string
+	VCAPRequestID   string
+	RequestReceived time.Time
+}
+
+type CampaignRecipient struct {
+	UserGUID  string
+	MessageID string
+}
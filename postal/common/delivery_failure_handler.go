@@ -4,12 +4,15 @@ import (
 	"math"
 	"time"
 
+	"github.com/cloudfoundry-incubator/notifications/mail"
 	"github.com/pivotal-golang/lager"
 	"github.com/rcrowley/go-metrics"
 )
 
 type Retryable interface {
 	Retry(duration time.Duration)
+	Fail(reason error)
+	GiveUp()
 	State() (retryCount int, activeAt time.Time)
 }
 
@@ -23,9 +26,29 @@ func NewDeliveryFailureHandler(numRetries int) DeliveryFailureHandler {
 	}
 }
 
-func (h DeliveryFailureHandler) Handle(job Retryable, logger lager.Logger) {
+func (h DeliveryFailureHandler) Handle(job Retryable, reason error, logger lager.Logger) {
+	job.Fail(reason)
+
 	retryCount, _ := job.State()
+	if mail.IsRecipientRejected(reason) || IsTemplateError(reason) {
+		job.GiveUp()
+
+		logger.Info("delivery-failed-permanently", lager.Data{
+			"retry_count": retryCount,
+		})
+
+		metrics.GetOrRegisterCounter("notifications.worker.permanent-failure", nil).Inc(1)
+		return
+	}
+
 	if retryCount >= h.numRetries {
+		job.GiveUp()
+
+		logger.Info("delivery-failed-dead-lettered", lager.Data{
+			"retry_count": retryCount,
+		})
+
+		metrics.GetOrRegisterCounter("notifications.worker.dead-lettered", nil).Inc(1)
 		return
 	}
 
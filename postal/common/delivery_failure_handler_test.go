@@ -2,8 +2,11 @@ package common_test
 
 import (
 	"bytes"
+	"errors"
+	"net/textproto"
 	"time"
 
+	"github.com/cloudfoundry-incubator/notifications/mail"
 	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
 	"github.com/pivotal-golang/lager"
@@ -46,7 +49,7 @@ var _ = Describe("DeliveryFailureHandler", func() {
 		for retryCount, duration := range backoffDurations {
 			job.StateCall.Returns.Count = retryCount
 
-			handler.Handle(job, logger)
+			handler.Handle(job, errors.New("some error"), logger)
 
 			Expect(job.RetryCall.Receives.Duration).To(Equal(duration))
 		}
@@ -54,7 +57,7 @@ var _ = Describe("DeliveryFailureHandler", func() {
 		job.StateCall.Returns.Count = 10
 		job.RetryCall.WasCalled = false
 
-		handler.Handle(job, logger)
+		handler.Handle(job, errors.New("some error"), logger)
 
 		Expect(job.RetryCall.WasCalled).To(BeFalse())
 	})
@@ -62,9 +65,78 @@ var _ = Describe("DeliveryFailureHandler", func() {
 	It("gives up after 9 retries", func() {
 		job.StateCall.Returns.Count = 10
 
-		handler.Handle(job, logger)
+		handler.Handle(job, errors.New("some error"), logger)
 
 		Expect(job.RetryCall.WasCalled).To(BeFalse())
+		Expect(job.GiveUpCall.WasCalled).To(BeTrue())
+	})
+
+	It("does not retry recipients the mail server rejected permanently", func() {
+		job.StateCall.Returns.Count = 0
+
+		handler.Handle(job, mail.RecipientError{Recipient: "nobody@example.com", Err: &textproto.Error{Code: 550, Msg: "5.1.1 mailbox unavailable"}}, logger)
+
+		Expect(job.FailCall.WasCalled).To(BeTrue())
+		Expect(job.RetryCall.WasCalled).To(BeFalse())
+		Expect(job.GiveUpCall.WasCalled).To(BeTrue())
+	})
+
+	It("does not retry template errors", func() {
+		job.StateCall.Returns.Count = 0
+
+		handler.Handle(job, common.TemplateError{Part: "text", Err: errors.New("can't evaluate field Nope")}, logger)
+
+		Expect(job.FailCall.WasCalled).To(BeTrue())
+		Expect(job.RetryCall.WasCalled).To(BeFalse())
+		Expect(job.GiveUpCall.WasCalled).To(BeTrue())
+	})
+
+	It("retries permanent SMTP failures earlier in the session", func() {
+		for _, reason := range []error{
+			&textproto.Error{Code: 535, Msg: "5.7.8 authentication credentials invalid"},
+			&textproto.Error{Code: 553, Msg: "5.1.8 sender address rejected"},
+		} {
+			job.RetryCall.WasCalled = false
+			job.StateCall.Returns.Count = 0
+
+			handler.Handle(job, reason, logger)
+
+			Expect(job.RetryCall.WasCalled).To(BeTrue())
+			Expect(job.GiveUpCall.WasCalled).To(BeFalse())
+		}
+	})
+
+	It("retries transient SMTP failures", func() {
+		job.StateCall.Returns.Count = 0
+
+		handler.Handle(job, &textproto.Error{Code: 451, Msg: "4.7.1 try again later"}, logger)
+
+		Expect(job.RetryCall.WasCalled).To(BeTrue())
+		Expect(job.GiveUpCall.WasCalled).To(BeFalse())
+	})
+
+	It("records the failure on the job", func() {
+		job.StateCall.Returns.Count = 2
+
+		handler.Handle(job, errors.New("smtp is down"), logger)
+
+		Expect(job.FailCall.WasCalled).To(BeTrue())
+		Expect(job.FailCall.Receives.Reason).To(MatchError("smtp is down"))
+		Expect(job.GiveUpCall.WasCalled).To(BeFalse())
+	})
+
+	It("logs when the job is dead-lettered", func() {
+		job.StateCall.Returns.Count = 10
+
+		handler.Handle(job, errors.New("some error"), logger)
+
+		lines, err := parseLogLines(buffer.Bytes())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lines).To(HaveLen(1))
+
+		line := lines[0]
+		Expect(line.Message).To(Equal("notifications.delivery-failed-dead-lettered"))
+		Expect(line.Data).To(HaveKeyWithValue("retry_count", float64(10)))
 	})
 
 	It("logs the retry attempt", func() {
@@ -72,7 +144,7 @@ var _ = Describe("DeliveryFailureHandler", func() {
 		job.StateCall.Returns.Time = expectedActiveAt
 		job.StateCall.Returns.Count = 4
 
-		handler.Handle(job, logger)
+		handler.Handle(job, errors.New("some error"), logger)
 
 		lines, err := parseLogLines(buffer.Bytes())
 		Expect(err).NotTo(HaveOccurred())
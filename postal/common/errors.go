@@ -2,6 +2,7 @@ package common
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -53,3 +54,21 @@ func UAAErrorFor(err error) error {
 		return UAAGenericError{errors.New("UAA Unknown Error: " + err.Error())}
 	}
 }
+
+type TemplateError struct {
+	Part string
+	Err  error
+}
+
+func (e TemplateError) Error() string {
+	return fmt.Sprintf("template %s could not be rendered: %s", e.Part, e.Err)
+}
+
+func (e TemplateError) Unwrap() error {
+	return e.Err
+}
+
+func IsTemplateError(err error) bool {
+	var templateError TemplateError
+	return errors.As(err, &templateError)
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"html"
+	"reflect"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
@@ -20,6 +21,7 @@ type Options struct {
 	Role              string
 	Endorsement       string
 	TemplateID        string
+	Locale            string
 }
 
 type Delivery struct {
@@ -38,10 +40,13 @@ type Delivery struct {
 }
 
 type Templates struct {
+	ID      string
+	Version int
 	Name    string
 	Subject string
 	Text    string
 	HTML    string
+	Strict  bool
 }
 
 type HTML struct {
@@ -62,6 +67,9 @@ type MessageContext struct {
 	TextTemplate      string
 	HTMLTemplate      string
 	SubjectTemplate   string
+	TemplateID        string
+	TemplateVersion   int
+	StrictTemplates   bool
 	KindDescription   string
 	SourceDescription string
 	UserGUID          string
@@ -77,6 +85,8 @@ type MessageContext struct {
 	OrganizationRole  string
 	RequestReceived   time.Time
 	Domain            string
+	PublicURL         string
+	Critical          bool
 }
 
 func NewMessageContext(delivery Delivery, sender, domain string, cloak conceal.CloakInterface, templates Templates) MessageContext {
@@ -107,6 +117,9 @@ func NewMessageContext(delivery Delivery, sender, domain string, cloak conceal.C
 		TextTemplate:      templates.Text,
 		HTMLTemplate:      templates.HTML,
 		SubjectTemplate:   templates.Subject,
+		TemplateID:        templates.ID,
+		TemplateVersion:   templates.Version,
+		StrictTemplates:   templates.Strict,
 		KindDescription:   kindDescription,
 		SourceDescription: sourceDescription,
 		UserGUID:          delivery.UserGUID,
@@ -136,6 +149,22 @@ func NewMessageContext(delivery Delivery, sender, domain string, cloak conceal.C
 	return messageContext
 }
 
+func (context MessageContext) providedValues() map[string]interface{} {
+	values := map[string]interface{}{}
+
+	fields := reflect.ValueOf(context)
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		if field.Kind() == reflect.String && field.String() == "" {
+			continue
+		}
+
+		values[fields.Type().Field(i).Name] = field.Interface()
+	}
+
+	return values
+}
+
 func (context *MessageContext) Escape() {
 	context.From = html.EscapeString(context.From)
 	context.To = html.EscapeString(context.To)
@@ -20,7 +20,7 @@ const HTMLWrapperTemplate = `{{.HTMLComponents.Doctype}}
 </html>`
 
 type templatesLoader interface {
-	LoadTemplates(clientID, kindID, templateID string) (Templates, error)
+	LoadTemplates(clientID, kindID, templateID, locale string) (Templates, error)
 }
 
 type Packager struct {
@@ -36,7 +36,7 @@ func NewPackager(templates templatesLoader, cloak conceal.CloakInterface) Packag
 }
 
 func (packager Packager) PrepareContext(delivery Delivery, sender, domain string) (MessageContext, error) {
-	templates, err := packager.templates.LoadTemplates(delivery.ClientID, delivery.Options.KindID, delivery.Options.TemplateID)
+	templates, err := packager.templates.LoadTemplates(delivery.ClientID, delivery.Options.KindID, delivery.Options.TemplateID, delivery.Options.Locale)
 	if err != nil {
 		return MessageContext{}, err
 	}
@@ -50,37 +50,55 @@ func (packager Packager) Pack(context MessageContext) (mail.Message, error) {
 		return mail.Message{}, err
 	}
 
-	compiledSubject, err := packager.compileTemplate(context, context.SubjectTemplate, false)
+	compiledSubject, err := packager.CompileSubject(context)
 	if err != nil {
 		return mail.Message{}, err
 	}
 
+	headers := []string{
+		fmt.Sprintf("X-CF-Client-ID: %s", context.ClientID),
+		fmt.Sprintf("%s: %s", mail.NotificationIDHeader, context.MessageID),
+		fmt.Sprintf("X-CF-Notification-Timestamp: %s", time.Now().Format(time.RFC3339Nano)),
+		fmt.Sprintf("X-CF-Notification-Request-Received: %s", context.RequestReceived.Format(time.RFC3339Nano)),
+	}
+
+	if !context.Critical && context.UnsubscribeID != "" && context.PublicURL != "" {
+		links := fmt.Sprintf("<%s/unsubscribe/%s>", context.PublicURL, context.UnsubscribeID)
+		if context.Domain != "" {
+			links = fmt.Sprintf("<mailto:unsubscribe+%s@%s>, %s", context.UnsubscribeID, context.Domain, links)
+		}
+
+		headers = append(headers,
+			fmt.Sprintf("List-Unsubscribe: %s", links),
+			"List-Unsubscribe-Post: List-Unsubscribe=One-Click",
+		)
+	}
+
 	return mail.Message{
 		From:    context.From,
 		ReplyTo: context.ReplyTo,
 		To:      context.To,
 		Subject: compiledSubject,
 		Body:    parts,
-		Headers: []string{
-			fmt.Sprintf("X-CF-Client-ID: %s", context.ClientID),
-			fmt.Sprintf("X-CF-Notification-ID: %s", context.MessageID),
-			fmt.Sprintf("X-CF-Notification-Timestamp: %s", time.Now().Format(time.RFC3339Nano)),
-			fmt.Sprintf("X-CF-Notification-Request-Received: %s", context.RequestReceived.Format(time.RFC3339Nano)),
-		},
+		Headers: headers,
 	}, nil
 }
 
+func (packager Packager) CompileSubject(context MessageContext) (string, error) {
+	return packager.compileTemplate(context, "subject", context.SubjectTemplate, false, context.StrictTemplates)
+}
+
 func (packager Packager) CompileParts(context MessageContext) ([]mail.Part, error) {
 	var parts []mail.Part
 	var err error
 
-	context.Endorsement, err = packager.compileTemplate(context, context.Endorsement, false)
+	context.Endorsement, err = packager.compileTemplate(context, "endorsement", context.Endorsement, false, false)
 	if err != nil {
 		return parts, err
 	}
 
 	if context.Text != "" {
-		plainText, err := packager.compileTemplate(context, context.TextTemplate, false)
+		plainText, err := packager.compileTemplate(context, "text", context.TextTemplate, false, context.StrictTemplates)
 		if err != nil {
 			return parts, err
 		}
@@ -95,12 +113,12 @@ func (packager Packager) CompileParts(context MessageContext) ([]mail.Part, erro
 	if context.HTML != "" {
 		var err error
 
-		context.HTMLComponents.BodyContent, err = packager.compileTemplate(context, context.HTMLTemplate, true)
+		context.HTMLComponents.BodyContent, err = packager.compileTemplate(context, "html", context.HTMLTemplate, true, context.StrictTemplates)
 		if err != nil {
 			return parts, err
 		}
 
-		htmlPart, err := packager.compileTemplate(context, HTMLWrapperTemplate, true)
+		htmlPart, err := packager.compileTemplate(context, "html", HTMLWrapperTemplate, true, false)
 		if err != nil {
 			return parts, err
 		}
@@ -114,20 +132,45 @@ func (packager Packager) CompileParts(context MessageContext) ([]mail.Part, erro
 	return parts, nil
 }
 
-func (packager Packager) compileTemplate(context MessageContext, theTemplate string, escapeContext bool) (string, error) {
-	buffer := bytes.NewBuffer([]byte{})
+func (packager Packager) compileTemplate(context MessageContext, part, theTemplate string, escapeContext, strict bool) (string, error) {
+	if escapeContext {
+		context.Escape()
+	}
 
-	source, err := template.New("compileTemplate").Parse(theTemplate)
+	compiledTemplate, err := RenderTemplate(part, theTemplate, context, strict)
 	if err != nil {
 		return "", err
 	}
 
-	if escapeContext {
-		context.Escape()
+	return strings.TrimSuffix(compiledTemplate, "\n"), nil
+}
+
+// RenderTemplate renders a single part of a message. Any failure, including
+// one raised while executing an otherwise valid template, is returned as a
+// TemplateError so that a broken template never produces a truncated message.
+//
+// A strict template is executed with missingkey=error against the values the
+// context actually holds, so referring to one that was not provided (such as
+// .Space on a message sent to a user) fails instead of rendering as empty.
+func RenderTemplate(part, theTemplate string, context MessageContext, strict bool) (string, error) {
+	var data interface{} = context
+
+	source := template.New(part).Funcs(TemplateFuncs())
+	if strict {
+		source = source.Option("missingkey=error")
+		data = context.providedValues()
 	}
 
-	source.Execute(buffer, context)
-	compiledTemplate := strings.TrimSuffix(buffer.String(), "\n")
+	source, err := source.Parse(theTemplate)
+	if err != nil {
+		return "", TemplateError{Part: part, Err: err}
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err = source.Execute(buffer, data)
+	if err != nil {
+		return "", TemplateError{Part: part, Err: err}
+	}
 
-	return compiledTemplate, nil
+	return buffer.String(), nil
 }
@@ -33,6 +33,7 @@ var _ = Describe("Packager", func() {
 				Subject:    "Some crazy subject",
 				TemplateID: "some-template-id",
 				KindID:     "some-kind-id",
+				Locale:     "fr-CA",
 				HTML: common.HTML{
 					BodyContent:    "<p>user supplied banana html</p>",
 					BodyAttributes: "class=\"bananaBody\"",
@@ -93,6 +94,7 @@ var _ = Describe("Packager", func() {
 			Expect(templatesLoader.LoadTemplatesCall.Receives.ClientID).To(Equal("some-client-id"))
 			Expect(templatesLoader.LoadTemplatesCall.Receives.KindID).To(Equal("some-kind-id"))
 			Expect(templatesLoader.LoadTemplatesCall.Receives.TemplateID).To(Equal("some-template-id"))
+			Expect(templatesLoader.LoadTemplatesCall.Receives.Locale).To(Equal("fr-CA"))
 
 			Expect(cloak.VeilCall.Receives.PlainText).To(Equal([]byte("some-user-guid|some-client-id|some-kind-id")))
 
@@ -165,6 +167,83 @@ var _ = Describe("Packager", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(timestamp).To(BeTemporally("~", time.Now(), 2*time.Second))
 		})
+
+		Context("when the message can be unsubscribed from", func() {
+			BeforeEach(func() {
+				context.UnsubscribeID = "some-unsubscribe-id"
+				context.Domain = "example.com"
+				context.PublicURL = "https://notifications.example.com/api"
+			})
+
+			It("includes one-click List-Unsubscribe headers pointing at the domain mailbox and the public URL", func() {
+				msg, err := packager.Pack(context)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(msg.Headers).To(ContainElement("List-Unsubscribe: <mailto:unsubscribe+some-unsubscribe-id@example.com>, <https://notifications.example.com/api/unsubscribe/some-unsubscribe-id>"))
+				Expect(msg.Headers).To(ContainElement("List-Unsubscribe-Post: List-Unsubscribe=One-Click"))
+			})
+
+			It("only points at the public URL without a domain", func() {
+				context.Domain = ""
+
+				msg, err := packager.Pack(context)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(msg.Headers).To(ContainElement("List-Unsubscribe: <https://notifications.example.com/api/unsubscribe/some-unsubscribe-id>"))
+			})
+
+			It("omits the List-Unsubscribe headers without a public URL", func() {
+				context.PublicURL = ""
+
+				msg, err := packager.Pack(context)
+				Expect(err).NotTo(HaveOccurred())
+				for _, header := range msg.Headers {
+					Expect(header).NotTo(HavePrefix("List-Unsubscribe"))
+				}
+			})
+
+			It("omits the List-Unsubscribe headers for critical kinds", func() {
+				context.Critical = true
+
+				msg, err := packager.Pack(context)
+				Expect(err).NotTo(HaveOccurred())
+				for _, header := range msg.Headers {
+					Expect(header).NotTo(HavePrefix("List-Unsubscribe"))
+				}
+			})
+		})
+
+		It("omits the List-Unsubscribe headers without an unsubscribe ID", func() {
+			msg, err := packager.Pack(context)
+			Expect(err).NotTo(HaveOccurred())
+			for _, header := range msg.Headers {
+				Expect(header).NotTo(HavePrefix("List-Unsubscribe"))
+			}
+		})
+	})
+
+	Describe("CompileSubject", func() {
+		It("compiles the subject template against the context", func() {
+			context.SubjectTemplate = "The Subject: {{.Subject}}"
+
+			subject, err := packager.CompileSubject(context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subject).To(Equal("The Subject: we will be eaten"))
+		})
+
+		It("returns an error when the subject template cannot be parsed", func() {
+			context.SubjectTemplate = "{{.Subject"
+
+			_, err := packager.CompileSubject(context)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns a template error when the subject template cannot be executed", func() {
+			context.SubjectTemplate = "The Subject: {{.Nope}}"
+
+			_, err := packager.CompileSubject(context)
+			Expect(err).To(BeAssignableToTypeOf(common.TemplateError{}))
+			Expect(err.(common.TemplateError).Part).To(Equal("subject"))
+			Expect(common.IsTemplateError(err)).To(BeTrue())
+		})
 	})
 
 	Describe("CompileParts", func() {
@@ -240,5 +319,61 @@ Banana preamble <p>user supplied banana html</p>  3&amp;3 4&#39;4 user-123
 				}))
 			})
 		})
+
+		It("makes the template functions available", func() {
+			context.Text = ""
+			context.HTMLTemplate = `<h1>{{upper .Subject}}</h1>{{markdown .HTML}}`
+			context.HTMLComponents.BodyContent = "**Heads up** & more"
+			context.HTML = "**Heads up** & more"
+
+			parts, err := packager.CompileParts(context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parts[0].Content).To(ContainSubstring(`<h1>WE WILL BE EATEN</h1><p><strong>Heads up</strong> &amp; more</p>`))
+		})
+
+		Context("when a template cannot be executed", func() {
+			It("returns a template error instead of a truncated part", func() {
+				context.TextTemplate = "Banana preamble {{.Text}} {{.Nope}}"
+
+				parts, err := packager.CompileParts(context)
+				Expect(err).To(MatchError(ContainSubstring("template text could not be rendered")))
+				Expect(common.IsTemplateError(err)).To(BeTrue())
+				Expect(parts).To(BeEmpty())
+			})
+		})
+
+		Context("when the templates are strict", func() {
+			BeforeEach(func() {
+				context.StrictTemplates = true
+			})
+
+			It("renders the values the context holds", func() {
+				parts, err := packager.CompileParts(context)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parts).To(ContainElement(mail.Part{
+					ContentType: "text/plain",
+					Content: `Banana preamble User <supplied> "banana" text 3&3 4'4 user-123
+This is an endorsement for the development space and banana org.`,
+				}))
+			})
+
+			It("returns a template error when a value was not provided", func() {
+				context.TextTemplate = "Sent to the {{.Scope}} scope"
+
+				_, err := packager.CompileParts(context)
+				Expect(err).To(MatchError(ContainSubstring(`map has no entry for key "Scope"`)))
+				Expect(common.IsTemplateError(err)).To(BeTrue())
+			})
+
+			It("renders missing values as empty when the templates are not strict", func() {
+				context.StrictTemplates = false
+				context.HTML = ""
+				context.TextTemplate = "Sent to the {{.Scope}} scope"
+
+				parts, err := packager.CompileParts(context)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parts[0].Content).To(Equal("Sent to the  scope"))
+			})
+		})
 	})
 })
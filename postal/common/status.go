@@ -6,4 +6,6 @@ const (
 	StatusDelivered     = "delivered"
 	StatusQueued        = "queued"
 	StatusUndeliverable = "undeliverable"
+	StatusScheduled     = "scheduled"
+	StatusCancelled     = "cancelled"
 )
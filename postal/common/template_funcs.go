@@ -0,0 +1,197 @@
+package common
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	_ "time/tzdata"
+)
+
+var (
+	markdownHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	markdownUnordered   = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	markdownOrdered     = regexp.MustCompile(`^\d+\.\s+(.*)$`)
+	markdownLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownStrong      = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	markdownEmphasis    = regexp.MustCompile(`\*([^*]+)\*`)
+	markdownLinkSchemes = []string{"http://", "https://", "mailto:"}
+)
+
+// TemplateFuncs returns the functions available to notification templates.
+// Every function is free of side effects so that a template can be rendered
+// any number of times, including as a preview or a dry-run.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"formatTime":   formatTime,
+		"formatTimeIn": formatTimeIn,
+		"truncate":     truncate,
+		"upper":        strings.ToUpper,
+		"lower":        strings.ToLower,
+		"pathEscape":   url.PathEscape,
+		"queryEscape":  url.QueryEscape,
+		"markdown":     markdown,
+		"default":      defaultValue,
+		"pluralize":    pluralize,
+	}
+}
+
+func formatTime(layout string, t time.Time) string {
+	return t.UTC().Format(layout)
+}
+
+func formatTimeIn(zone, layout string, t time.Time) (string, error) {
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return "", fmt.Errorf("unknown time zone %q", zone)
+	}
+
+	return t.In(location).Format(layout), nil
+}
+
+// truncate shortens s to at most length characters, marking the cut with an
+// ellipsis.
+func truncate(length int, s string) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+
+	if length <= 3 {
+		return string(runes[:length])
+	}
+
+	return string(runes[:length-3]) + "..."
+}
+
+func defaultValue(fallback, value interface{}) interface{} {
+	if value == nil {
+		return fallback
+	}
+
+	if reflect.ValueOf(value).IsZero() {
+		return fallback
+	}
+
+	return value
+}
+
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+
+	return plural
+}
+
+// markdown renders a small, safe subset of Markdown: headings, paragraphs,
+// lists, strong and emphasised text, inline code and http, https or mailto
+// links. Any HTML in the source is escaped rather than passed through. The
+// source is unescaped first so that values which were already escaped for the
+// HTML part of a message are not escaped twice.
+func markdown(source string) string {
+	source = html.EscapeString(html.UnescapeString(source))
+
+	var (
+		output    strings.Builder
+		paragraph []string
+		list      string
+	)
+
+	closeParagraph := func() {
+		if len(paragraph) > 0 {
+			output.WriteString("<p>" + markdownInline(strings.Join(paragraph, "\n")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	closeList := func() {
+		if list != "" {
+			output.WriteString("</" + list + ">\n")
+			list = ""
+		}
+	}
+
+	openList := func(tag string) {
+		closeParagraph()
+		if list != tag {
+			closeList()
+			output.WriteString("<" + tag + ">\n")
+			list = tag
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+
+		if matches := markdownHeading.FindStringSubmatch(line); matches != nil {
+			closeParagraph()
+			closeList()
+			level := strconv.Itoa(len(matches[1]))
+			output.WriteString("<h" + level + ">" + markdownInline(matches[2]) + "</h" + level + ">\n")
+			continue
+		}
+
+		if matches := markdownUnordered.FindStringSubmatch(line); matches != nil {
+			openList("ul")
+			output.WriteString("<li>" + markdownInline(matches[1]) + "</li>\n")
+			continue
+		}
+
+		if matches := markdownOrdered.FindStringSubmatch(line); matches != nil {
+			openList("ol")
+			output.WriteString("<li>" + markdownInline(matches[1]) + "</li>\n")
+			continue
+		}
+
+		closeList()
+
+		if line == "" {
+			closeParagraph()
+			continue
+		}
+
+		paragraph = append(paragraph, line)
+	}
+
+	closeParagraph()
+	closeList()
+
+	return strings.TrimSuffix(output.String(), "\n")
+}
+
+func markdownInline(text string) string {
+	// Odd segments sit between backticks and are rendered as code verbatim.
+	segments := strings.Split(text, "`")
+	if len(segments)%2 == 0 {
+		segments[len(segments)-2] += "`" + segments[len(segments)-1]
+		segments = segments[:len(segments)-1]
+	}
+
+	for i, segment := range segments {
+		if i%2 == 1 {
+			segments[i] = "<code>" + segment + "</code>"
+			continue
+		}
+
+		segment = markdownLink.ReplaceAllStringFunc(segment, func(link string) string {
+			matches := markdownLink.FindStringSubmatch(link)
+			for _, scheme := range markdownLinkSchemes {
+				if strings.HasPrefix(strings.ToLower(matches[2]), scheme) {
+					return `<a href="` + matches[2] + `">` + matches[1] + "</a>"
+				}
+			}
+
+			return matches[1]
+		})
+		segment = markdownStrong.ReplaceAllString(segment, "<strong>$1</strong>")
+		segments[i] = markdownEmphasis.ReplaceAllString(segment, "<em>$1</em>")
+	}
+
+	return strings.Join(segments, "")
+}
@@ -0,0 +1,115 @@
+package common_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplateFuncs", func() {
+	var context common.MessageContext
+
+	render := func(source string) string {
+		output, err := common.RenderTemplate("text", source, context, false)
+		Expect(err).NotTo(HaveOccurred())
+		return output
+	}
+
+	BeforeEach(func() {
+		context = common.MessageContext{
+			Subject:         "Your app has crashed",
+			Text:            "A very long description of what went wrong",
+			Space:           "dev & test",
+			Domain:          "notifications.example.com",
+			RequestReceived: time.Date(2015, 6, 8, 21, 38, 3, 0, time.UTC),
+		}
+	})
+
+	Describe("formatTime", func() {
+		It("formats the time in UTC", func() {
+			Expect(render(`{{.RequestReceived | formatTime "2006-01-02 15:04 MST"}}`)).To(Equal("2015-06-08 21:38 UTC"))
+		})
+	})
+
+	Describe("formatTimeIn", func() {
+		It("formats the time in the given time zone", func() {
+			Expect(render(`{{.RequestReceived | formatTimeIn "America/New_York" "Jan 2, 2006 3:04 PM MST"}}`)).To(Equal("Jun 8, 2015 5:38 PM EDT"))
+		})
+
+		It("fails for an unknown time zone", func() {
+			_, err := common.RenderTemplate("text", `{{.RequestReceived | formatTimeIn "Mars/Olympus" "15:04"}}`, context, false)
+			Expect(err).To(MatchError(ContainSubstring(`unknown time zone "Mars/Olympus"`)))
+		})
+	})
+
+	Describe("truncate", func() {
+		It("shortens long text with an ellipsis", func() {
+			Expect(render(`{{.Text | truncate 16}}`)).To(Equal("A very long d..."))
+		})
+
+		It("leaves short text alone", func() {
+			Expect(render(`{{.Subject | truncate 100}}`)).To(Equal("Your app has crashed"))
+		})
+	})
+
+	Describe("upper and lower", func() {
+		It("changes the case of the text", func() {
+			Expect(render(`{{upper .Subject}} {{lower .Subject}}`)).To(Equal("YOUR APP HAS CRASHED your app has crashed"))
+		})
+	})
+
+	Describe("pathEscape and queryEscape", func() {
+		It("escapes values for use in a URL", func() {
+			Expect(render(`https://{{.Domain}}/spaces/{{pathEscape .Space}}?name={{queryEscape .Space}}`)).To(Equal("https://notifications.example.com/spaces/dev%20&%20test?name=dev+%26+test"))
+		})
+	})
+
+	Describe("default", func() {
+		It("uses the fallback when the value is empty", func() {
+			Expect(render(`{{.Organization | default "your organization"}}`)).To(Equal("your organization"))
+		})
+
+		It("uses the value when it is set", func() {
+			Expect(render(`{{.Space | default "your space"}}`)).To(Equal("dev & test"))
+		})
+	})
+
+	Describe("pluralize", func() {
+		It("picks the form matching the count", func() {
+			Expect(render(`1 {{pluralize 1 "app" "apps"}}, 3 {{pluralize 3 "app" "apps"}}`)).To(Equal("1 app, 3 apps"))
+		})
+	})
+
+	Describe("markdown", func() {
+		It("renders markdown as HTML", func() {
+			context.Text = "# Outage\n\nThe **API** is *down*, see `cf logs`.\n\n- first\n- second\n\n1. one\n2. two\n\n[Status](https://status.example.com)"
+
+			Expect(render(`{{markdown .Text}}`)).To(Equal(`<h1>Outage</h1>
+<p>The <strong>API</strong> is <em>down</em>, see <code>cf logs</code>.</p>
+<ul>
+<li>first</li>
+<li>second</li>
+</ul>
+<ol>
+<li>one</li>
+<li>two</li>
+</ol>
+<p><a href="https://status.example.com">Status</a></p>`))
+		})
+
+		It("escapes HTML and drops links with other schemes", func() {
+			context.Text = `<script>alert(1)</script> [click](javascript:void)`
+
+			Expect(render(`{{markdown .Text}}`)).To(Equal(`<p>&lt;script&gt;alert(1)&lt;/script&gt; click</p>`))
+		})
+
+		It("does not escape values that were already escaped", func() {
+			context.Text = "dev &amp; test"
+
+			Expect(render(`{{markdown .Text}}`)).To(Equal(`<p>dev &amp; test</p>`))
+		})
+	})
+})
@@ -6,7 +6,6 @@ import (
 	"github.com/cloudfoundry-incubator/notifications/db"
 	"github.com/cloudfoundry-incubator/notifications/gobble"
 	"github.com/cloudfoundry-incubator/notifications/postal/common"
-	"github.com/cloudfoundry-incubator/notifications/v1/services"
 	"github.com/pivotal-golang/lager"
 	"github.com/rcrowley/go-metrics"
 )
@@ -20,7 +19,7 @@ type v2DeliveryJobProcessor interface {
 }
 
 type campaignJobProcessor interface {
-	Process(conn services.ConnectionInterface, uaaHost string, job gobble.Job, logger lager.Logger) error
+	Process(job *gobble.Job, logger lager.Logger) error
 }
 
 type messageStatusUpdater interface {
@@ -28,7 +27,7 @@ type messageStatusUpdater interface {
 }
 
 type deliveryFailureHandler interface {
-	Handle(job common.Retryable, logger lager.Logger)
+	Handle(job common.Retryable, reason error, logger lager.Logger)
 }
 
 type DeliveryWorkerConfig struct {
@@ -82,7 +81,12 @@ func (worker DeliveryWorker) Deliver(job *gobble.Job) {
 	if err != nil {
 		metrics.GetOrRegisterCounter("notifications.worker.panic.json", nil).Inc(1)
 
-		worker.deliveryFailureHandler.Handle(job, worker.logger)
+		worker.deliveryFailureHandler.Handle(job, err, worker.logger)
+		return
+	}
+
+	if typedJob.JobType == common.CampaignJobType {
+		worker.campaignJobProcessor.Process(job, worker.logger)
 		return
 	}
 
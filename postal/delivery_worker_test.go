@@ -25,6 +25,7 @@ var _ = Describe("DeliveryWorker", func() {
 		v1DeliveryJobProcessor *mocks.V1DeliveryJobProcessor
 		connection             *mocks.Connection
 		messageStatusUpdater   *mocks.MessageStatusUpdater
+		campaignJobProcessor   *mocks.CampaignJobProcessor
 	)
 
 	BeforeEach(func() {
@@ -37,6 +38,7 @@ var _ = Describe("DeliveryWorker", func() {
 		database := mocks.NewDatabase()
 		database.ConnectionCall.Returns.Connection = connection
 		messageStatusUpdater = mocks.NewMessageStatusUpdater()
+		campaignJobProcessor = mocks.NewCampaignJobProcessor()
 
 		config := postal.DeliveryWorkerConfig{
 			ID:                     42,
@@ -46,6 +48,7 @@ var _ = Describe("DeliveryWorker", func() {
 			Database:               database,
 			UAAHost:                "my-uaa-host",
 			MessageStatusUpdater:   messageStatusUpdater,
+			CampaignJobProcessor:   campaignJobProcessor,
 		}
 
 		v1DeliveryJobProcessor = mocks.NewV1DeliveryJobProcessor()
@@ -94,6 +97,20 @@ var _ = Describe("DeliveryWorker", func() {
 			Expect(v1DeliveryJobProcessor.ProcessCall.Receives.Logger).ToNot(BeNil())
 		})
 
+		Context("when the job is a campaign", func() {
+			It("should hand the job to the campaign job processor", func() {
+				job = gobble.NewJob(common.Campaign{
+					JobType: common.CampaignJobType,
+				})
+
+				worker.Deliver(job)
+
+				Expect(campaignJobProcessor.ProcessCall.Receives.Job).To(Equal(job))
+				Expect(campaignJobProcessor.ProcessCall.Receives.Logger).ToNot(BeNil())
+				Expect(v1DeliveryJobProcessor.ProcessCall.CallCount).To(Equal(0))
+			})
+		})
+
 		Context("when the job cannot be unmarshalled", func() {
 			BeforeEach(func() {
 				j := gobble.Job{
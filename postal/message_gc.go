@@ -2,41 +2,88 @@ package postal
 
 import (
 	"log"
+	"sort"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 )
 
 type messagesDeleter interface {
-	DeleteBefore(models.ConnectionInterface, time.Time) (int, error)
+	DeleteBatch(models.ConnectionInterface, models.MessagesDeletion) (int, error)
+}
+
+type MessageRetention struct {
+	Lifetime          time.Duration
+	StatusLifetimes   map[string]time.Duration
+	DeletionBatchSize int
 }
 
 type MessageGC struct {
 	messages        messagesDeleter
 	db              db.DatabaseInterface
-	lifetime        time.Duration
+	retention       MessageRetention
 	logger          *log.Logger
 	timer           <-chan time.Time
 	pollingInterval time.Duration
 }
 
-func NewMessageGC(lifetime time.Duration, db db.DatabaseInterface, messages messagesDeleter, pollingInterval time.Duration, logger *log.Logger) MessageGC {
+func NewMessageGC(retention MessageRetention, db db.DatabaseInterface, messages messagesDeleter, pollingInterval time.Duration, logger *log.Logger) MessageGC {
 	return MessageGC{
 		messages:        messages,
 		db:              db,
-		lifetime:        lifetime,
+		retention:       retention,
 		logger:          logger,
 		pollingInterval: pollingInterval,
 		timer:           time.After(0),
 	}
 }
 
+// Collect deletes messages whose status has outlived its retention. Scheduled
+// messages are never collected: their status is not updated until they are
+// sent, so a send_at beyond the retention would otherwise be deleted before
+// it fires, and could then no longer be cancelled. Cancelled messages are kept
+// until their send_at has passed, because their job is still queued until then.
 func (gc MessageGC) Collect() {
-	threshold := time.Now().Add(-1 * gc.lifetime)
-	_, err := gc.messages.DeleteBefore(gc.db.Connection(), threshold)
-	if err != nil {
-		gc.logger.Printf("MessageGC.Collect() failed: " + err.Error())
+	now := time.Now()
+
+	var statuses []string
+	for status := range gc.retention.StatusLifetimes {
+		if status == common.StatusScheduled {
+			continue
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	for _, status := range statuses {
+		gc.deleteAll(models.MessagesDeletion{
+			Before:   now.Add(-1 * gc.retention.StatusLifetimes[status]),
+			Statuses: []string{status},
+		})
+	}
+
+	gc.deleteAll(models.MessagesDeletion{
+		Before:           now.Add(-1 * gc.retention.Lifetime),
+		ExcludedStatuses: append(statuses, common.StatusScheduled),
+	})
+}
+
+func (gc MessageGC) deleteAll(deletion models.MessagesDeletion) {
+	deletion.Limit = gc.retention.DeletionBatchSize
+	deletion.UnsentStatuses = []string{common.StatusCancelled}
+
+	for {
+		count, err := gc.messages.DeleteBatch(gc.db.Connection(), deletion)
+		if err != nil {
+			gc.logger.Printf("MessageGC.Collect() failed: " + err.Error())
+			return
+		}
+
+		if count == 0 || count < deletion.Limit {
+			return
+		}
 	}
 }
 
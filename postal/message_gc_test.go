@@ -21,7 +21,7 @@ var _ = Describe("MessageGC", func() {
 		database        *mocks.Database
 		conn            db.ConnectionInterface
 		loggerBuffer    *bytes.Buffer
-		lifetime        time.Duration
+		retention       postal.MessageRetention
 		pollingInterval time.Duration
 	)
 
@@ -35,10 +35,13 @@ var _ = Describe("MessageGC", func() {
 
 		repo = mocks.NewMessagesRepo()
 
-		lifetime = 2 * time.Minute
+		retention = postal.MessageRetention{
+			Lifetime:          2 * time.Minute,
+			DeletionBatchSize: 100,
+		}
 		pollingInterval = 500 * time.Millisecond
 
-		messageGC = postal.NewMessageGC(lifetime, database, repo, pollingInterval, logger)
+		messageGC = postal.NewMessageGC(retention, database, repo, pollingInterval, logger)
 	})
 
 	Describe("Run", func() {
@@ -46,11 +49,11 @@ var _ = Describe("MessageGC", func() {
 			messageGC.Run()
 
 			Eventually(func() int {
-				return repo.DeleteBeforeCall.CallCount
+				return repo.DeleteBatchCall.CallCount
 			}).Should(BeNumerically(">=", 2))
 
-			call1 := repo.DeleteBeforeCall.InvocationTimes[0]
-			call2 := repo.DeleteBeforeCall.InvocationTimes[1]
+			call1 := repo.DeleteBatchCall.InvocationTimes[0]
+			call2 := repo.DeleteBatchCall.InvocationTimes[1]
 			Expect(call2).To(BeTemporally(">", call1.Add(pollingInterval-50*time.Millisecond)))
 			Expect(call2).To(BeTemporally("<", call1.Add(pollingInterval+50*time.Millisecond)))
 		})
@@ -60,19 +63,90 @@ var _ = Describe("MessageGC", func() {
 		It("Deletes message statuses older than the specified time", func() {
 			messageGC.Collect()
 
-			Expect(repo.DeleteBeforeCall.Receives.Connection).To(Equal(conn))
-			Expect(repo.DeleteBeforeCall.Receives.ThresholdTime).To(BeTemporally("~", time.Now().Add(-2*time.Minute), 10*time.Second))
+			Expect(repo.DeleteBatchCall.Receives.Connection).To(Equal(conn))
+			Expect(repo.DeleteBatchCall.Receives.Deletions).To(HaveLen(1))
+
+			deletion := repo.DeleteBatchCall.Receives.Deletions[0]
+			Expect(deletion.Before).To(BeTemporally("~", time.Now().Add(-2*time.Minute), 10*time.Second))
+			Expect(deletion.Statuses).To(BeEmpty())
+			Expect(deletion.ExcludedStatuses).To(Equal([]string{"scheduled"}))
+			Expect(deletion.UnsentStatuses).To(Equal([]string{"cancelled"}))
+			Expect(deletion.Limit).To(Equal(100))
+		})
+
+		It("keeps deleting in batches until a batch comes back short", func() {
+			repo.DeleteBatchCall.Returns.RowsAffected = []int{100, 100, 42}
+
+			messageGC.Collect()
+
+			Expect(repo.DeleteBatchCall.CallCount).To(Equal(3))
+		})
+
+		It("stops when a batch deletes nothing, whatever the batch size", func() {
+			retention.DeletionBatchSize = 0
+			messageGC = postal.NewMessageGC(retention, database, repo, pollingInterval, log.New(loggerBuffer, "", 0))
+
+			messageGC.Collect()
+
+			Expect(repo.DeleteBatchCall.CallCount).To(Equal(1))
+		})
+
+		Context("when statuses have their own retention", func() {
+			BeforeEach(func() {
+				retention.StatusLifetimes = map[string]time.Duration{
+					"undeliverable": 48 * time.Hour,
+					"failed":        7 * 24 * time.Hour,
+				}
+
+				messageGC = postal.NewMessageGC(retention, database, repo, pollingInterval, log.New(loggerBuffer, "", 0))
+			})
+
+			It("collects each of those statuses separately and excludes them from the default lifetime", func() {
+				messageGC.Collect()
+
+				deletions := repo.DeleteBatchCall.Receives.Deletions
+				Expect(deletions).To(HaveLen(3))
+
+				Expect(deletions[0].Statuses).To(Equal([]string{"failed"}))
+				Expect(deletions[0].UnsentStatuses).To(Equal([]string{"cancelled"}))
+				Expect(deletions[0].Before).To(BeTemporally("~", time.Now().Add(-7*24*time.Hour), 10*time.Second))
+
+				Expect(deletions[1].Statuses).To(Equal([]string{"undeliverable"}))
+				Expect(deletions[1].Before).To(BeTemporally("~", time.Now().Add(-48*time.Hour), 10*time.Second))
+
+				Expect(deletions[2].Statuses).To(BeEmpty())
+				Expect(deletions[2].ExcludedStatuses).To(Equal([]string{"failed", "undeliverable", "scheduled"}))
+				Expect(deletions[2].Before).To(BeTemporally("~", time.Now().Add(-2*time.Minute), 10*time.Second))
+			})
+		})
+
+		Context("when a message is scheduled beyond the retention", func() {
+			BeforeEach(func() {
+				retention.StatusLifetimes = map[string]time.Duration{
+					"scheduled": time.Minute,
+				}
+
+				messageGC = postal.NewMessageGC(retention, database, repo, pollingInterval, log.New(loggerBuffer, "", 0))
+			})
+
+			It("never collects scheduled messages, even when their status has a retention", func() {
+				messageGC.Collect()
+
+				deletions := repo.DeleteBatchCall.Receives.Deletions
+				Expect(deletions).To(HaveLen(1))
+				Expect(deletions[0].Statuses).To(BeEmpty())
+				Expect(deletions[0].ExcludedStatuses).To(Equal([]string{"scheduled"}))
+			})
 		})
 
 		Context("When the repo errors unexpectantly", func() {
 			It("logs the error", func() {
-				repo.DeleteBeforeCall.Returns.Error = errors.New("messages table is totally corrupt")
+				repo.DeleteBatchCall.Returns.Error = errors.New("messages table is totally corrupt")
 
 				messageGC.Collect()
 
 				Expect(loggerBuffer.String()).To(ContainSubstring("messages table is totally corrupt"))
 			})
 		})
-
 	})
 })
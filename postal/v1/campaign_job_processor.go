@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/pivotal-golang/lager"
+	"github.com/rcrowley/go-metrics"
+	"gopkg.in/gorp.v1"
+)
+
+const DefaultQueueFullRetryDelay = 30 * time.Second
+
+type jobBatchEnqueuer interface {
+	EnqueueAll([]*gobble.Job, gobble.ConnectionInterface) ([]*gobble.Job, error)
+}
+
+type gobbleInitializer interface {
+	InitializeDBMap(*gorp.DbMap)
+}
+
+type CampaignJobProcessorConfig struct {
+	UAAHost             string
+	QueueFullRetryDelay time.Duration
+
+	Database          db.DatabaseInterface
+	TokenLoader       tokenLoader
+	UserLoader        userLoader
+	Queue             jobBatchEnqueuer
+	GobbleInitializer gobbleInitializer
+
+	DeliveryFailureHandler deliveryFailureHandler
+}
+
+type CampaignJobProcessor struct {
+	uaaHost             string
+	queueFullRetryDelay time.Duration
+
+	database          db.DatabaseInterface
+	tokenLoader       tokenLoader
+	userLoader        userLoader
+	queue             jobBatchEnqueuer
+	gobbleInitializer gobbleInitializer
+
+	deliveryFailureHandler deliveryFailureHandler
+}
+
+func NewCampaignJobProcessor(config CampaignJobProcessorConfig) CampaignJobProcessor {
+	if config.QueueFullRetryDelay == 0 {
+		config.QueueFullRetryDelay = DefaultQueueFullRetryDelay
+	}
+
+	return CampaignJobProcessor{
+		uaaHost:             config.UAAHost,
+		queueFullRetryDelay: config.QueueFullRetryDelay,
+
+		database:          config.Database,
+		tokenLoader:       config.TokenLoader,
+		userLoader:        config.UserLoader,
+		queue:             config.Queue,
+		gobbleInitializer: config.GobbleInitializer,
+
+		deliveryFailureHandler: config.DeliveryFailureHandler,
+	}
+}
+
+func (p CampaignJobProcessor) Process(job *gobble.Job, logger lager.Logger) error {
+	var campaign common.Campaign
+	err := job.Unmarshal(&campaign)
+	if err != nil {
+		metrics.GetOrRegisterCounter("notifications.worker.panic.json", nil).Inc(1)
+
+		p.deliveryFailureHandler.Handle(job, err, logger)
+		return nil
+	}
+
+	logger = logger.Session("campaign", lager.Data{
+		"vcap_request_id": campaign.VCAPRequestID,
+		"recipients":      len(campaign.Recipients),
+	})
+
+	token, err := p.tokenLoader.Load(p.uaaHost)
+	if err != nil {
+		p.deliveryFailureHandler.Handle(job, err, logger)
+		return nil
+	}
+
+	var userGUIDs []string
+	for _, recipient := range campaign.Recipients {
+		userGUIDs = append(userGUIDs, recipient.UserGUID)
+	}
+
+	users, err := p.userLoader.Load(userGUIDs, token)
+	if err != nil {
+		p.deliveryFailureHandler.Handle(job, err, logger)
+		return nil
+	}
+
+	var deliveries []*gobble.Job
+	for _, recipient := range campaign.Recipients {
+		user := users[recipient.UserGUID]
+
+		var email string
+		if len(user.Emails) > 0 {
+			email = user.Emails[0]
+		}
+
+		options := campaign.Options
+		if options.Locale == "" {
+			options.Locale = user.Locale
+		}
+
+		delivery := gobble.NewJob(common.Delivery{
+			MessageID:       recipient.MessageID,
+			Options:         options,
+			UserGUID:        recipient.UserGUID,
+			Email:           email,
+			Space:           campaign.Space,
+			Organization:    campaign.Organization,
+			ClientID:        campaign.ClientID,
+			UAAHost:         campaign.UAAHost,
+			Scope:           campaign.Scope,
+			VCAPRequestID:   campaign.VCAPRequestID,
+			RequestReceived: campaign.RequestReceived,
+		})
+		delivery.ClientID = job.ClientID
+		delivery.Priority = job.Priority
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	transaction := p.database.Connection().Transaction()
+	p.gobbleInitializer.InitializeDBMap(transaction.GetDbMap())
+
+	err = transaction.Begin()
+	if err != nil {
+		p.deliveryFailureHandler.Handle(job, err, logger)
+		return nil
+	}
+
+	_, err = p.queue.EnqueueAll(deliveries, transaction)
+	if _, ok := err.(gobble.QueueFullError); ok {
+		transaction.Rollback()
+		logger.Info("campaign-queue-full", lager.Data{"retry_delay": p.queueFullRetryDelay.String()})
+		metrics.GetOrRegisterCounter("notifications.worker.campaign.deferred", nil).Inc(1)
+
+		job.Defer(p.queueFullRetryDelay)
+		return nil
+	}
+
+	if err != nil {
+		transaction.Rollback()
+		p.deliveryFailureHandler.Handle(job, err, logger)
+		return nil
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		p.deliveryFailureHandler.Handle(job, err, logger)
+		return nil
+	}
+
+	logger.Info("campaign-expanded")
+	metrics.GetOrRegisterCounter("notifications.worker.campaign.expanded", nil).Inc(int64(len(campaign.Recipients)))
+
+	return nil
+}
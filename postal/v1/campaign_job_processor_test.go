@@ -0,0 +1,279 @@
+package v1_test
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/postal/v1"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/uaa"
+	"github.com/pivotal-golang/lager"
+	"gopkg.in/gorp.v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CampaignJobProcessor", func() {
+	var (
+		processor              v1.CampaignJobProcessor
+		logger                 lager.Logger
+		buffer                 *bytes.Buffer
+		database               *mocks.Database
+		conn                   *mocks.Connection
+		transaction            *mocks.Transaction
+		tokenLoader            *mocks.TokenLoader
+		userLoader             *mocks.UserLoader
+		queue                  *mocks.Queue
+		gobbleInitializer      *mocks.GobbleInitializer
+		deliveryFailureHandler *mocks.DeliveryFailureHandler
+		campaign               common.Campaign
+		job                    *gobble.Job
+		requestReceived        time.Time
+	)
+
+	BeforeEach(func() {
+		buffer = bytes.NewBuffer([]byte{})
+		logger = lager.NewLogger("notifications")
+		logger.RegisterSink(lager.NewWriterSink(buffer, lager.DEBUG))
+
+		transaction = mocks.NewTransaction()
+		transaction.Connection = mocks.NewConnection()
+		transaction.GetDbMapCall.Returns.DbMap = &gorp.DbMap{}
+
+		conn = mocks.NewConnection()
+		conn.TransactionCall.Returns.Transaction = transaction
+
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		tokenLoader = mocks.NewTokenLoader()
+		tokenLoader.LoadCall.Returns.Token = "some-token"
+
+		userLoader = mocks.NewUserLoader()
+		userLoader.LoadCall.Returns.Users = map[string]uaa.User{
+			"user-1": {ID: "user-1", Emails: []string{"user-1@example.com"}},
+			"user-2": {ID: "user-2"},
+		}
+
+		queue = mocks.NewQueue()
+		queue.EnqueueAllCall.Returns.Jobs = []*gobble.Job{{}, {}}
+
+		gobbleInitializer = mocks.NewGobbleInitializer()
+		deliveryFailureHandler = mocks.NewDeliveryFailureHandler()
+
+		processor = v1.NewCampaignJobProcessor(v1.CampaignJobProcessorConfig{
+			UAAHost:             "https://uaa.example.com",
+			QueueFullRetryDelay: time.Minute,
+
+			Database:          database,
+			TokenLoader:       tokenLoader,
+			UserLoader:        userLoader,
+			Queue:             queue,
+			GobbleInitializer: gobbleInitializer,
+
+			DeliveryFailureHandler: deliveryFailureHandler,
+		})
+
+		requestReceived = time.Date(2015, 6, 8, 14, 40, 12, 0, time.UTC)
+		campaign = common.Campaign{
+			JobType: common.CampaignJobType,
+			Options: common.Options{
+				KindID:  "some-kind",
+				Subject: "the subject",
+			},
+			Recipients: []common.CampaignRecipient{
+				{UserGUID: "user-1", MessageID: "message-1"},
+				{UserGUID: "user-2", MessageID: "message-2"},
+			},
+			Organization:    cf.CloudControllerOrganization{GUID: "org-guid", Name: "my-org"},
+			ClientID:        "some-client",
+			UAAHost:         "https://uaa.example.com",
+			VCAPRequestID:   "some-request-id",
+			RequestReceived: requestReceived,
+		}
+		job = gobble.NewJob(campaign)
+	})
+
+	It("resolves all of the recipients with a single UAA lookup", func() {
+		Expect(processor.Process(job, logger)).To(Succeed())
+
+		Expect(tokenLoader.LoadCall.Receives.UAAHost).To(Equal("https://uaa.example.com"))
+		Expect(userLoader.LoadCall.Receives.Token).To(Equal("some-token"))
+		Expect(userLoader.LoadCall.Receives.UserGUIDs).To(Equal([]string{"user-1", "user-2"}))
+	})
+
+	It("expands the campaign into a batch of delivery jobs, one per recipient, within a transaction", func() {
+		Expect(processor.Process(job, logger)).To(Succeed())
+
+		Expect(gobbleInitializer.InitializeDBMapCall.Receives.DbMap).To(BeIdenticalTo(transaction.GetDbMapCall.Returns.DbMap))
+		Expect(queue.EnqueueAllCall.Receives.Connection).To(Equal(transaction))
+		Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+		Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+
+		var deliveries []common.Delivery
+		for _, enqueued := range queue.EnqueueAllCall.Receives.Jobs {
+			var delivery common.Delivery
+			Expect(enqueued.Unmarshal(&delivery)).To(Succeed())
+			deliveries = append(deliveries, delivery)
+		}
+
+		Expect(deliveries).To(Equal([]common.Delivery{
+			{
+				MessageID:       "message-1",
+				Options:         campaign.Options,
+				UserGUID:        "user-1",
+				Email:           "user-1@example.com",
+				Organization:    campaign.Organization,
+				ClientID:        "some-client",
+				UAAHost:         "https://uaa.example.com",
+				VCAPRequestID:   "some-request-id",
+				RequestReceived: requestReceived,
+			},
+			{
+				MessageID:       "message-2",
+				Options:         campaign.Options,
+				UserGUID:        "user-2",
+				Organization:    campaign.Organization,
+				ClientID:        "some-client",
+				UAAHost:         "https://uaa.example.com",
+				VCAPRequestID:   "some-request-id",
+				RequestReceived: requestReceived,
+			},
+		}))
+
+		Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
+	})
+
+	Context("when the recipients have a locale in UAA", func() {
+		BeforeEach(func() {
+			userLoader.LoadCall.Returns.Users["user-1"] = uaa.User{ID: "user-1", Emails: []string{"user-1@example.com"}, Locale: "fr-FR"}
+		})
+
+		It("uses each recipient's locale for their delivery", func() {
+			Expect(processor.Process(job, logger)).To(Succeed())
+
+			var locales []string
+			for _, enqueued := range queue.EnqueueAllCall.Receives.Jobs {
+				var delivery common.Delivery
+				Expect(enqueued.Unmarshal(&delivery)).To(Succeed())
+				locales = append(locales, delivery.Options.Locale)
+			}
+
+			Expect(locales).To(Equal([]string{"fr-FR", ""}))
+		})
+
+		It("keeps the locale requested for the campaign", func() {
+			campaign.Options.Locale = "de-DE"
+			job = gobble.NewJob(campaign)
+
+			Expect(processor.Process(job, logger)).To(Succeed())
+
+			for _, enqueued := range queue.EnqueueAllCall.Receives.Jobs {
+				var delivery common.Delivery
+				Expect(enqueued.Unmarshal(&delivery)).To(Succeed())
+				Expect(delivery.Options.Locale).To(Equal("de-DE"))
+			}
+		})
+	})
+
+	It("carries the campaign's client and priority over to the delivery jobs", func() {
+		job.ClientID = "some-client"
+		job.Priority = gobble.PriorityCritical
+
+		Expect(processor.Process(job, logger)).To(Succeed())
+
+		Expect(queue.EnqueueAllCall.Receives.Jobs).To(HaveLen(2))
+		for _, enqueued := range queue.EnqueueAllCall.Receives.Jobs {
+			Expect(enqueued.ClientID).To(Equal("some-client"))
+			Expect(enqueued.Priority).To(Equal(gobble.PriorityCritical))
+		}
+	})
+
+	Context("when the queue is full", func() {
+		BeforeEach(func() {
+			queue.EnqueueAllCall.Returns.Jobs = nil
+			queue.EnqueueAllCall.Returns.Error = gobble.QueueFullError{Length: 10, MaxLength: 10}
+		})
+
+		It("rolls back and retries the campaign later without counting it as a failure", func() {
+			job.RetryCount = 2
+
+			Expect(processor.Process(job, logger)).To(Succeed())
+
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+			Expect(job.ShouldRetry).To(BeTrue())
+			Expect(job.RetryCount).To(Equal(2))
+			Expect(job.ActiveAt).To(BeTemporally("~", time.Now().Add(time.Minute), 5*time.Second))
+			Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
+
+			lines, err := parseLogLines(buffer.Bytes())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(lines).To(ContainElement(logLine{
+				Source:   "notifications",
+				Message:  "notifications.campaign.campaign-queue-full",
+				LogLevel: int(lager.INFO),
+				Data: map[string]interface{}{
+					"session":         "1",
+					"recipients":      float64(2),
+					"retry_delay":     "1m0s",
+					"vcap_request_id": "some-request-id",
+				},
+			}))
+		})
+	})
+
+	Context("when the job cannot be unmarshalled", func() {
+		It("hands the job to the failure handler", func() {
+			job = &gobble.Job{Payload: "%%"}
+
+			Expect(processor.Process(job, logger)).To(Succeed())
+			Expect(deliveryFailureHandler.HandleCall.Receives.Job).To(Equal(job))
+		})
+	})
+
+	Context("when the token cannot be loaded", func() {
+		It("hands the job to the failure handler", func() {
+			tokenLoader.LoadCall.Returns.Error = errors.New("no token")
+
+			Expect(processor.Process(job, logger)).To(Succeed())
+			Expect(deliveryFailureHandler.HandleCall.Receives.Error).To(MatchError(errors.New("no token")))
+			Expect(queue.EnqueueAllCall.Receives.Jobs).To(BeEmpty())
+		})
+	})
+
+	Context("when the users cannot be loaded", func() {
+		It("hands the job to the failure handler", func() {
+			userLoader.LoadCall.Returns.Error = errors.New("uaa is down")
+
+			Expect(processor.Process(job, logger)).To(Succeed())
+			Expect(deliveryFailureHandler.HandleCall.Receives.Error).To(MatchError(errors.New("uaa is down")))
+			Expect(queue.EnqueueAllCall.Receives.Jobs).To(BeEmpty())
+		})
+	})
+
+	Context("when enqueuing a delivery fails", func() {
+		It("rolls back and hands the job to the failure handler", func() {
+			queue.EnqueueAllCall.Returns.Error = errors.New("database is down")
+
+			Expect(processor.Process(job, logger)).To(Succeed())
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(deliveryFailureHandler.HandleCall.Receives.Error).To(MatchError(errors.New("database is down")))
+		})
+	})
+
+	Context("when the transaction cannot be committed", func() {
+		It("hands the job to the failure handler", func() {
+			transaction.CommitCall.Returns.Error = errors.New("commit failed")
+
+			Expect(processor.Process(job, logger)).To(Succeed())
+			Expect(deliveryFailureHandler.HandleCall.Receives.Error).To(MatchError(errors.New("commit failed")))
+		})
+	})
+})
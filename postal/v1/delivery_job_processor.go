@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/db"
 	"github.com/cloudfoundry-incubator/notifications/gobble"
@@ -30,8 +32,12 @@ type messageStatusUpdater interface {
 	Update(conn db.ConnectionInterface, messageID, messageStatus, campaignID string, logger lager.Logger)
 }
 
+type messageEventRecorder interface {
+	Record(conn db.ConnectionInterface, messageID, event, detail string, logger lager.Logger)
+}
+
 type deliveryFailureHandler interface {
-	Handle(job common.Retryable, logger lager.Logger)
+	Handle(job common.Retryable, reason error, logger lager.Logger)
 }
 
 type kindsFinder interface {
@@ -46,15 +52,26 @@ type unsubscribesGetter interface {
 	Get(connection models.ConnectionInterface, userGUID string, clientID string, kindID string) (bool, error)
 }
 
+type scheduledMessagesRepo interface {
+	FindByID(connection models.ConnectionInterface, messageID string) (models.Message, error)
+	TransitionStatus(connection models.ConnectionInterface, messageID, fromStatus, toStatus string) (bool, error)
+}
+
 type globalUnsubscribesGetter interface {
 	Get(connection models.ConnectionInterface, userGUID string) (bool, error)
 }
 
+type suppressionsRepo interface {
+	IsSuppressed(connection models.ConnectionInterface, email string) (bool, error)
+	Suppress(connection models.ConnectionInterface, email, reason string) error
+}
+
 type DeliveryJobProcessorConfig struct {
-	DBTrace bool
-	UAAHost string
-	Sender  string
-	Domain  string
+	DBTrace   bool
+	UAAHost   string
+	Sender    string
+	Domain    string
+	PublicURL string
 
 	Packager    common.Packager
 	MailClient  mailSender
@@ -66,15 +83,19 @@ type DeliveryJobProcessorConfig struct {
 	ReceiptsRepo           receiptsCreator
 	UnsubscribesRepo       unsubscribesGetter
 	GlobalUnsubscribesRepo globalUnsubscribesGetter
+	MessagesRepo           scheduledMessagesRepo
+	SuppressionsRepo       suppressionsRepo
 	MessageStatusUpdater   messageStatusUpdater
+	MessageEventRecorder   messageEventRecorder
 	DeliveryFailureHandler deliveryFailureHandler
 }
 
 type DeliveryJobProcessor struct {
-	dbTrace bool
-	uaaHost string
-	sender  string
-	domain  string
+	dbTrace   bool
+	uaaHost   string
+	sender    string
+	domain    string
+	publicURL string
 
 	packager    common.Packager
 	mailClient  mailSender
@@ -86,16 +107,20 @@ type DeliveryJobProcessor struct {
 	receiptsRepo           receiptsCreator
 	unsubscribesRepo       unsubscribesGetter
 	globalUnsubscribesRepo globalUnsubscribesGetter
+	messagesRepo           scheduledMessagesRepo
+	suppressionsRepo       suppressionsRepo
 	messageStatusUpdater   messageStatusUpdater
+	messageEventRecorder   messageEventRecorder
 	deliveryFailureHandler deliveryFailureHandler
 }
 
 func NewDeliveryJobProcessor(config DeliveryJobProcessorConfig) DeliveryJobProcessor {
 	return DeliveryJobProcessor{
-		dbTrace: config.DBTrace,
-		uaaHost: config.UAAHost,
-		sender:  config.Sender,
-		domain:  config.Domain,
+		dbTrace:   config.DBTrace,
+		uaaHost:   config.UAAHost,
+		sender:    config.Sender,
+		domain:    config.Domain,
+		publicURL: config.PublicURL,
 
 		packager:    config.Packager,
 		mailClient:  config.MailClient,
@@ -107,7 +132,10 @@ func NewDeliveryJobProcessor(config DeliveryJobProcessorConfig) DeliveryJobProce
 		receiptsRepo:           config.ReceiptsRepo,
 		unsubscribesRepo:       config.UnsubscribesRepo,
 		globalUnsubscribesRepo: config.GlobalUnsubscribesRepo,
+		messagesRepo:           config.MessagesRepo,
+		suppressionsRepo:       config.SuppressionsRepo,
 		messageStatusUpdater:   config.MessageStatusUpdater,
+		messageEventRecorder:   config.MessageEventRecorder,
 		deliveryFailureHandler: config.DeliveryFailureHandler,
 	}
 }
@@ -118,7 +146,7 @@ func (p DeliveryJobProcessor) Process(job *gobble.Job, logger lager.Logger) erro
 	if err != nil {
 		metrics.GetOrRegisterCounter("notifications.worker.panic.json", nil).Inc(1)
 
-		p.deliveryFailureHandler.Handle(job, logger)
+		p.deliveryFailureHandler.Handle(job, err, logger)
 		return nil
 	}
 
@@ -131,9 +159,17 @@ func (p DeliveryJobProcessor) Process(job *gobble.Job, logger lager.Logger) erro
 		p.database.TraceOn("", gorpCompatibleLogger{logger})
 	}
 
+	if p.isCancelled(delivery.MessageID) {
+		logger.Info("message-cancelled")
+		metrics.GetOrRegisterCounter("notifications.worker.cancelled", nil).Inc(1)
+		return nil
+	}
+
+	p.recordEvent(delivery.MessageID, models.MessageEventReserved, job.WorkerID, logger)
+
 	err = p.receiptsRepo.CreateReceipts(p.database.Connection(), []string{delivery.UserGUID}, delivery.ClientID, delivery.Options.KindID)
 	if err != nil {
-		p.deliveryFailureHandler.Handle(job, logger)
+		p.handleFailure(job, delivery.MessageID, err, logger)
 		return nil
 	}
 
@@ -142,13 +178,13 @@ func (p DeliveryJobProcessor) Process(job *gobble.Job, logger lager.Logger) erro
 
 		token, err = p.tokenLoader.Load(p.uaaHost)
 		if err != nil {
-			p.deliveryFailureHandler.Handle(job, logger)
+			p.handleFailure(job, delivery.MessageID, err, logger)
 			return nil
 		}
 
 		users, err := p.userLoader.Load([]string{delivery.UserGUID}, token)
 		if err != nil || len(users) < 1 {
-			p.deliveryFailureHandler.Handle(job, logger)
+			p.handleFailure(job, delivery.MessageID, err, logger)
 			return nil
 		}
 
@@ -156,17 +192,23 @@ func (p DeliveryJobProcessor) Process(job *gobble.Job, logger lager.Logger) erro
 		if len(emails) > 0 {
 			delivery.Email = emails[0]
 		}
+
+		if delivery.Options.Locale == "" {
+			delivery.Options.Locale = users[delivery.UserGUID].Locale
+		}
 	}
 
 	logger = logger.WithData(lager.Data{
 		"recipient": delivery.Email,
 	})
 
-	if p.shouldDeliver(delivery, logger) {
-		status := p.process(delivery, logger)
+	critical := p.isCritical(p.database.Connection(), delivery.Options.KindID, delivery.ClientID)
+
+	if p.shouldDeliver(delivery, critical, logger) {
+		status, err := p.process(delivery, critical, logger)
 
 		if status != common.StatusDelivered {
-			p.deliveryFailureHandler.Handle(job, logger)
+			p.handleFailure(job, delivery.MessageID, err, logger)
 			return nil
 		} else {
 			metrics.GetOrRegisterCounter("notifications.worker.delivered", nil).Inc(1)
@@ -178,28 +220,79 @@ func (p DeliveryJobProcessor) Process(job *gobble.Job, logger lager.Logger) erro
 	return nil
 }
 
-func (p DeliveryJobProcessor) process(delivery common.Delivery, logger lager.Logger) string {
+func (p DeliveryJobProcessor) handleFailure(job *gobble.Job, messageID string, reason error, logger lager.Logger) {
+	detail := "unknown error"
+	if reason != nil {
+		detail = reason.Error()
+	}
+	p.recordEvent(messageID, models.MessageEventFailed, detail, logger)
+
+	p.deliveryFailureHandler.Handle(job, reason, logger)
+
+	switch {
+	case job.ShouldDeadLetter:
+		p.recordEvent(messageID, models.MessageEventDeadLettered, "", logger)
+	case job.ShouldRetry:
+		p.recordEvent(messageID, models.MessageEventRetried, job.ActiveAt.UTC().Format(time.RFC3339), logger)
+	}
+}
+
+func (p DeliveryJobProcessor) recordEvent(messageID, event, detail string, logger lager.Logger) {
+	p.messageEventRecorder.Record(p.database.Connection(), messageID, event, detail, logger)
+}
+
+func templateVersion(context common.MessageContext) string {
+	if context.TemplateID == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s@%d", context.TemplateID, context.TemplateVersion)
+}
+
+func (p DeliveryJobProcessor) process(delivery common.Delivery, critical bool, logger lager.Logger) (string, error) {
 	context, err := p.packager.PrepareContext(delivery, p.sender, p.domain)
 	if err != nil {
-		panic(err)
+		logger.Error("template-load-failed", err)
+		return common.StatusFailed, err
 	}
+	context.Critical = critical
+	context.PublicURL = p.publicURL
 
 	message, err := p.packager.Pack(context)
 	if err != nil {
 		logger.Info("template-pack-failed")
 		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusFailed, "", logger)
-		return common.StatusFailed
+		return common.StatusFailed, err
 	}
+	p.recordEvent(delivery.MessageID, models.MessageEventTemplatePacked, templateVersion(context), logger)
 
-	status := p.sendMail(delivery.MessageID, message, logger)
+	status, err := p.sendMail(delivery.MessageID, message, logger)
 	p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, status, "", logger)
 
-	return status
+	if mail.IsRecipientRejected(err) {
+		p.suppress(delivery, err, logger)
+	}
+
+	if status == common.StatusDelivered {
+		p.recordEvent(delivery.MessageID, models.MessageEventSMTPAccepted, "", logger)
+	}
+
+	return status, err
 }
 
-func (p DeliveryJobProcessor) shouldDeliver(delivery common.Delivery, logger lager.Logger) bool {
+func (p DeliveryJobProcessor) shouldDeliver(delivery common.Delivery, critical bool, logger lager.Logger) bool {
 	conn := p.database.Connection()
-	if p.isCritical(conn, delivery.Options.KindID, delivery.ClientID) {
+	if delivery.Email != "" {
+		suppressed, err := p.suppressionsRepo.IsSuppressed(conn, delivery.Email)
+		if err == nil && suppressed {
+			logger.Info("recipient-suppressed")
+			p.messageStatusUpdater.Update(conn, delivery.MessageID, common.StatusUndeliverable, "", logger)
+			p.recordEvent(delivery.MessageID, models.MessageEventSuppressedSkipped, "email address is suppressed", logger)
+			return false
+		}
+	}
+
+	if critical {
 		return true
 	}
 
@@ -207,6 +300,7 @@ func (p DeliveryJobProcessor) shouldDeliver(delivery common.Delivery, logger lag
 	if err != nil || globallyUnsubscribed {
 		logger.Info("user-unsubscribed")
 		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", logger)
+		p.recordEvent(delivery.MessageID, models.MessageEventUnsubscribedSkipped, "globally unsubscribed", logger)
 		return false
 	}
 
@@ -214,29 +308,45 @@ func (p DeliveryJobProcessor) shouldDeliver(delivery common.Delivery, logger lag
 	if err != nil || isUnsubscribed {
 		logger.Info("user-unsubscribed")
 		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", logger)
+		p.recordEvent(delivery.MessageID, models.MessageEventUnsubscribedSkipped, "unsubscribed from kind", logger)
 		return false
 	}
 
 	if delivery.Email == "" {
 		logger.Info("no-email-address-for-user")
 		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", logger)
+		p.recordEvent(delivery.MessageID, models.MessageEventUndeliverable, "no email address for user", logger)
 		return false
 	}
 
 	if !strings.Contains(delivery.Email, "@") {
 		logger.Info("malformatted-email-address")
 		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", logger)
+		p.recordEvent(delivery.MessageID, models.MessageEventUndeliverable, "malformatted email address", logger)
 		return false
 	}
 
 	return true
 }
 
-func (p DeliveryJobProcessor) sendMail(messageID string, message mail.Message, logger lager.Logger) string {
+func (p DeliveryJobProcessor) suppress(delivery common.Delivery, reason error, logger lager.Logger) {
+	p.recordEvent(delivery.MessageID, models.MessageEventBounced, reason.Error(), logger)
+	metrics.GetOrRegisterCounter("notifications.worker.bounced", nil).Inc(1)
+
+	err := p.suppressionsRepo.Suppress(p.database.Connection(), delivery.Email, reason.Error())
+	if err != nil {
+		logger.Error("recipient-suppression-failed", err)
+		return
+	}
+
+	logger.Info("recipient-suppressed-after-bounce")
+}
+
+func (p DeliveryJobProcessor) sendMail(messageID string, message mail.Message, logger lager.Logger) (string, error) {
 	err := p.mailClient.Connect(logger)
 	if err != nil {
 		logger.Error("smtp-connection-error", err)
-		return common.StatusFailed
+		return common.StatusFailed, err
 	}
 
 	logger.Info("delivery-start")
@@ -244,12 +354,32 @@ func (p DeliveryJobProcessor) sendMail(messageID string, message mail.Message, l
 	err = p.mailClient.Send(message, logger)
 	if err != nil {
 		logger.Error("delivery-failed-smtp-error", err)
-		return common.StatusFailed
+		return common.StatusFailed, err
 	}
 
 	logger.Info("message-sent")
 
-	return common.StatusDelivered
+	return common.StatusDelivered, nil
+}
+
+// isCancelled reports whether the message must not be sent. A message that no
+// longer exists is treated as cancelled, since it may have been cancelled and
+// then garbage collected.
+func (p DeliveryJobProcessor) isCancelled(messageID string) bool {
+	conn := p.database.Connection()
+
+	_, err := p.messagesRepo.TransitionStatus(conn, messageID, common.StatusScheduled, common.StatusQueued)
+	if err != nil {
+		return false
+	}
+
+	message, err := p.messagesRepo.FindByID(conn, messageID)
+	if err != nil {
+		_, notFound := err.(models.NotFoundError)
+		return notFound
+	}
+
+	return message.Status == common.StatusCancelled
 }
 
 func (p DeliveryJobProcessor) isCritical(conn db.ConnectionInterface, kindID, clientID string) bool {
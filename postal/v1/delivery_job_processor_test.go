@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/md5"
 	"errors"
+	"net/textproto"
 	"strings"
 	"time"
 
@@ -41,6 +42,9 @@ var _ = Describe("DeliveryJobProcessor", func() {
 		tokenLoader            *mocks.TokenLoader
 		messageID              string
 		messageStatusUpdater   *mocks.MessageStatusUpdater
+		messageEventRecorder   *mocks.MessageEventRecorder
+		messagesRepo           *mocks.MessagesRepo
+		suppressionsRepo       *mocks.SuppressionsRepo
 		deliveryFailureHandler *mocks.DeliveryFailureHandler
 	)
 
@@ -80,22 +84,28 @@ var _ = Describe("DeliveryJobProcessor", func() {
 		tokenLoader = mocks.NewTokenLoader()
 		templateLoader = mocks.NewTemplatesLoader()
 		templateLoader.LoadTemplatesCall.Returns.Templates = common.Templates{
+			ID:      "some-template-id",
+			Version: 3,
 			Text:    "{{.Text}} {{.Domain}}",
 			HTML:    "<p>{{.HTML}}</p>",
 			Subject: "{{.Subject}}",
 		}
 		receiptsRepo = mocks.NewReceiptsRepo()
 		messageStatusUpdater = mocks.NewMessageStatusUpdater()
+		messageEventRecorder = mocks.NewMessageEventRecorder()
+		messagesRepo = mocks.NewMessagesRepo()
+		suppressionsRepo = mocks.NewSuppressionsRepo()
 		deliveryFailureHandler = mocks.NewDeliveryFailureHandler()
 
 		cloak, err := conceal.NewCloak(encryptionKey)
 		Expect(err).NotTo(HaveOccurred())
 
 		processor = v1.NewDeliveryJobProcessor(v1.DeliveryJobProcessorConfig{
-			DBTrace: false,
-			UAAHost: "https://uaa.example.com",
-			Sender:  "from@example.com",
-			Domain:  "example.com",
+			DBTrace:   false,
+			UAAHost:   "https://uaa.example.com",
+			Sender:    "from@example.com",
+			Domain:    "example.com",
+			PublicURL: "https://notifications.example.com",
 
 			Packager:    common.NewPackager(templateLoader, cloak),
 			MailClient:  mailClient,
@@ -107,7 +117,10 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			ReceiptsRepo:           receiptsRepo,
 			UnsubscribesRepo:       unsubscribesRepo,
 			GlobalUnsubscribesRepo: globalUnsubscribesRepo,
+			MessagesRepo:           messagesRepo,
+			SuppressionsRepo:       suppressionsRepo,
 			MessageStatusUpdater:   messageStatusUpdater,
+			MessageEventRecorder:   messageEventRecorder,
 			DeliveryFailureHandler: deliveryFailureHandler,
 		})
 
@@ -154,6 +167,49 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			}))
 		})
 
+		It("skips messages that were cancelled before they were sent", func() {
+			messagesRepo.FindByIDCall.Returns.Message = models.Message{ID: messageID, Status: common.StatusCancelled}
+
+			err := processor.Process(job, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(messagesRepo.FindByIDCall.Receives.Connection).To(Equal(conn))
+			Expect(messagesRepo.FindByIDCall.Receives.MessageID).To(Equal(messageID))
+			Expect(mailClient.SendCall.CallCount).To(Equal(0))
+			Expect(receiptsRepo.CreateReceiptsCall.Receives.UserGUIDs).To(BeEmpty())
+			Expect(messageStatusUpdater.UpdateCall.Receives.MessageID).To(BeEmpty())
+			Expect(messageEventRecorder.RecordCall.Receives.Events).To(BeEmpty())
+		})
+
+		It("moves scheduled messages to queued so they can no longer be cancelled", func() {
+			err := processor.Process(job, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(messagesRepo.TransitionStatusCall.Receives.Connection).To(Equal(conn))
+			Expect(messagesRepo.TransitionStatusCall.Receives.MessageID).To(Equal(messageID))
+			Expect(messagesRepo.TransitionStatusCall.Receives.FromStatus).To(Equal(common.StatusScheduled))
+			Expect(messagesRepo.TransitionStatusCall.Receives.ToStatus).To(Equal(common.StatusQueued))
+		})
+
+		It("skips messages that no longer exist", func() {
+			messagesRepo.FindByIDCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			err := processor.Process(job, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mailClient.SendCall.CallCount).To(Equal(0))
+			Expect(messageStatusUpdater.UpdateCall.Receives.MessageID).To(BeEmpty())
+		})
+
+		It("delivers the message when its status cannot be looked up", func() {
+			messagesRepo.FindByIDCall.Returns.Error = errors.New("BOOM!")
+
+			err := processor.Process(job, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mailClient.SendCall.CallCount).To(Equal(1))
+		})
+
 		It("loads the correct template", func() {
 			processor.Process(job, logger)
 
@@ -162,6 +218,27 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			Expect(templateLoader.LoadTemplatesCall.Receives.TemplateID).To(Equal("some-template-id"))
 		})
 
+		Context("when the recipient has a locale in UAA", func() {
+			BeforeEach(func() {
+				userLoader.LoadCall.Returns.Users = map[string]uaa.User{
+					"user-123": {Emails: []string{fakeUserEmail}, Locale: "fr-CA"},
+				}
+			})
+
+			It("loads the template for the recipient's locale", func() {
+				processor.Process(job, logger)
+
+				Expect(templateLoader.LoadTemplatesCall.Receives.Locale).To(Equal("fr-CA"))
+			})
+
+			It("prefers a locale given explicitly on the request", func() {
+				delivery.Options.Locale = "pt-BR"
+				processor.Process(gobble.NewJob(delivery), logger)
+
+				Expect(templateLoader.LoadTemplatesCall.Receives.Locale).To(Equal("pt-BR"))
+			})
+		})
+
 		It("logs successful delivery", func() {
 			processor.Process(job, logger)
 
@@ -203,7 +280,10 @@ var _ = Describe("DeliveryJobProcessor", func() {
 				ReceiptsRepo:           receiptsRepo,
 				UnsubscribesRepo:       unsubscribesRepo,
 				GlobalUnsubscribesRepo: globalUnsubscribesRepo,
+				MessagesRepo:           messagesRepo,
+				SuppressionsRepo:       suppressionsRepo,
 				MessageStatusUpdater:   messageStatusUpdater,
+				MessageEventRecorder:   messageEventRecorder,
 				DeliveryFailureHandler: deliveryFailureHandler,
 			})
 			processor.Process(job, logger)
@@ -227,6 +307,19 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			Expect(messageStatusUpdater.UpdateCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
 		})
 
+		It("records the timeline of a successful delivery", func() {
+			job.WorkerID = "worker-1"
+			processor.Process(job, logger)
+
+			Expect(messageEventRecorder.RecordCall.Receives.Connection).To(Equal(conn))
+			Expect(messageEventRecorder.RecordCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
+			Expect(messageEventRecorder.RecordCall.Receives.Events).To(Equal([]models.MessageEvent{
+				{MessageID: messageID, Event: models.MessageEventReserved, Detail: "worker-1"},
+				{MessageID: messageID, Event: models.MessageEventTemplatePacked, Detail: "some-template-id@3"},
+				{MessageID: messageID, Event: models.MessageEventSMTPAccepted},
+			}))
+		})
+
 		It("creates a reciept for the delivery", func() {
 			processor.Process(job, logger)
 
@@ -242,6 +335,7 @@ var _ = Describe("DeliveryJobProcessor", func() {
 				processor.Process(job, logger)
 
 				Expect(deliveryFailureHandler.HandleCall.Receives.Job).To(Equal(job))
+				Expect(deliveryFailureHandler.HandleCall.Receives.Error).To(MatchError("something happened"))
 				Expect(deliveryFailureHandler.HandleCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
 			})
 		})
@@ -291,6 +385,23 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			Expect(timestamp).To(BeTemporally("~", time.Now(), 2*time.Second))
 		})
 
+		It("includes one-click unsubscribe headers for non-critical kinds", func() {
+			processor.Process(job, logger)
+
+			msg := mailClient.SendCall.Receives.Message
+			Expect(msg.Headers).To(ContainElement(MatchRegexp(`^List-Unsubscribe: <mailto:unsubscribe\+[^@>]+@example\.com>, <https://notifications\.example\.com/unsubscribe/[^>]+>$`)))
+			Expect(msg.Headers).To(ContainElement("List-Unsubscribe-Post: List-Unsubscribe=One-Click"))
+		})
+
+		It("omits the unsubscribe headers for critical kinds", func() {
+			kindsRepo.FindCall.Returns.Kinds[0].Critical = true
+
+			processor.Process(job, logger)
+
+			msg := mailClient.SendCall.Receives.Message
+			Expect(msg.Headers).NotTo(ContainElement(HavePrefix("List-Unsubscribe")))
+		})
+
 		It("should connect and send the message with the worker's logger session", func() {
 			processor.Process(job, logger)
 			Expect(mailClient.ConnectCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
@@ -307,9 +418,42 @@ var _ = Describe("DeliveryJobProcessor", func() {
 					processor.Process(job, logger)
 
 					Expect(deliveryFailureHandler.HandleCall.Receives.Job).To(Equal(job))
+					Expect(deliveryFailureHandler.HandleCall.Receives.Error).To(MatchError("Error sending message!!!"))
 					Expect(deliveryFailureHandler.HandleCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
 				})
 
+				It("records the failure and the retry in the timeline", func() {
+					deliveryFailureHandler.HandleCall.Hook = func() {
+						job.Retry(time.Minute)
+					}
+
+					processor.Process(job, logger)
+
+					events := messageEventRecorder.RecordCall.Receives.Events
+					Expect(events).To(HaveLen(4))
+					Expect(events[2]).To(Equal(models.MessageEvent{
+						MessageID: messageID,
+						Event:     models.MessageEventFailed,
+						Detail:    "Error sending message!!!",
+					}))
+					Expect(events[3].Event).To(Equal(models.MessageEventRetried))
+					Expect(events[3].Detail).To(Equal(job.ActiveAt.UTC().Format(time.RFC3339)))
+				})
+
+				It("records that the job was dead lettered when it is given up on", func() {
+					deliveryFailureHandler.HandleCall.Hook = func() {
+						job.GiveUp()
+					}
+
+					processor.Process(job, logger)
+
+					events := messageEventRecorder.RecordCall.Receives.Events
+					Expect(events[len(events)-1]).To(Equal(models.MessageEvent{
+						MessageID: messageID,
+						Event:     models.MessageEventDeadLettered,
+					}))
+				})
+
 				It("logs an SMTP send error", func() {
 					processor.Process(job, logger)
 
@@ -383,6 +527,71 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			})
 		})
 
+		Context("when the recipient is permanently rejected", func() {
+			var rejection error
+
+			BeforeEach(func() {
+				rejection = mail.RecipientError{Recipient: fakeUserEmail, Err: &textproto.Error{Code: 550, Msg: "5.1.1 mailbox unavailable"}}
+				mailClient.SendCall.Returns.Error = rejection
+
+				processor.Process(job, logger)
+			})
+
+			It("suppresses the email address", func() {
+				Expect(suppressionsRepo.SuppressCall.Receives.Connection).To(Equal(conn))
+				Expect(suppressionsRepo.SuppressCall.Receives.Email).To(Equal(fakeUserEmail))
+				Expect(suppressionsRepo.SuppressCall.Receives.Reason).To(Equal(rejection.Error()))
+			})
+
+			It("records the bounce in the timeline", func() {
+				Expect(messageEventRecorder.RecordCall.Receives.Events).To(ContainElement(models.MessageEvent{
+					MessageID: messageID,
+					Event:     models.MessageEventBounced,
+					Detail:    rejection.Error(),
+				}))
+			})
+
+			It("hands the failure to the failure handler", func() {
+				Expect(deliveryFailureHandler.HandleCall.Receives.Error).To(Equal(rejection))
+			})
+		})
+
+		Context("when the send fails for another reason", func() {
+			It("does not suppress the email address", func() {
+				mailClient.SendCall.Returns.Error = &textproto.Error{Code: 451, Msg: "4.7.1 try again later"}
+
+				processor.Process(job, logger)
+
+				Expect(suppressionsRepo.SuppressCall.WasCalled).To(BeFalse())
+			})
+		})
+
+		Context("when the email address is suppressed", func() {
+			BeforeEach(func() {
+				suppressionsRepo.IsSuppressedCall.Returns.Suppressed = true
+				kindsRepo.FindCall.Returns.Kinds[0].Critical = true
+
+				processor.Process(job, logger)
+			})
+
+			It("does not send the message, even for critical kinds", func() {
+				Expect(suppressionsRepo.IsSuppressedCall.Receives.Email).To(Equal(fakeUserEmail))
+				Expect(mailClient.SendCall.CallCount).To(Equal(0))
+			})
+
+			It("updates the message status as undeliverable", func() {
+				Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusUndeliverable))
+			})
+
+			It("records that the message was skipped", func() {
+				Expect(messageEventRecorder.RecordCall.Receives.Events).To(ContainElement(models.MessageEvent{
+					MessageID: messageID,
+					Event:     models.MessageEventSuppressedSkipped,
+					Detail:    "email address is suppressed",
+				}))
+			})
+		})
+
 		Context("when recipient has globally unsubscribed", func() {
 			BeforeEach(func() {
 				globalUnsubscribesRepo.GetCall.Returns.Unsubscribed = true
@@ -418,6 +627,14 @@ var _ = Describe("DeliveryJobProcessor", func() {
 				Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusUndeliverable))
 				Expect(messageStatusUpdater.UpdateCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
 			})
+
+			It("records that the message was skipped", func() {
+				Expect(messageEventRecorder.RecordCall.Receives.Events).To(ContainElement(models.MessageEvent{
+					MessageID: messageID,
+					Event:     models.MessageEventUnsubscribedSkipped,
+					Detail:    "globally unsubscribed",
+				}))
+			})
 		})
 
 		Context("when the recipient hasn't unsubscribed, but doesn't have a valid email address", func() {
@@ -623,6 +840,67 @@ var _ = Describe("DeliveryJobProcessor", func() {
 				Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusFailed))
 				Expect(messageStatusUpdater.UpdateCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
 			})
+
+			It("records the failure without a template-packed event", func() {
+				processor.Process(job, logger)
+
+				events := messageEventRecorder.RecordCall.Receives.Events
+				Expect(events).To(HaveLen(2))
+				Expect(events[0].Event).To(Equal(models.MessageEventReserved))
+				Expect(events[1].Event).To(Equal(models.MessageEventFailed))
+				Expect(events[1].Detail).NotTo(BeEmpty())
+			})
+		})
+
+		Context("when the templates cannot be loaded", func() {
+			BeforeEach(func() {
+				templateLoader.LoadTemplatesCall.Returns.Error = errors.New("database is down")
+				job = gobble.NewJob(delivery)
+			})
+
+			It("fails the job so that it is retried instead of panicking", func() {
+				Expect(func() {
+					processor.Process(job, logger)
+				}).ToNot(Panic())
+
+				Expect(mailClient.SendCall.CallCount).To(Equal(0))
+				Expect(deliveryFailureHandler.HandleCall.Receives.Job).To(Equal(job))
+				Expect(deliveryFailureHandler.HandleCall.Receives.Error).To(MatchError("database is down"))
+			})
+		})
+
+		Context("when the template cannot be executed", func() {
+			BeforeEach(func() {
+				templateLoader.LoadTemplatesCall.Returns.Templates = common.Templates{
+					Text:    "{{.Text}} was sent to {{.Nope}}",
+					HTML:    "<p>{{.HTML}}</p>",
+					Subject: "{{.Subject}}",
+				}
+				job = gobble.NewJob(delivery)
+			})
+
+			It("does not send the email", func() {
+				processor.Process(job, logger)
+
+				Expect(mailClient.SendCall.CallCount).To(Equal(0))
+			})
+
+			It("fails the job with the template error", func() {
+				processor.Process(job, logger)
+
+				Expect(common.IsTemplateError(deliveryFailureHandler.HandleCall.Receives.Error)).To(BeTrue())
+				Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusFailed))
+			})
+
+			It("records the template error on the failed event", func() {
+				processor.Process(job, logger)
+
+				events := messageEventRecorder.RecordCall.Receives.Events
+				Expect(events).To(HaveLen(2))
+				Expect(events[1].Event).To(Equal(models.MessageEventFailed))
+				Expect(events[1].Detail).To(ContainSubstring("template text could not be rendered"))
+				Expect(events[1].Detail).To(ContainSubstring("can't evaluate field Nope"))
+			})
 		})
 
 		Context("when the job contains malformed JSON", func() {
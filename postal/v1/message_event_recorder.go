@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type MessageEventRecorder struct {
+	eventsRepo MessageEventCreator
+}
+
+type MessageEventCreator interface {
+	Create(conn models.ConnectionInterface, event models.MessageEvent) (models.MessageEvent, error)
+}
+
+func NewMessageEventRecorder(eventsRepo MessageEventCreator) MessageEventRecorder {
+	return MessageEventRecorder{
+		eventsRepo: eventsRepo,
+	}
+}
+
+func (r MessageEventRecorder) Record(conn db.ConnectionInterface, messageID, event, detail string, logger lager.Logger) {
+	_, err := r.eventsRepo.Create(conn, models.MessageEvent{
+		MessageID: messageID,
+		Event:     event,
+		Detail:    detail,
+	})
+	if err != nil {
+		logger.Session("message-event-recorder").Error("failed-message-event-insert", err, lager.Data{
+			"event": event,
+		})
+	}
+}
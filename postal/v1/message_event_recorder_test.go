@@ -0,0 +1,72 @@
+package v1_test
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/postal/v1"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/pivotal-golang/lager"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MessageEventRecorder", func() {
+	var (
+		recorder   v1.MessageEventRecorder
+		eventsRepo *mocks.MessageEventsRepo
+		logger     lager.Logger
+		buffer     *bytes.Buffer
+		conn       *mocks.Connection
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		eventsRepo = mocks.NewMessageEventsRepo()
+
+		buffer = bytes.NewBuffer([]byte{})
+		logger = lager.NewLogger("notifications")
+		logger.RegisterSink(lager.NewWriterSink(buffer, lager.INFO))
+
+		recorder = v1.NewMessageEventRecorder(eventsRepo)
+	})
+
+	It("appends the event to the message's timeline", func() {
+		recorder.Record(conn, "some-message-id", models.MessageEventFailed, "550 mailbox unavailable", logger)
+
+		Expect(eventsRepo.CreateCall.Receives.Connection).To(Equal(conn))
+		Expect(eventsRepo.CreateCall.Receives.Events).To(Equal([]models.MessageEvent{
+			{
+				MessageID: "some-message-id",
+				Event:     models.MessageEventFailed,
+				Detail:    "550 mailbox unavailable",
+			},
+		}))
+	})
+
+	Context("when the repository fails to insert the event", func() {
+		It("logs the error", func() {
+			eventsRepo.CreateCall.Returns.Error = errors.New("failed to insert")
+
+			recorder.Record(conn, "some-message-id", models.MessageEventQueued, "", logger)
+
+			lines, err := parseLogLines(buffer.Bytes())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(lines).To(Equal([]logLine{
+				{
+					Source:   "notifications",
+					Message:  "notifications.message-event-recorder.failed-message-event-insert",
+					LogLevel: int(lager.ERROR),
+					Data: map[string]interface{}{
+						"session": "1",
+						"error":   "failed to insert",
+						"event":   models.MessageEventQueued,
+					},
+				},
+			}))
+		})
+	})
+})
@@ -35,7 +35,7 @@ func NewTemplatesLoader(database db.DatabaseInterface, clientsRepo clientFinder,
 	}
 }
 
-func (loader TemplatesLoader) LoadTemplates(clientID, kindID, templateID string) (common.Templates, error) {
+func (loader TemplatesLoader) LoadTemplates(clientID, kindID, templateID, locale string) (common.Templates, error) {
 	conn := loader.database.Connection()
 
 	if kindID != "" {
@@ -45,7 +45,7 @@ func (loader TemplatesLoader) LoadTemplates(clientID, kindID, templateID string)
 		}
 
 		if kind.TemplateID != models.DefaultTemplateID {
-			return loader.loadTemplate(conn, kind.TemplateID)
+			return loader.loadTemplate(conn, kind.TemplateID, locale)
 		}
 	}
 
@@ -54,18 +54,26 @@ func (loader TemplatesLoader) LoadTemplates(clientID, kindID, templateID string)
 		return common.Templates{}, err
 	}
 
-	return loader.loadTemplate(conn, client.TemplateID)
+	return loader.loadTemplate(conn, client.TemplateID, locale)
 }
 
-func (loader TemplatesLoader) loadTemplate(conn db.ConnectionInterface, templateID string) (common.Templates, error) {
+func (loader TemplatesLoader) loadTemplate(conn db.ConnectionInterface, templateID, locale string) (common.Templates, error) {
 	template, err := loader.templatesRepo.FindByID(conn, templateID)
 	if err != nil {
 		return common.Templates{}, err
 	}
 
+	template, err = template.Localize(locale)
+	if err != nil {
+		return common.Templates{}, err
+	}
+
 	return common.Templates{
+		ID:      template.ID,
+		Version: template.Version,
 		Subject: template.Subject,
 		Text:    template.Text,
 		HTML:    template.HTML,
+		Strict:  template.Strict,
 	}, nil
 }
@@ -63,10 +63,12 @@ var _ = Describe("TemplateLoader", func() {
 			BeforeEach(func() {
 				templatesRepo.FindByIDCall.Returns.Template = models.Template{
 					ID:      "my-kind-template",
+					Version: 4,
 					Name:    "my-kind-template",
 					HTML:    "<p>kind template</p>",
 					Text:    "some kind template text",
 					Subject: "kind subject",
+					Strict:  true,
 				}
 
 				kindsRepo.FindCall.Returns.Kinds = []models.Kind{
@@ -79,12 +81,15 @@ var _ = Describe("TemplateLoader", func() {
 			})
 
 			It("returns the template belonging to the kind", func() {
-				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "")
+				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(common.Templates{
+					ID:      "my-kind-template",
+					Version: 4,
 					HTML:    "<p>kind template</p>",
 					Text:    "some kind template text",
 					Subject: "kind subject",
+					Strict:  true,
 				}))
 
 				Expect(templatesRepo.FindByIDCall.Receives.Connection).To(Equal(conn))
@@ -109,9 +114,10 @@ var _ = Describe("TemplateLoader", func() {
 			})
 
 			It("returns the template belonging to the client", func() {
-				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "")
+				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(common.Templates{
+					ID:      "my-client-template",
 					HTML:    "<p>client template</p>",
 					Text:    "some client template text",
 					Subject: "client subject",
@@ -124,9 +130,10 @@ var _ = Describe("TemplateLoader", func() {
 
 		Context("when the neither client nor kind has a template", func() {
 			It("returns the default template", func() {
-				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "")
+				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(common.Templates{
+					ID:      models.DefaultTemplateID,
 					HTML:    "<p>The default template</p>",
 					Text:    "The default template",
 					Subject: "default subject",
@@ -136,9 +143,10 @@ var _ = Describe("TemplateLoader", func() {
 
 		Context("when kindID is an empty string", func() {
 			It("does not look for a template belonging to the kind", func() {
-				templates, err := loader.LoadTemplates("my-client-id", "", "")
+				templates, err := loader.LoadTemplates("my-client-id", "", "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(common.Templates{
+					ID:      models.DefaultTemplateID,
 					HTML:    "<p>The default template</p>",
 					Text:    "The default template",
 					Subject: "default subject",
@@ -146,11 +154,41 @@ var _ = Describe("TemplateLoader", func() {
 			})
 		})
 
+		Context("when a locale is given", func() {
+			BeforeEach(func() {
+				templatesRepo.FindByIDCall.Returns.Template = models.Template{
+					ID:            models.DefaultTemplateID,
+					HTML:          "<p>The default template</p>",
+					Text:          "The default template",
+					Subject:       "default subject",
+					Localizations: `{"fr": {"subject": "sujet", "text": "le texte"}}`,
+				}
+			})
+
+			It("returns the localized templates, keeping the default for anything not localized", func() {
+				templates, err := loader.LoadTemplates("my-client-id", "", "", "fr-CA")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(templates).To(Equal(common.Templates{
+					ID:      models.DefaultTemplateID,
+					HTML:    "<p>The default template</p>",
+					Text:    "le texte",
+					Subject: "sujet",
+				}))
+			})
+
+			It("returns the default templates when the locale has no localization", func() {
+				templates, err := loader.LoadTemplates("my-client-id", "", "", "de")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(templates.Subject).To(Equal("default subject"))
+				Expect(templates.Text).To(Equal("The default template"))
+			})
+		})
+
 		Context("when the kinds repo has an error", func() {
 			It("bubbles up the error", func() {
 				kindsRepo.FindCall.Returns.Error = errors.New("BOOM!")
 
-				_, err := loader.LoadTemplates("my-client-id", "my-kind-id", "")
+				_, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "")
 				Expect(err).To(HaveOccurred())
 			})
 
@@ -160,7 +198,7 @@ var _ = Describe("TemplateLoader", func() {
 			It("bubbles up the error", func() {
 				clientsRepo.FindCall.Returns.Error = errors.New("BOOM!")
 
-				_, err := loader.LoadTemplates("my-client-id", "my-kind-id", "")
+				_, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "")
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -3,15 +3,26 @@ package postal
 type WorkerGenerator struct {
 	InstanceIndex int
 	Count         int
+	CriticalCount int
 }
 
 type Worker interface {
 	Work()
+	Halt()
 }
 
-func (w WorkerGenerator) Work(workerFunc func(id int) Worker) {
-	firstID := w.InstanceIndex*w.Count + 1
-	for i := 0; i < w.Count; i++ {
-		workerFunc(firstID + i).Work()
+// Work starts Count workers followed by CriticalCount workers that only
+// reserve jobs from the critical lane.
+func (w WorkerGenerator) Work(workerFunc func(id int, critical bool) Worker) []Worker {
+	var workers []Worker
+
+	total := w.Count + w.CriticalCount
+	firstID := w.InstanceIndex*total + 1
+	for i := 0; i < total; i++ {
+		worker := workerFunc(firstID+i, i >= w.Count)
+		worker.Work()
+		workers = append(workers, worker)
 	}
+
+	return workers
 }
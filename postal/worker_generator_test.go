@@ -13,11 +13,14 @@ func (m *mockWorker) Work() {
 	*m++
 }
 
+func (m *mockWorker) Halt() {}
+
 var _ = Describe("WorkerGenerator", func() {
 	Describe("#Work", func() {
 		var (
 			workerIDs []int
 			worker    mockWorker
+			workers   []postal.Worker
 		)
 
 		BeforeEach(func() {
@@ -28,7 +31,7 @@ var _ = Describe("WorkerGenerator", func() {
 				InstanceIndex: 2,
 			}
 
-			generator.Work(func(id int) postal.Worker {
+			workers = generator.Work(func(id int, critical bool) postal.Worker {
 				workerIDs = append(workerIDs, id)
 				return &worker
 			})
@@ -41,5 +44,37 @@ var _ = Describe("WorkerGenerator", func() {
 		It("should do work on each worker", func() {
 			Expect(worker).To(BeEquivalentTo(5))
 		})
+
+		It("returns the workers it started", func() {
+			Expect(workers).To(HaveLen(5))
+		})
+
+		Context("when critical workers are requested", func() {
+			var lanes map[int]bool
+
+			BeforeEach(func() {
+				workerIDs = make([]int, 0)
+				lanes = map[int]bool{}
+				generator := postal.WorkerGenerator{
+					Count:         3,
+					CriticalCount: 2,
+					InstanceIndex: 1,
+				}
+
+				generator.Work(func(id int, critical bool) postal.Worker {
+					workerIDs = append(workerIDs, id)
+					lanes[id] = critical
+					return &worker
+				})
+			})
+
+			It("keeps the worker IDs unique across instances", func() {
+				Expect(workerIDs).To(Equal([]int{6, 7, 8, 9, 10}))
+			})
+
+			It("starts the critical workers after the regular ones", func() {
+				Expect(lanes).To(Equal(map[int]bool{6: false, 7: false, 8: false, 9: true, 10: true}))
+			})
+		})
 	})
 })
@@ -0,0 +1,63 @@
+package postal
+
+import (
+	"context"
+	"sync"
+)
+
+type closer interface {
+	Close()
+}
+
+type Workers struct {
+	workers   []Worker
+	queues    []closer
+	resources []closer
+}
+
+func NewWorkers(workers []Worker, queues ...closer) Workers {
+	return Workers{
+		workers: workers,
+		queues:  queues,
+	}
+}
+
+// CloseAfterHalt returns Workers that also close the given resources, such
+// as a shared SMTP pool, once every worker has halted.
+func (w Workers) CloseAfterHalt(resources ...closer) Workers {
+	w.resources = append(append([]closer{}, w.resources...), resources...)
+	return w
+}
+
+// Shutdown stops the queues from reserving more jobs and waits for the
+// workers to finish the jobs they are delivering, giving up when the context
+// is done.
+func (w Workers) Shutdown(ctx context.Context) error {
+	for _, queue := range w.queues {
+		queue.Close()
+	}
+
+	halted := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for _, worker := range w.workers {
+			wg.Add(1)
+			go func(worker Worker) {
+				defer wg.Done()
+				worker.Halt()
+			}(worker)
+		}
+		wg.Wait()
+		close(halted)
+	}()
+
+	select {
+	case <-halted:
+		for _, resource := range w.resources {
+			resource.Close()
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
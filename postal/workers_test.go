@@ -0,0 +1,97 @@
+package postal_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/postal"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type haltingWorker struct {
+	halted chan struct{}
+	finish chan struct{}
+}
+
+func newHaltingWorker() *haltingWorker {
+	return &haltingWorker{
+		halted: make(chan struct{}),
+		finish: make(chan struct{}),
+	}
+}
+
+func (w *haltingWorker) Work() {}
+
+func (w *haltingWorker) Halt() {
+	<-w.finish
+	close(w.halted)
+}
+
+type closingQueue struct {
+	closed bool
+}
+
+func (q *closingQueue) Close() {
+	q.closed = true
+}
+
+var _ = Describe("Workers", func() {
+	Describe("Shutdown", func() {
+		var (
+			first   *haltingWorker
+			second  *haltingWorker
+			queue   *closingQueue
+			workers postal.Workers
+		)
+
+		BeforeEach(func() {
+			first = newHaltingWorker()
+			second = newHaltingWorker()
+			queue = &closingQueue{}
+
+			workers = postal.NewWorkers([]postal.Worker{first, second}, queue)
+		})
+
+		It("closes the queues and waits for every worker to halt", func() {
+			close(first.finish)
+			close(second.finish)
+
+			Expect(workers.Shutdown(context.Background())).To(Succeed())
+
+			Expect(queue.closed).To(BeTrue())
+			Expect(first.halted).To(BeClosed())
+			Expect(second.halted).To(BeClosed())
+		})
+
+		It("closes the shared resources once the workers have halted", func() {
+			pool := &closingQueue{}
+			workers = workers.CloseAfterHalt(pool)
+
+			Expect(pool.closed).To(BeFalse())
+
+			close(first.finish)
+			close(second.finish)
+
+			Expect(workers.Shutdown(context.Background())).To(Succeed())
+			Expect(pool.closed).To(BeTrue())
+		})
+
+		It("gives up when the deadline passes before the workers halt", func() {
+			close(first.finish)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			pool := &closingQueue{}
+			workers = workers.CloseAfterHalt(pool)
+
+			Expect(workers.Shutdown(ctx)).To(MatchError(context.DeadlineExceeded))
+			Expect(queue.closed).To(BeTrue())
+			Expect(pool.closed).To(BeFalse())
+
+			close(second.finish)
+		})
+	})
+})
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type BounceRecorder struct {
+	RecordCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection services.ConnectionInterface
+			DSN        mail.DSN
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewBounceRecorder() *BounceRecorder {
+	return &BounceRecorder{}
+}
+
+func (r *BounceRecorder) Record(conn services.ConnectionInterface, dsn mail.DSN) error {
+	r.RecordCall.WasCalled = true
+	r.RecordCall.Receives.Connection = conn
+	r.RecordCall.Receives.DSN = dsn
+
+	return r.RecordCall.Returns.Error
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/pivotal-golang/lager"
+)
+
+type CampaignJobProcessor struct {
+	ProcessCall struct {
+		CallCount int
+		Receives  struct {
+			Job    *gobble.Job
+			Logger lager.Logger
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewCampaignJobProcessor() *CampaignJobProcessor {
+	return &CampaignJobProcessor{}
+}
+
+func (p *CampaignJobProcessor) Process(job *gobble.Job, logger lager.Logger) error {
+	p.ProcessCall.Receives.Job = job
+	p.ProcessCall.Receives.Logger = logger
+	p.ProcessCall.CallCount++
+
+	return p.ProcessCall.Returns.Error
+}
@@ -0,0 +1,84 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/gobble"
+
+type DeadLetters struct {
+	ListCall struct {
+		WasCalled bool
+		Returns   struct {
+			DeadLetters []gobble.DeadLetter
+			Error       error
+		}
+	}
+
+	FindCall struct {
+		Receives struct {
+			ID int
+		}
+		Returns struct {
+			DeadLetter gobble.DeadLetter
+			Error      error
+		}
+	}
+
+	ReplayCall struct {
+		Receives struct {
+			ID int
+		}
+		Returns struct {
+			Job   *gobble.Job
+			Error error
+		}
+	}
+
+	PurgeCall struct {
+		Receives struct {
+			ID int
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	PurgeAllCall struct {
+		WasCalled bool
+		Returns   struct {
+			Count int
+			Error error
+		}
+	}
+}
+
+func NewDeadLetters() *DeadLetters {
+	return &DeadLetters{}
+}
+
+func (d *DeadLetters) List() ([]gobble.DeadLetter, error) {
+	d.ListCall.WasCalled = true
+
+	return d.ListCall.Returns.DeadLetters, d.ListCall.Returns.Error
+}
+
+func (d *DeadLetters) Find(id int) (gobble.DeadLetter, error) {
+	d.FindCall.Receives.ID = id
+
+	return d.FindCall.Returns.DeadLetter, d.FindCall.Returns.Error
+}
+
+func (d *DeadLetters) Replay(id int) (*gobble.Job, error) {
+	d.ReplayCall.Receives.ID = id
+
+	return d.ReplayCall.Returns.Job, d.ReplayCall.Returns.Error
+}
+
+func (d *DeadLetters) Purge(id int) error {
+	d.PurgeCall.Receives.ID = id
+
+	return d.PurgeCall.Returns.Error
+}
+
+func (d *DeadLetters) PurgeAll() (int, error) {
+	d.PurgeAllCall.WasCalled = true
+
+	return d.PurgeAllCall.Returns.Count, d.PurgeAllCall.Returns.Error
+}
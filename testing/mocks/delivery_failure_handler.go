@@ -8,8 +8,10 @@ import (
 type DeliveryFailureHandler struct {
 	HandleCall struct {
 		WasCalled bool
+		Hook      func()
 		Receives  struct {
 			Job    common.Retryable
+			Error  error
 			Logger lager.Logger
 		}
 	}
@@ -19,8 +21,13 @@ func NewDeliveryFailureHandler() *DeliveryFailureHandler {
 	return &DeliveryFailureHandler{}
 }
 
-func (h *DeliveryFailureHandler) Handle(job common.Retryable, logger lager.Logger) {
+func (h *DeliveryFailureHandler) Handle(job common.Retryable, reason error, logger lager.Logger) {
 	h.HandleCall.WasCalled = true
 	h.HandleCall.Receives.Job = job
+	h.HandleCall.Receives.Error = reason
 	h.HandleCall.Receives.Logger = logger
+
+	if h.HandleCall.Hook != nil {
+		h.HandleCall.Hook()
+	}
 }
@@ -27,6 +27,26 @@ type Enqueuer struct {
 			Err       error
 		}
 	}
+
+	EnqueueCampaignCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection      services.ConnectionInterface
+			Users           []services.User
+			Options         services.Options
+			Space           cf.CloudControllerSpace
+			Org             cf.CloudControllerOrganization
+			Client          string
+			Scope           string
+			VCAPRequestID   string
+			RequestReceived time.Time
+			UAAHost         string
+		}
+		Returns struct {
+			Responses []services.Response
+			Err       error
+		}
+	}
 }
 
 func NewEnqueuer() *Enqueuer {
@@ -59,3 +79,30 @@ func (m *Enqueuer) Enqueue(
 	m.EnqueueCall.WasCalled = true
 	return m.EnqueueCall.Returns.Responses, m.EnqueueCall.Returns.Err
 }
+
+func (m *Enqueuer) EnqueueCampaign(
+	conn services.ConnectionInterface,
+	users []services.User,
+	options services.Options,
+	space cf.CloudControllerSpace,
+	org cf.CloudControllerOrganization,
+	client string,
+	uaaHost string,
+	scope string,
+	vcapRequestID string,
+	reqReceived time.Time) ([]services.Response, error) {
+
+	m.EnqueueCampaignCall.Receives.Connection = conn
+	m.EnqueueCampaignCall.Receives.Users = users
+	m.EnqueueCampaignCall.Receives.Options = options
+	m.EnqueueCampaignCall.Receives.Space = space
+	m.EnqueueCampaignCall.Receives.Org = org
+	m.EnqueueCampaignCall.Receives.Client = client
+	m.EnqueueCampaignCall.Receives.UAAHost = uaaHost
+	m.EnqueueCampaignCall.Receives.Scope = scope
+	m.EnqueueCampaignCall.Receives.VCAPRequestID = vcapRequestID
+	m.EnqueueCampaignCall.Receives.RequestReceived = reqReceived
+
+	m.EnqueueCampaignCall.WasCalled = true
+	return m.EnqueueCampaignCall.Returns.Responses, m.EnqueueCampaignCall.Returns.Err
+}
@@ -10,6 +10,17 @@ type GobbleJob struct {
 		}
 	}
 
+	FailCall struct {
+		WasCalled bool
+		Receives  struct {
+			Reason error
+		}
+	}
+
+	GiveUpCall struct {
+		WasCalled bool
+	}
+
 	StateCall struct {
 		Returns struct {
 			Count int
@@ -27,6 +38,15 @@ func (j *GobbleJob) Retry(duration time.Duration) {
 	j.RetryCall.Receives.Duration = duration
 }
 
+func (j *GobbleJob) Fail(reason error) {
+	j.FailCall.WasCalled = true
+	j.FailCall.Receives.Reason = reason
+}
+
+func (j *GobbleJob) GiveUp() {
+	j.GiveUpCall.WasCalled = true
+}
+
 func (j *GobbleJob) State() (int, time.Time) {
 	return j.StateCall.Returns.Count, j.StateCall.Returns.Time
 }
@@ -0,0 +1,132 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type IdempotencyKeysRepo struct {
+	CreateCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Key        models.IdempotencyKey
+		}
+		Returns struct {
+			Key   models.IdempotencyKey
+			Error error
+		}
+	}
+
+	FindCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+			Key        string
+		}
+		Returns struct {
+			Key   models.IdempotencyKey
+			Error error
+		}
+	}
+
+	UpdateCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection models.ConnectionInterface
+			Key        models.IdempotencyKey
+		}
+		Returns struct {
+			Key   models.IdempotencyKey
+			Error error
+		}
+	}
+
+	TakeOverCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection models.ConnectionInterface
+			Key        models.IdempotencyKey
+			ReservedAt time.Time
+		}
+		Returns struct {
+			TakenOver bool
+			Error     error
+		}
+	}
+
+	DeleteCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+			Key        string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	DeleteBeforeCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+			Before     time.Time
+		}
+		Returns struct {
+			RowsAffected int
+			Error        error
+		}
+	}
+}
+
+func NewIdempotencyKeysRepo() *IdempotencyKeysRepo {
+	return &IdempotencyKeysRepo{}
+}
+
+func (r *IdempotencyKeysRepo) Create(conn models.ConnectionInterface, key models.IdempotencyKey) (models.IdempotencyKey, error) {
+	r.CreateCall.Receives.Connection = conn
+	r.CreateCall.Receives.Key = key
+
+	return r.CreateCall.Returns.Key, r.CreateCall.Returns.Error
+}
+
+func (r *IdempotencyKeysRepo) Find(conn models.ConnectionInterface, clientID, key string) (models.IdempotencyKey, error) {
+	r.FindCall.Receives.Connection = conn
+	r.FindCall.Receives.ClientID = clientID
+	r.FindCall.Receives.Key = key
+
+	return r.FindCall.Returns.Key, r.FindCall.Returns.Error
+}
+
+func (r *IdempotencyKeysRepo) Update(conn models.ConnectionInterface, key models.IdempotencyKey) (models.IdempotencyKey, error) {
+	r.UpdateCall.WasCalled = true
+	r.UpdateCall.Receives.Connection = conn
+	r.UpdateCall.Receives.Key = key
+
+	return r.UpdateCall.Returns.Key, r.UpdateCall.Returns.Error
+}
+
+func (r *IdempotencyKeysRepo) TakeOver(conn models.ConnectionInterface, key models.IdempotencyKey, reservedAt time.Time) (bool, error) {
+	r.TakeOverCall.WasCalled = true
+	r.TakeOverCall.Receives.Connection = conn
+	r.TakeOverCall.Receives.Key = key
+	r.TakeOverCall.Receives.ReservedAt = reservedAt
+
+	return r.TakeOverCall.Returns.TakenOver, r.TakeOverCall.Returns.Error
+}
+
+func (r *IdempotencyKeysRepo) Delete(conn models.ConnectionInterface, clientID, key string) error {
+	r.DeleteCall.Receives.Connection = conn
+	r.DeleteCall.Receives.ClientID = clientID
+	r.DeleteCall.Receives.Key = key
+
+	return r.DeleteCall.Returns.Error
+}
+
+func (r *IdempotencyKeysRepo) DeleteBefore(conn models.ConnectionInterface, clientID string, before time.Time) (int, error) {
+	r.DeleteBeforeCall.Receives.Connection = conn
+	r.DeleteBeforeCall.Receives.ClientID = clientID
+	r.DeleteBeforeCall.Receives.Before = before
+
+	return r.DeleteBeforeCall.Returns.RowsAffected, r.DeleteBeforeCall.Returns.Error
+}
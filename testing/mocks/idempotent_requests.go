@@ -0,0 +1,78 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/services"
+
+type IdempotentRequests struct {
+	BeginCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection  services.ConnectionInterface
+			ClientID    string
+			Key         string
+			RequestHash string
+		}
+		Returns struct {
+			Response []byte
+			Replayed bool
+			Error    error
+		}
+	}
+
+	FinishCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection services.ConnectionInterface
+			ClientID   string
+			Key        string
+			Response   []byte
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	AbandonCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection services.ConnectionInterface
+			ClientID   string
+			Key        string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewIdempotentRequests() *IdempotentRequests {
+	return &IdempotentRequests{}
+}
+
+func (r *IdempotentRequests) Begin(conn services.ConnectionInterface, clientID, key, requestHash string) ([]byte, bool, error) {
+	r.BeginCall.WasCalled = true
+	r.BeginCall.Receives.Connection = conn
+	r.BeginCall.Receives.ClientID = clientID
+	r.BeginCall.Receives.Key = key
+	r.BeginCall.Receives.RequestHash = requestHash
+
+	return r.BeginCall.Returns.Response, r.BeginCall.Returns.Replayed, r.BeginCall.Returns.Error
+}
+
+func (r *IdempotentRequests) Finish(conn services.ConnectionInterface, clientID, key string, response []byte) error {
+	r.FinishCall.WasCalled = true
+	r.FinishCall.Receives.Connection = conn
+	r.FinishCall.Receives.ClientID = clientID
+	r.FinishCall.Receives.Key = key
+	r.FinishCall.Receives.Response = response
+
+	return r.FinishCall.Returns.Error
+}
+
+func (r *IdempotentRequests) Abandon(conn services.ConnectionInterface, clientID, key string) error {
+	r.AbandonCall.WasCalled = true
+	r.AbandonCall.Receives.Connection = conn
+	r.AbandonCall.Receives.ClientID = clientID
+	r.AbandonCall.Receives.Key = key
+
+	return r.AbandonCall.Returns.Error
+}
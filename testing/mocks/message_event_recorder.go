@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/pivotal-golang/lager"
+)
+
+type MessageEventRecorder struct {
+	RecordCall struct {
+		Receives struct {
+			Connection db.ConnectionInterface
+			Events     []models.MessageEvent
+			Logger     lager.Logger
+		}
+	}
+}
+
+func NewMessageEventRecorder() *MessageEventRecorder {
+	return &MessageEventRecorder{}
+}
+
+func (r *MessageEventRecorder) Record(conn db.ConnectionInterface, messageID, event, detail string, logger lager.Logger) {
+	r.RecordCall.Receives.Connection = conn
+	r.RecordCall.Receives.Events = append(r.RecordCall.Receives.Events, models.MessageEvent{
+		MessageID: messageID,
+		Event:     event,
+		Detail:    detail,
+	})
+	r.RecordCall.Receives.Logger = logger
+}
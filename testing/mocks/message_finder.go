@@ -1,6 +1,9 @@
 package mocks
 
-import "github.com/cloudfoundry-incubator/notifications/v1/services"
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
 
 type MessageFinder struct {
 	FindCall struct {
@@ -13,6 +16,29 @@ type MessageFinder struct {
 			Error   error
 		}
 	}
+
+	ListCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			Filter   models.MessagesFilter
+		}
+		Returns struct {
+			Messages []services.Message
+			Total    int
+			Error    error
+		}
+	}
+
+	TimelineCall struct {
+		Receives struct {
+			Database  services.DatabaseInterface
+			MessageID string
+		}
+		Returns struct {
+			Message services.Message
+			Error   error
+		}
+	}
 }
 
 func NewMessageFinder() *MessageFinder {
@@ -25,3 +51,17 @@ func (f *MessageFinder) Find(database services.DatabaseInterface, messageID stri
 
 	return f.FindCall.Returns.Message, f.FindCall.Returns.Error
 }
+
+func (f *MessageFinder) Timeline(database services.DatabaseInterface, messageID string) (services.Message, error) {
+	f.TimelineCall.Receives.Database = database
+	f.TimelineCall.Receives.MessageID = messageID
+
+	return f.TimelineCall.Returns.Message, f.TimelineCall.Returns.Error
+}
+
+func (f *MessageFinder) List(database services.DatabaseInterface, filter models.MessagesFilter) ([]services.Message, int, error) {
+	f.ListCall.Receives.Database = database
+	f.ListCall.Receives.Filter = filter
+
+	return f.ListCall.Returns.Messages, f.ListCall.Returns.Total, f.ListCall.Returns.Error
+}
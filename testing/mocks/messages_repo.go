@@ -41,15 +41,40 @@ type MessagesRepo struct {
 		}
 	}
 
-	DeleteBeforeCall struct {
+	ListCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Filter     models.MessagesFilter
+		}
+		Returns struct {
+			Messages []models.Message
+			Total    int
+			Error    error
+		}
+	}
+
+	TransitionStatusCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			MessageID  string
+			FromStatus string
+			ToStatus   string
+		}
+		Returns struct {
+			Transitioned bool
+			Error        error
+		}
+	}
+
+	DeleteBatchCall struct {
 		InvocationTimes []time.Time
 		CallCount       int
 		Receives        struct {
-			Connection    models.ConnectionInterface
-			ThresholdTime time.Time
+			Connection models.ConnectionInterface
+			Deletions  []models.MessagesDeletion
 		}
 		Returns struct {
-			RowsAffected int
+			RowsAffected []int
 			Error        error
 		}
 	}
@@ -83,11 +108,32 @@ func (mr *MessagesRepo) FindByID(conn models.ConnectionInterface, messageID stri
 	return mr.FindByIDCall.Returns.Message, mr.FindByIDCall.Returns.Error
 }
 
-func (mr *MessagesRepo) DeleteBefore(conn models.ConnectionInterface, thresholdTime time.Time) (int, error) {
-	mr.DeleteBeforeCall.Receives.Connection = conn
-	mr.DeleteBeforeCall.Receives.ThresholdTime = thresholdTime
-	mr.DeleteBeforeCall.InvocationTimes = append(mr.DeleteBeforeCall.InvocationTimes, time.Now())
-	mr.DeleteBeforeCall.CallCount++
+func (mr *MessagesRepo) List(conn models.ConnectionInterface, filter models.MessagesFilter) ([]models.Message, int, error) {
+	mr.ListCall.Receives.Connection = conn
+	mr.ListCall.Receives.Filter = filter
+
+	return mr.ListCall.Returns.Messages, mr.ListCall.Returns.Total, mr.ListCall.Returns.Error
+}
+
+func (mr *MessagesRepo) TransitionStatus(conn models.ConnectionInterface, messageID, fromStatus, toStatus string) (bool, error) {
+	mr.TransitionStatusCall.Receives.Connection = conn
+	mr.TransitionStatusCall.Receives.MessageID = messageID
+	mr.TransitionStatusCall.Receives.FromStatus = fromStatus
+	mr.TransitionStatusCall.Receives.ToStatus = toStatus
+
+	return mr.TransitionStatusCall.Returns.Transitioned, mr.TransitionStatusCall.Returns.Error
+}
+
+func (mr *MessagesRepo) DeleteBatch(conn models.ConnectionInterface, deletion models.MessagesDeletion) (int, error) {
+	mr.DeleteBatchCall.Receives.Connection = conn
+	mr.DeleteBatchCall.Receives.Deletions = append(mr.DeleteBatchCall.Receives.Deletions, deletion)
+	mr.DeleteBatchCall.InvocationTimes = append(mr.DeleteBatchCall.InvocationTimes, time.Now())
+
+	var rowsAffected int
+	if mr.DeleteBatchCall.CallCount < len(mr.DeleteBatchCall.Returns.RowsAffected) {
+		rowsAffected = mr.DeleteBatchCall.Returns.RowsAffected[mr.DeleteBatchCall.CallCount]
+	}
+	mr.DeleteBatchCall.CallCount++
 
-	return mr.DeleteBeforeCall.Returns.RowsAffected, mr.DeleteBeforeCall.Returns.Error
+	return rowsAffected, mr.DeleteBatchCall.Returns.Error
 }
@@ -1,6 +1,9 @@
 package mocks
 
-import "github.com/cloudfoundry-incubator/notifications/gobble"
+import (
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/pivotal-golang/lager"
+)
 
 type Queue struct {
 	EnqueueCall struct {
@@ -16,16 +19,45 @@ type Queue struct {
 		Hook func()
 	}
 
+	EnqueueAllCall struct {
+		Receives struct {
+			Jobs       []*gobble.Job
+			Connection gobble.ConnectionInterface
+		}
+		Returns struct {
+			Jobs  []*gobble.Job
+			Error error
+		}
+	}
+
 	RequeueCall struct {
 		Receives struct {
 			Job *gobble.Job
 		}
+		Returns struct {
+			Error error
+		}
 	}
 
-	DequeueCall struct {
+	DeadLetterCall struct {
 		Receives struct {
 			Job *gobble.Job
 		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	DequeueCall struct {
+		CallCount int
+		Receives  struct {
+			Job *gobble.Job
+		}
+		Returns struct {
+			Error error
+		}
+
+		Hook func()
 	}
 
 	LenCall struct {
@@ -44,6 +76,12 @@ type Queue struct {
 		}
 	}
 
+	LoggerCall struct {
+		Returns struct {
+			Logger lager.Logger
+		}
+	}
+
 	RetryQueueLengthsCall struct {
 		Returns struct {
 			Lengths map[int]int
@@ -53,7 +91,10 @@ type Queue struct {
 }
 
 func NewQueue() *Queue {
-	return &Queue{}
+	queue := &Queue{}
+	queue.LoggerCall.Returns.Logger = lager.NewLogger("queue")
+
+	return queue
 }
 
 func (q *Queue) Enqueue(job *gobble.Job, connection gobble.ConnectionInterface) (*gobble.Job, error) {
@@ -67,12 +108,34 @@ func (q *Queue) Enqueue(job *gobble.Job, connection gobble.ConnectionInterface)
 	return q.EnqueueCall.Returns.Job, q.EnqueueCall.Returns.Error
 }
 
-func (q *Queue) Dequeue(job *gobble.Job) {
+func (q *Queue) EnqueueAll(jobs []*gobble.Job, connection gobble.ConnectionInterface) ([]*gobble.Job, error) {
+	q.EnqueueAllCall.Receives.Jobs = jobs
+	q.EnqueueAllCall.Receives.Connection = connection
+
+	return q.EnqueueAllCall.Returns.Jobs, q.EnqueueAllCall.Returns.Error
+}
+
+func (q *Queue) Dequeue(job *gobble.Job) error {
+	q.DequeueCall.CallCount++
 	q.DequeueCall.Receives.Job = job
+
+	if q.DequeueCall.Hook != nil {
+		q.DequeueCall.Hook()
+	}
+
+	return q.DequeueCall.Returns.Error
+}
+
+func (q *Queue) DeadLetter(job *gobble.Job) error {
+	q.DeadLetterCall.Receives.Job = job
+
+	return q.DeadLetterCall.Returns.Error
 }
 
-func (q *Queue) Requeue(job *gobble.Job) {
+func (q *Queue) Requeue(job *gobble.Job) error {
 	q.RequeueCall.Receives.Job = job
+
+	return q.RequeueCall.Returns.Error
 }
 
 func (q *Queue) Len() (int, error) {
@@ -85,6 +148,10 @@ func (q *Queue) Reserve(id string) <-chan *gobble.Job {
 	return q.ReserveCall.Returns.Chan
 }
 
+func (q *Queue) Logger() lager.Logger {
+	return q.LoggerCall.Returns.Logger
+}
+
 func (q *Queue) RetryQueueLengths() (map[int]int, error) {
 	return q.RetryQueueLengthsCall.Returns.Lengths, q.RetryQueueLengthsCall.Returns.Error
 }
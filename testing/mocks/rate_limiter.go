@@ -0,0 +1,70 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type RateLimiter struct {
+	LimitsCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+		}
+		Returns struct {
+			Limits services.RateLimits
+			Error  error
+		}
+	}
+
+	AllowRequestCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	AllowRecipientsCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+			Count      int
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{}
+}
+
+func (l *RateLimiter) Limits(conn models.ConnectionInterface, clientID string) (services.RateLimits, error) {
+	l.LimitsCall.Receives.Connection = conn
+	l.LimitsCall.Receives.ClientID = clientID
+
+	return l.LimitsCall.Returns.Limits, l.LimitsCall.Returns.Error
+}
+
+func (l *RateLimiter) AllowRequest(conn models.ConnectionInterface, clientID string) error {
+	l.AllowRequestCall.WasCalled = true
+	l.AllowRequestCall.Receives.Connection = conn
+	l.AllowRequestCall.Receives.ClientID = clientID
+
+	return l.AllowRequestCall.Returns.Error
+}
+
+func (l *RateLimiter) AllowRecipients(conn models.ConnectionInterface, clientID string, count int) error {
+	l.AllowRecipientsCall.WasCalled = true
+	l.AllowRecipientsCall.Receives.Connection = conn
+	l.AllowRecipientsCall.Receives.ClientID = clientID
+	l.AllowRecipientsCall.Receives.Count = count
+
+	return l.AllowRecipientsCall.Returns.Error
+}
@@ -0,0 +1,64 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type RateLimitsRepo struct {
+	FindCall struct {
+		CallCount int
+		Receives  struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+		}
+		Returns struct {
+			RateLimit models.RateLimit
+			Error     error
+		}
+	}
+
+	UpsertCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			RateLimit  models.RateLimit
+		}
+		Returns struct {
+			RateLimit models.RateLimit
+			Error     error
+		}
+	}
+
+	DeleteCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewRateLimitsRepo() *RateLimitsRepo {
+	return &RateLimitsRepo{}
+}
+
+func (r *RateLimitsRepo) Find(conn models.ConnectionInterface, clientID string) (models.RateLimit, error) {
+	r.FindCall.CallCount++
+	r.FindCall.Receives.Connection = conn
+	r.FindCall.Receives.ClientID = clientID
+
+	return r.FindCall.Returns.RateLimit, r.FindCall.Returns.Error
+}
+
+func (r *RateLimitsRepo) Upsert(conn models.ConnectionInterface, rateLimit models.RateLimit) (models.RateLimit, error) {
+	r.UpsertCall.Receives.Connection = conn
+	r.UpsertCall.Receives.RateLimit = rateLimit
+
+	return r.UpsertCall.Returns.RateLimit, r.UpsertCall.Returns.Error
+}
+
+func (r *RateLimitsRepo) Delete(conn models.ConnectionInterface, clientID string) error {
+	r.DeleteCall.Receives.Connection = conn
+	r.DeleteCall.Receives.ClientID = clientID
+
+	return r.DeleteCall.Returns.Error
+}
@@ -0,0 +1,99 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type SuppressionsRepo struct {
+	SuppressCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection models.ConnectionInterface
+			Email      string
+			Reason     string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	IsSuppressedCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Email      string
+		}
+		Returns struct {
+			Suppressed bool
+			Error      error
+		}
+	}
+
+	FindCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Email      string
+		}
+		Returns struct {
+			Suppression models.Suppression
+			Error       error
+		}
+	}
+
+	FindAllCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+		}
+		Returns struct {
+			Suppressions []models.Suppression
+			Error        error
+		}
+	}
+
+	DeleteCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Email      string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewSuppressionsRepo() *SuppressionsRepo {
+	return &SuppressionsRepo{}
+}
+
+func (r *SuppressionsRepo) Suppress(conn models.ConnectionInterface, email, reason string) error {
+	r.SuppressCall.WasCalled = true
+	r.SuppressCall.Receives.Connection = conn
+	r.SuppressCall.Receives.Email = email
+	r.SuppressCall.Receives.Reason = reason
+
+	return r.SuppressCall.Returns.Error
+}
+
+func (r *SuppressionsRepo) IsSuppressed(conn models.ConnectionInterface, email string) (bool, error) {
+	r.IsSuppressedCall.Receives.Connection = conn
+	r.IsSuppressedCall.Receives.Email = email
+
+	return r.IsSuppressedCall.Returns.Suppressed, r.IsSuppressedCall.Returns.Error
+}
+
+func (r *SuppressionsRepo) Find(conn models.ConnectionInterface, email string) (models.Suppression, error) {
+	r.FindCall.Receives.Connection = conn
+	r.FindCall.Receives.Email = email
+
+	return r.FindCall.Returns.Suppression, r.FindCall.Returns.Error
+}
+
+func (r *SuppressionsRepo) FindAll(conn models.ConnectionInterface) ([]models.Suppression, error) {
+	r.FindAllCall.Receives.Connection = conn
+
+	return r.FindAllCall.Returns.Suppressions, r.FindAllCall.Returns.Error
+}
+
+func (r *SuppressionsRepo) Delete(conn models.ConnectionInterface, email string) error {
+	r.DeleteCall.Receives.Connection = conn
+	r.DeleteCall.Receives.Email = email
+
+	return r.DeleteCall.Returns.Error
+}
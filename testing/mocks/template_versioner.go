@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type TemplateVersioner struct {
+	ListVersionsCall struct {
+		Receives struct {
+			Database   services.DatabaseInterface
+			TemplateID string
+		}
+		Returns struct {
+			Versions []models.TemplateVersion
+			Error    error
+		}
+	}
+
+	RollbackCall struct {
+		Receives struct {
+			Database   services.DatabaseInterface
+			TemplateID string
+			Version    int
+		}
+		Returns struct {
+			Template models.Template
+			Error    error
+		}
+	}
+}
+
+func NewTemplateVersioner() *TemplateVersioner {
+	return &TemplateVersioner{}
+}
+
+func (v *TemplateVersioner) ListVersions(database services.DatabaseInterface, templateID string) ([]models.TemplateVersion, error) {
+	v.ListVersionsCall.Receives.Database = database
+	v.ListVersionsCall.Receives.TemplateID = templateID
+
+	return v.ListVersionsCall.Returns.Versions, v.ListVersionsCall.Returns.Error
+}
+
+func (v *TemplateVersioner) Rollback(database services.DatabaseInterface, templateID string, version int) (models.Template, error) {
+	v.RollbackCall.Receives.Database = database
+	v.RollbackCall.Receives.TemplateID = templateID
+	v.RollbackCall.Receives.Version = version
+
+	return v.RollbackCall.Returns.Template, v.RollbackCall.Returns.Error
+}
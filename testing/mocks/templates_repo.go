@@ -35,6 +35,18 @@ type TemplatesRepo struct {
 		}
 	}
 
+	FindVersionCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			TemplateID string
+			Version    int
+		}
+		Returns struct {
+			Version models.TemplateVersion
+			Error   error
+		}
+	}
+
 	ListIDsAndNamesCall struct {
 		Receives struct {
 			Connection models.ConnectionInterface
@@ -45,6 +57,29 @@ type TemplatesRepo struct {
 		}
 	}
 
+	ListVersionsCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			TemplateID string
+		}
+		Returns struct {
+			Versions []models.TemplateVersion
+			Error    error
+		}
+	}
+
+	RestoreCall struct {
+		Receives struct {
+			Connection      models.ConnectionInterface
+			TemplateID      string
+			TemplateVersion models.TemplateVersion
+		}
+		Returns struct {
+			Template models.Template
+			Error    error
+		}
+	}
+
 	UpdateCall struct {
 		Receives struct {
 			Connection models.ConnectionInterface
@@ -83,12 +118,35 @@ func (tr *TemplatesRepo) FindByID(conn models.ConnectionInterface, templateID st
 	return tr.FindByIDCall.Returns.Template, tr.FindByIDCall.Returns.Error
 }
 
+func (tr *TemplatesRepo) FindVersion(conn models.ConnectionInterface, templateID string, version int) (models.TemplateVersion, error) {
+	tr.FindVersionCall.Receives.Connection = conn
+	tr.FindVersionCall.Receives.TemplateID = templateID
+	tr.FindVersionCall.Receives.Version = version
+
+	return tr.FindVersionCall.Returns.Version, tr.FindVersionCall.Returns.Error
+}
+
 func (tr *TemplatesRepo) ListIDsAndNames(conn models.ConnectionInterface) ([]models.Template, error) {
 	tr.ListIDsAndNamesCall.Receives.Connection = conn
 
 	return tr.ListIDsAndNamesCall.Returns.Templates, tr.ListIDsAndNamesCall.Returns.Error
 }
 
+func (tr *TemplatesRepo) ListVersions(conn models.ConnectionInterface, templateID string) ([]models.TemplateVersion, error) {
+	tr.ListVersionsCall.Receives.Connection = conn
+	tr.ListVersionsCall.Receives.TemplateID = templateID
+
+	return tr.ListVersionsCall.Returns.Versions, tr.ListVersionsCall.Returns.Error
+}
+
+func (tr *TemplatesRepo) Restore(conn models.ConnectionInterface, templateID string, templateVersion models.TemplateVersion) (models.Template, error) {
+	tr.RestoreCall.Receives.Connection = conn
+	tr.RestoreCall.Receives.TemplateID = templateID
+	tr.RestoreCall.Receives.TemplateVersion = templateVersion
+
+	return tr.RestoreCall.Returns.Template, tr.RestoreCall.Returns.Error
+}
+
 func (tr *TemplatesRepo) Update(conn models.ConnectionInterface, templateID string, template models.Template) (models.Template, error) {
 	tr.UpdateCall.Receives.Connection = conn
 	tr.UpdateCall.Receives.TemplateID = templateID
@@ -0,0 +1,47 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/services"
+
+type Unsubscriber struct {
+	FindCall struct {
+		Receives struct {
+			Connection    services.ConnectionInterface
+			UnsubscribeID string
+		}
+		Returns struct {
+			Link  services.UnsubscribeLink
+			Error error
+		}
+	}
+
+	UnsubscribeCall struct {
+		Receives struct {
+			Connection    services.ConnectionInterface
+			UnsubscribeID string
+			Global        bool
+		}
+		Returns struct {
+			Link  services.UnsubscribeLink
+			Error error
+		}
+	}
+}
+
+func NewUnsubscriber() *Unsubscriber {
+	return &Unsubscriber{}
+}
+
+func (u *Unsubscriber) Find(conn services.ConnectionInterface, unsubscribeID string) (services.UnsubscribeLink, error) {
+	u.FindCall.Receives.Connection = conn
+	u.FindCall.Receives.UnsubscribeID = unsubscribeID
+
+	return u.FindCall.Returns.Link, u.FindCall.Returns.Error
+}
+
+func (u *Unsubscriber) Unsubscribe(conn services.ConnectionInterface, unsubscribeID string, global bool) (services.UnsubscribeLink, error) {
+	u.UnsubscribeCall.Receives.Connection = conn
+	u.UnsubscribeCall.Receives.UnsubscribeID = unsubscribeID
+	u.UnsubscribeCall.Receives.Global = global
+
+	return u.UnsubscribeCall.Returns.Link, u.UnsubscribeCall.Returns.Error
+}
@@ -1,8 +1,10 @@
 package uaa
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/pivotal-cf-experimental/warrant"
@@ -54,21 +56,71 @@ func (z ZonedUAAClient) UsersEmailsByIDs(token string, ids ...string) ([]User, e
 		return nil, err
 	}
 
-	uaaClient := uaaSSOGolang.NewUAA("", uaaHost, z.clientID, z.clientSecret, "")
-	uaaClient.VerifySSL = z.verifySSL
-	uaaClient.SetToken(token)
+	client := uaaSSOGolang.NewClient(uaaHost, z.verifySSL).WithAuthorizationToken(token)
 
 	var myUsers []User
-	users, err := uaaClient.UsersEmailsByIDs(ids...)
-	if err != nil {
-		return myUsers, err
+	for _, path := range usersQueryPaths(uaaHost, ids) {
+		code, body, err := client.MakeRequest("GET", path, nil)
+		if err != nil {
+			return myUsers, err
+		}
+
+		if code > 399 {
+			return myUsers, NewFailure(code, body)
+		}
+
+		var response struct {
+			Resources []struct {
+				ID     string `json:"id"`
+				Locale string `json:"locale"`
+				Emails []struct {
+					Value string `json:"value"`
+				} `json:"emails"`
+			} `json:"resources"`
+		}
+
+		err = json.Unmarshal(body, &response)
+		if err != nil {
+			return myUsers, err
+		}
+
+		for _, resource := range response.Resources {
+			user := User{
+				ID:     resource.ID,
+				Locale: resource.Locale,
+			}
+			for _, email := range resource.Emails {
+				user.Emails = append(user.Emails, email.Value)
+			}
+
+			myUsers = append(myUsers, user)
+		}
 	}
 
-	for _, user := range users {
-		myUsers = append(myUsers, newUserFromSSOGolangUser(user))
+	return myUsers, nil
+}
+
+// usersQueryPaths splits a users query across as many requests as it takes
+// to keep every request URI within the length UAA will accept.
+func usersQueryPaths(host string, ids []string) []string {
+	var paths []string
+	var filters []string
+
+	path := func(filters []string) string {
+		return "/Users?attributes=emails,id,locale&filter=" + url.QueryEscape(strings.Join(filters, " or "))
 	}
 
-	return myUsers, nil
+	for _, id := range ids {
+		filter := fmt.Sprintf(`Id eq "%s"`, id)
+		if len(filters) > 0 && len(host+path(append(filters, filter))) > uaaSSOGolang.MaxQueryLength {
+			paths = append(paths, path(filters))
+			filters = nil
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return append(paths, path(filters))
 }
 
 func (z ZonedUAAClient) tokenHost(token string) (string, error) {
@@ -136,6 +188,7 @@ func newUserFromSSOGolangUser(uaaUser uaaSSOGolang.User) User {
 type User struct {
 	ID     string
 	Emails []string
+	Locale string
 }
 
 type Failure struct {
@@ -0,0 +1,114 @@
+package uaa_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/uaa"
+	"github.com/pivotal-cf-experimental/warrant"
+	"github.com/pivotal-golang/lager"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ZonedUAAClient", func() {
+	Describe("UsersEmailsByIDs", func() {
+		var (
+			server      *httptest.Server
+			client      uaa.ZonedUAAClient
+			token       string
+			requestURIs []string
+			status      int
+		)
+
+		BeforeEach(func() {
+			requestURIs = []string{}
+			status = http.StatusOK
+
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				requestURIs = append(requestURIs, req.URL.RequestURI())
+
+				if status != http.StatusOK {
+					w.WriteHeader(status)
+					w.Write([]byte(`{"error": "nope"}`))
+					return
+				}
+
+				resources := []map[string]interface{}{}
+				for _, match := range regexp.MustCompile(`Id eq "([^"]*)"`).FindAllStringSubmatch(req.URL.Query().Get("filter"), -1) {
+					resources = append(resources, map[string]interface{}{
+						"id":     match[1],
+						"locale": "fr-CA",
+						"emails": []map[string]string{{"value": match[1] + "@example.com"}},
+					})
+				}
+
+				response, err := json.Marshal(map[string]interface{}{"resources": resources})
+				Expect(err).NotTo(HaveOccurred())
+
+				w.Write(response)
+			}))
+
+			keyFetcher := &mocks.KeyFetcher{}
+			keyFetcher.GetSigningKeysCall.Returns.Keys = []warrant.SigningKey{
+				{KeyId: "some-key", Algorithm: "RS256", Value: helpers.UAAPublicKey},
+			}
+			validator := uaa.NewTokenValidator(lager.NewLogger("test"), keyFetcher)
+
+			token = helpers.BuildToken(map[string]interface{}{
+				"alg": "RS256",
+				"kid": "some-key",
+			}, map[string]interface{}{
+				"iss": server.URL + "/oauth/token",
+				"exp": 3404281214,
+			})
+
+			client = uaa.NewZonedUAAClient("client-id", "client-secret", false, validator)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("returns the emails and locale of each user", func() {
+			users, err := client.UsersEmailsByIDs(token, "user-123", "user-456")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(users).To(Equal([]uaa.User{
+				{ID: "user-123", Emails: []string{"user-123@example.com"}, Locale: "fr-CA"},
+				{ID: "user-456", Emails: []string{"user-456@example.com"}, Locale: "fr-CA"},
+			}))
+
+			Expect(requestURIs).To(HaveLen(1))
+			Expect(requestURIs[0]).To(ContainSubstring("attributes=emails,id,locale"))
+		})
+
+		It("splits long queries across several requests", func() {
+			var ids []string
+			for i := 0; i < 500; i++ {
+				ids = append(ids, fmt.Sprintf("6f1bd0c5-1c4c-4d3a-9c1a-%012d", i))
+			}
+
+			users, err := client.UsersEmailsByIDs(token, ids...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(users).To(HaveLen(500))
+
+			Expect(len(requestURIs)).To(BeNumerically(">", 1))
+			for _, uri := range requestURIs {
+				Expect(len(server.URL + uri)).To(BeNumerically("<=", 8000))
+			}
+		})
+
+		It("returns a failure when UAA responds with an error", func() {
+			status = http.StatusNotFound
+
+			_, err := client.UsersEmailsByIDs(token, "user-123")
+			Expect(err).To(MatchError(uaa.NewFailure(http.StatusNotFound, []byte(`{"error": "nope"}`))))
+		})
+	})
+})
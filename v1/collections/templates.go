@@ -46,12 +46,14 @@ type TemplateAssociation struct {
 }
 
 type Template struct {
-	ID       string
-	Name     string
-	Text     string
-	HTML     string
-	Subject  string
-	Metadata string
+	ID            string
+	Name          string
+	Text          string
+	HTML          string
+	Subject       string
+	Metadata      string
+	Localizations string
+	Strict        bool
 }
 
 type TemplatesCollection struct {
@@ -175,23 +177,27 @@ func (c TemplatesCollection) ListAssociations(conn ConnectionInterface, template
 
 func (c TemplatesCollection) Create(connection ConnectionInterface, template Template) (Template, error) {
 	tmpl, err := c.templatesRepo.Create(connection, models.Template{
-		Name:     template.Name,
-		Text:     template.Text,
-		HTML:     template.HTML,
-		Subject:  template.Subject,
-		Metadata: template.Metadata,
+		Name:          template.Name,
+		Text:          template.Text,
+		HTML:          template.HTML,
+		Subject:       template.Subject,
+		Metadata:      template.Metadata,
+		Localizations: template.Localizations,
+		Strict:        template.Strict,
 	})
 	if err != nil {
 		return Template{}, err
 	}
 
 	return Template{
-		ID:       tmpl.ID,
-		Name:     tmpl.Name,
-		Text:     tmpl.Text,
-		HTML:     tmpl.HTML,
-		Subject:  tmpl.Subject,
-		Metadata: tmpl.Metadata,
+		ID:            tmpl.ID,
+		Name:          tmpl.Name,
+		Text:          tmpl.Text,
+		HTML:          tmpl.HTML,
+		Subject:       tmpl.Subject,
+		Metadata:      tmpl.Metadata,
+		Localizations: tmpl.Localizations,
+		Strict:        tmpl.Strict,
 	}, nil
 }
 
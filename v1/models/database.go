@@ -34,5 +34,10 @@ func Setup(database *db.DB) {
 	database.TableMap().AddTableWithName(Unsubscribe{}, "unsubscribes").SetKeys(true, "Primary").SetUniqueTogether("user_id", "client_id", "kind_id")
 	database.TableMap().AddTableWithName(GlobalUnsubscribe{}, "global_unsubscribes").SetKeys(true, "Primary").ColMap("UserID").SetUnique(true)
 	database.TableMap().AddTableWithName(Template{}, "templates").SetKeys(true, "Primary").ColMap("Name").SetUnique(true)
+	database.TableMap().AddTableWithName(TemplateVersion{}, "template_versions").SetKeys(true, "Primary").SetUniqueTogether("template_id", "version")
 	database.TableMap().AddTableWithName(Message{}, "messages").SetKeys(false, "ID")
+	database.TableMap().AddTableWithName(MessageEvent{}, "message_events").SetKeys(true, "Primary")
+	database.TableMap().AddTableWithName(IdempotencyKey{}, "idempotency_keys").SetKeys(true, "Primary").SetUniqueTogether("client_id", "idempotency_key")
+	database.TableMap().AddTableWithName(Suppression{}, "suppressions").SetKeys(true, "Primary").ColMap("Email").SetUnique(true)
+	database.TableMap().AddTableWithName(RateLimit{}, "rate_limits").SetKeys(true, "Primary").ColMap("ClientID").SetUnique(true)
 }
@@ -68,15 +68,30 @@ func (d DatabaseMigrator) Seed(database DatabaseInterface, defaultTemplatePath s
 	}
 
 	if !existingTemplate.Overridden {
+		unchanged := existingTemplate.Name == template.Name &&
+			existingTemplate.Subject == template.Subject &&
+			existingTemplate.HTML == template.HTML &&
+			existingTemplate.Text == template.Text &&
+			existingTemplate.Metadata == string(template.Metadata)
+		if unchanged {
+			return
+		}
+
 		existingTemplate.Name = template.Name
 		existingTemplate.Subject = template.Subject
 		existingTemplate.HTML = template.HTML
 		existingTemplate.Text = template.Text
 		existingTemplate.Metadata = string(template.Metadata)
 		existingTemplate.UpdatedAt = time.Now().Truncate(1 * time.Second).UTC()
+		existingTemplate.Version++
 		_, err = conn.Update(&existingTemplate)
 		if err != nil {
 			panic(err)
 		}
+
+		err = repo.recordVersion(conn, existingTemplate)
+		if err != nil {
+			panic(err)
+		}
 	}
 }
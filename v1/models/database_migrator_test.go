@@ -63,6 +63,7 @@ var _ = Describe("DatabaseMigrator", func() {
 			Expect(tables).To(ContainElement("unsubscribes"))
 			Expect(tables).To(ContainElement("global_unsubscribes"))
 			Expect(tables).To(ContainElement("templates"))
+			Expect(tables).To(ContainElement("template_versions"))
 		})
 	})
 
@@ -122,6 +123,29 @@ var _ = Describe("DatabaseMigrator", func() {
 				Expect(template.Metadata).To(Equal("{}"))
 				Expect(template.Overridden).To(BeFalse())
 			})
+
+			It("records a new version only when the file has changed", func() {
+				dbMigrator.Seed(database, defaultTemplatePath)
+				dbMigrator.Seed(database, defaultTemplatePath)
+
+				template, err := repo.FindByID(connection, models.DefaultTemplateID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(template.Version).To(Equal(1))
+
+				template.Subject = "Updated Subject"
+				_, err = connection.Update(&template)
+				Expect(err).NotTo(HaveOccurred())
+
+				dbMigrator.Seed(database, defaultTemplatePath)
+
+				template, err = repo.FindByID(connection, models.DefaultTemplateID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(template.Version).To(Equal(2))
+
+				version, err := repo.FindVersion(connection, models.DefaultTemplateID, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(version.Subject).To(Equal("CF Notification: {{.Subject}}"))
+			})
 		})
 
 		Context("when it has been overridden", func() {
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type IdempotencyKeysRepo struct{}
+
+func NewIdempotencyKeysRepo() IdempotencyKeysRepo {
+	return IdempotencyKeysRepo{}
+}
+
+func (repo IdempotencyKeysRepo) Create(conn ConnectionInterface, key IdempotencyKey) (IdempotencyKey, error) {
+	err := conn.Insert(&key)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			err = DuplicateError{errors.New("duplicate record")}
+		}
+		return IdempotencyKey{}, err
+	}
+
+	return key, nil
+}
+
+func (repo IdempotencyKeysRepo) Find(conn ConnectionInterface, clientID, key string) (IdempotencyKey, error) {
+	record := IdempotencyKey{}
+	err := conn.SelectOne(&record, "SELECT * FROM `idempotency_keys` WHERE `client_id` = ? AND `idempotency_key` = ?", clientID, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = NotFoundError{fmt.Errorf("Idempotency key %q belonging to client %q could not be found", key, clientID)}
+		}
+		return IdempotencyKey{}, err
+	}
+
+	return record, nil
+}
+
+func (repo IdempotencyKeysRepo) Update(conn ConnectionInterface, key IdempotencyKey) (IdempotencyKey, error) {
+	_, err := conn.Update(&key)
+	if err != nil {
+		return IdempotencyKey{}, err
+	}
+
+	return key, nil
+}
+
+// TakeOver moves a reservation that never got a response to the given time.
+// It only succeeds while the reservation is still the one that was read, so
+// that exactly one of several concurrent retries takes it over.
+func (repo IdempotencyKeysRepo) TakeOver(conn ConnectionInterface, key IdempotencyKey, reservedAt time.Time) (bool, error) {
+	result, err := conn.Exec("UPDATE `idempotency_keys` SET `created_at` = ? WHERE `client_id` = ? AND `idempotency_key` = ? AND `created_at` = ? AND `response` = ''",
+		reservedAt.UTC(), key.ClientID, key.Key, key.CreatedAt.UTC())
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count == 1, nil
+}
+
+func (repo IdempotencyKeysRepo) Delete(conn ConnectionInterface, clientID, key string) error {
+	_, err := conn.Exec("DELETE FROM `idempotency_keys` WHERE `client_id` = ? AND `idempotency_key` = ?", clientID, key)
+	return err
+}
+
+func (repo IdempotencyKeysRepo) DeleteBefore(conn ConnectionInterface, clientID string, before time.Time) (int, error) {
+	result, err := conn.Exec("DELETE FROM `idempotency_keys` WHERE `client_id` = ? AND `created_at` < ?", clientID, before.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
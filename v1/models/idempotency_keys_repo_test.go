@@ -0,0 +1,165 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IdempotencyKeysRepo", func() {
+	var (
+		repo models.IdempotencyKeysRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+
+		repo = models.NewIdempotencyKeysRepo()
+	})
+
+	Describe("Create", func() {
+		It("inserts a key with a creation timestamp", func() {
+			key, err := repo.Create(conn, models.IdempotencyKey{
+				ClientID:    "some-client",
+				Key:         "some-key",
+				RequestHash: "some-hash",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(key.Primary).NotTo(BeZero())
+			Expect(key.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+		})
+
+		It("returns a duplicate error when the client has already used the key", func() {
+			_, err := repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "some-key", RequestHash: "some-hash"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "some-key", RequestHash: "other-hash"})
+			Expect(err).To(BeAssignableToTypeOf(models.DuplicateError{}))
+
+			_, err = repo.Create(conn, models.IdempotencyKey{ClientID: "other-client", Key: "some-key", RequestHash: "other-hash"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Find", func() {
+		It("finds the key belonging to the client", func() {
+			created, err := repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "some-key", RequestHash: "some-hash"})
+			Expect(err).NotTo(HaveOccurred())
+
+			key, err := repo.Find(conn, "some-client", "some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key.Primary).To(Equal(created.Primary))
+			Expect(key.RequestHash).To(Equal("some-hash"))
+		})
+
+		It("returns a not found error when the key is unknown", func() {
+			_, err := repo.Find(conn, "some-client", "missing-key")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+
+	Describe("Update", func() {
+		It("stores the response", func() {
+			key, err := repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "some-key", RequestHash: "some-hash"})
+			Expect(err).NotTo(HaveOccurred())
+
+			key.Response = `[{"status":"queued"}]`
+			_, err = repo.Update(conn, key)
+			Expect(err).NotTo(HaveOccurred())
+
+			key, err = repo.Find(conn, "some-client", "some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key.Response).To(Equal(`[{"status":"queued"}]`))
+		})
+	})
+
+	Describe("TakeOver", func() {
+		var key models.IdempotencyKey
+
+		BeforeEach(func() {
+			var err error
+			key, err = repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "some-key", RequestHash: "some-hash", CreatedAt: time.Now().Add(-time.Hour)})
+			Expect(err).NotTo(HaveOccurred())
+
+			key, err = repo.Find(conn, "some-client", "some-key")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("moves the reservation to the given time", func() {
+			reservedAt := time.Now().Truncate(time.Second).UTC()
+
+			takenOver, err := repo.TakeOver(conn, key, reservedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(takenOver).To(BeTrue())
+
+			key, err = repo.Find(conn, "some-client", "some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key.CreatedAt).To(BeTemporally("==", reservedAt))
+		})
+
+		It("only lets one caller take over the same reservation", func() {
+			takenOver, err := repo.TakeOver(conn, key, time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(takenOver).To(BeTrue())
+
+			takenOver, err = repo.TakeOver(conn, key, time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(takenOver).To(BeFalse())
+		})
+
+		It("does not take over a reservation that has a response", func() {
+			key.Response = `[{"status":"queued"}]`
+			_, err := repo.Update(conn, key)
+			Expect(err).NotTo(HaveOccurred())
+
+			takenOver, err := repo.TakeOver(conn, key, time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(takenOver).To(BeFalse())
+		})
+	})
+
+	Describe("Delete", func() {
+		It("deletes the key", func() {
+			_, err := repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "some-key", RequestHash: "some-hash"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(repo.Delete(conn, "some-client", "some-key")).To(Succeed())
+
+			_, err = repo.Find(conn, "some-client", "some-key")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+
+	Describe("DeleteBefore", func() {
+		It("deletes the client's keys created before the given time", func() {
+			_, err := repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "old-key", RequestHash: "some-hash", CreatedAt: time.Now().Add(-2 * time.Hour)})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "new-key", RequestHash: "some-hash"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = repo.Create(conn, models.IdempotencyKey{ClientID: "other-client", Key: "old-key", RequestHash: "some-hash", CreatedAt: time.Now().Add(-2 * time.Hour)})
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := repo.DeleteBefore(conn, "some-client", time.Now().Add(-time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+
+			_, err = repo.Find(conn, "some-client", "old-key")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+
+			_, err = repo.Find(conn, "some-client", "new-key")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Find(conn, "other-client", "old-key")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -7,14 +7,24 @@ import (
 )
 
 type Message struct {
-	ID         string    `db:"id"`
-	Status     string    `db:"status"`
-	UpdatedAt  time.Time `db:"updated_at"`
+	ID            string    `db:"id"`
+	Status        string    `db:"status"`
+	ClientID      string    `db:"client_id"`
+	KindID        string    `db:"kind_id"`
+	UserGUID      string    `db:"user_guid"`
+	Email         string    `db:"email"`
+	VCAPRequestID string    `db:"vcap_request_id"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
 }
 
 func (m *Message) PreInsert(s gorp.SqlExecutor) error {
 	m.UpdatedAt = time.Now().Truncate(1 * time.Second).UTC()
 
+	if (m.CreatedAt == time.Time{}) {
+		m.CreatedAt = m.UpdatedAt
+	}
+
 	return nil
 }
 
@@ -23,3 +33,31 @@ func (m *Message) PreUpdate(s gorp.SqlExecutor) error {
 
 	return nil
 }
+
+func (m Message) withMetadataFrom(existing Message) Message {
+	if m.ClientID == "" {
+		m.ClientID = existing.ClientID
+	}
+
+	if m.KindID == "" {
+		m.KindID = existing.KindID
+	}
+
+	if m.UserGUID == "" {
+		m.UserGUID = existing.UserGUID
+	}
+
+	if m.Email == "" {
+		m.Email = existing.Email
+	}
+
+	if m.VCAPRequestID == "" {
+		m.VCAPRequestID = existing.VCAPRequestID
+	}
+
+	if (m.CreatedAt == time.Time{}) {
+		m.CreatedAt = existing.CreatedAt
+	}
+
+	return m
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+const (
+	MessageEventQueued              = "queued"
+	MessageEventReserved            = "reserved"
+	MessageEventTemplatePacked      = "template-packed"
+	MessageEventSMTPAccepted        = "smtp-accepted"
+	MessageEventFailed              = "failed"
+	MessageEventRetried             = "retried"
+	MessageEventDeadLettered        = "dead-lettered"
+	MessageEventUnsubscribedSkipped = "unsubscribed-skipped"
+	MessageEventUndeliverable       = "undeliverable"
+	MessageEventScheduled           = "scheduled"
+	MessageEventCancelled           = "cancelled"
+	MessageEventBounced             = "bounced"
+	MessageEventSuppressedSkipped   = "suppressed-skipped"
+)
+
+type MessageEvent struct {
+	Primary   int       `db:"primary"`
+	MessageID string    `db:"message_id"`
+	Event     string    `db:"event"`
+	Detail    string    `db:"detail"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (e *MessageEvent) PreInsert(s gorp.SqlExecutor) error {
+	if (e.CreatedAt == time.Time{}) {
+		e.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package models
+
+type MessageEventsRepo struct{}
+
+func NewMessageEventsRepo() MessageEventsRepo {
+	return MessageEventsRepo{}
+}
+
+func (repo MessageEventsRepo) Create(conn ConnectionInterface, event MessageEvent) (MessageEvent, error) {
+	err := conn.Insert(&event)
+	if err != nil {
+		return MessageEvent{}, err
+	}
+
+	return event, nil
+}
+
+func (repo MessageEventsRepo) ListByMessageID(conn ConnectionInterface, messageID string) ([]MessageEvent, error) {
+	events := []MessageEvent{}
+	_, err := conn.Select(&events, "SELECT * FROM `message_events` WHERE `message_id` = ? ORDER BY `primary`", messageID)
+	if err != nil {
+		return []MessageEvent{}, err
+	}
+
+	return events, nil
+}
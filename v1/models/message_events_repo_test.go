@@ -0,0 +1,74 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MessageEventsRepo", func() {
+	var (
+		repo models.MessageEventsRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+
+		repo = models.NewMessageEventsRepo()
+	})
+
+	Describe("Create", func() {
+		It("inserts an event with a creation timestamp", func() {
+			event, err := repo.Create(conn, models.MessageEvent{
+				MessageID: "some-message-id",
+				Event:     models.MessageEventQueued,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(event.Primary).NotTo(BeZero())
+			Expect(event.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+		})
+	})
+
+	Describe("ListByMessageID", func() {
+		It("returns the events for the message in the order they were recorded", func() {
+			for _, name := range []string{models.MessageEventQueued, models.MessageEventReserved, models.MessageEventFailed} {
+				_, err := repo.Create(conn, models.MessageEvent{
+					MessageID: "some-message-id",
+					Event:     name,
+					Detail:    name + "-detail",
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err := repo.Create(conn, models.MessageEvent{
+				MessageID: "other-message-id",
+				Event:     models.MessageEventQueued,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := repo.ListByMessageID(conn, "some-message-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events).To(HaveLen(3))
+			Expect(events[0].Event).To(Equal(models.MessageEventQueued))
+			Expect(events[1].Event).To(Equal(models.MessageEventReserved))
+			Expect(events[2].Event).To(Equal(models.MessageEventFailed))
+			Expect(events[2].Detail).To(Equal("failed-detail"))
+		})
+
+		It("returns an empty list when the message has no events", func() {
+			events, err := repo.ListByMessageID(conn, "missing-message-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+	})
+})
@@ -3,9 +3,31 @@ package models
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 )
 
+// MessagesDeletion selects messages to delete. Messages with one of the
+// UnsentStatuses are kept while their scheduled send time is still ahead.
+type MessagesDeletion struct {
+	Before           time.Time
+	Statuses         []string
+	ExcludedStatuses []string
+	UnsentStatuses   []string
+	Limit            int
+}
+
+type MessagesFilter struct {
+	ClientID      string
+	KindID        string
+	UserGUID      string
+	Email         string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Limit         int
+	Offset        int
+}
+
 type IDGeneratorFunc func() (string, error)
 
 type MessagesRepo struct {
@@ -56,26 +78,143 @@ func (repo MessagesRepo) Update(conn ConnectionInterface, message Message) (Mess
 }
 
 func (repo MessagesRepo) Upsert(conn ConnectionInterface, message Message) (Message, error) {
-	_, err := repo.FindByID(conn, message.ID)
+	existing, err := repo.FindByID(conn, message.ID)
 
 	switch err.(type) {
 	case NotFoundError:
 		return repo.Create(conn, message)
 	case nil:
-		return repo.Update(conn, message)
+		return repo.Update(conn, message.withMetadataFrom(existing))
 	default:
 		return message, err
 	}
 }
 
-func (repo MessagesRepo) DeleteBefore(conn ConnectionInterface, threshold time.Time) (int, error) {
-	result, err := conn.Exec("DELETE FROM `messages` WHERE `updated_at` < ?", threshold.UTC())
+func (repo MessagesRepo) TransitionStatus(conn ConnectionInterface, messageID, fromStatus, toStatus string) (bool, error) {
+	result, err := conn.Exec("UPDATE `messages` SET `status` = ?, `updated_at` = ? WHERE `id` = ? AND `status` = ?",
+		toStatus, time.Now().Truncate(1*time.Second).UTC(), messageID, fromStatus)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (repo MessagesRepo) DeleteBatch(conn ConnectionInterface, deletion MessagesDeletion) (int, error) {
+	conditions := []string{"`updated_at` < ?"}
+	args := []interface{}{deletion.Before.UTC()}
+
+	if len(deletion.Statuses) > 0 {
+		conditions = append(conditions, "`status` IN ("+placeholders(len(deletion.Statuses))+")")
+		for _, status := range deletion.Statuses {
+			args = append(args, status)
+		}
+	}
+
+	if len(deletion.ExcludedStatuses) > 0 {
+		conditions = append(conditions, "`status` NOT IN ("+placeholders(len(deletion.ExcludedStatuses))+")")
+		for _, status := range deletion.ExcludedStatuses {
+			args = append(args, status)
+		}
+	}
+
+	if len(deletion.UnsentStatuses) > 0 {
+		conditions = append(conditions, "NOT ( `status` IN ("+placeholders(len(deletion.UnsentStatuses))+") AND EXISTS ( SELECT 1 FROM `message_events` WHERE `message_events`.`message_id` = `messages`.`id` AND `message_events`.`event` = ? AND `message_events`.`detail` > ? ) )")
+		for _, status := range deletion.UnsentStatuses {
+			args = append(args, status)
+		}
+		args = append(args, MessageEventScheduled, time.Now().UTC().Format(time.RFC3339))
+	}
+
+	var ids []string
+	_, err := conn.Select(&ids, "SELECT `id` FROM `messages` WHERE "+strings.Join(conditions, " AND ")+" LIMIT ?", append(args, deletion.Limit)...)
 	if err != nil {
 		return 0, err
 	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	idArgs := []interface{}{}
+	for _, id := range ids {
+		idArgs = append(idArgs, id)
+	}
+
+	_, err = conn.Exec("DELETE FROM `message_events` WHERE `message_id` IN ("+placeholders(len(ids))+")", idArgs...)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := conn.Exec("DELETE FROM `messages` WHERE `id` IN ("+placeholders(len(ids))+")", idArgs...)
+	if err != nil {
+		return 0, err
+	}
+
 	count, err := result.RowsAffected()
 	if err != nil {
 		return 0, err
 	}
+
 	return int(count), nil
 }
+
+func (repo MessagesRepo) List(conn ConnectionInterface, filter MessagesFilter) ([]Message, int, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	for _, equality := range []struct {
+		column string
+		value  string
+	}{
+		{"client_id", filter.ClientID},
+		{"kind_id", filter.KindID},
+		{"user_guid", filter.UserGUID},
+		{"email", filter.Email},
+	} {
+		if equality.value != "" {
+			conditions = append(conditions, fmt.Sprintf("`%s` = ?", equality.column))
+			args = append(args, equality.value)
+		}
+	}
+
+	if (filter.CreatedAfter != time.Time{}) {
+		conditions = append(conditions, "`created_at` >= ?")
+		args = append(args, filter.CreatedAfter.UTC())
+	}
+
+	if (filter.CreatedBefore != time.Time{}) {
+		conditions = append(conditions, "`created_at` < ?")
+		args = append(args, filter.CreatedBefore.UTC())
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	err := conn.SelectOne(&total, "SELECT COUNT(*) FROM `messages`"+where, args...)
+	if err != nil {
+		return []Message{}, 0, err
+	}
+
+	messages := []Message{}
+	_, err = conn.Select(&messages, "SELECT * FROM `messages`"+where+" ORDER BY `created_at` DESC, `id` LIMIT ? OFFSET ?", append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return []Message{}, 0, err
+	}
+
+	return messages, total, nil
+}
+
+func placeholders(count int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", count), ", ")
+}
@@ -109,33 +109,266 @@ var _ = Describe("MessagesRepo", func() {
 				Expect(messageFound.ID).To(Equal(message.ID))
 				Expect(messageFound.Status).To(Equal(message.Status))
 			})
+
+			It("keeps the metadata recorded when the message was created", func() {
+				message.ClientID = "some-client"
+				message.KindID = "some-kind"
+				message.UserGUID = "some-user"
+				message.Email = "user@example.com"
+				message.VCAPRequestID = "some-request-id"
+
+				message, err := repo.Create(conn, message)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = repo.Upsert(conn, models.Message{
+					ID:     message.ID,
+					Status: common.StatusFailed,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				messageFound, err := repo.FindByID(conn, message.ID)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(messageFound.Status).To(Equal(common.StatusFailed))
+				Expect(messageFound.ClientID).To(Equal("some-client"))
+				Expect(messageFound.KindID).To(Equal("some-kind"))
+				Expect(messageFound.UserGUID).To(Equal("some-user"))
+				Expect(messageFound.Email).To(Equal("user@example.com"))
+				Expect(messageFound.VCAPRequestID).To(Equal("some-request-id"))
+				Expect(messageFound.CreatedAt).To(Equal(message.CreatedAt))
+			})
+		})
+	})
+
+	Describe("TransitionStatus", func() {
+		It("updates the status when the message is in the expected status", func() {
+			message.Status = "scheduled"
+			message, err := repo.Create(conn, message)
+			Expect(err).NotTo(HaveOccurred())
+
+			transitioned, err := repo.TransitionStatus(conn, message.ID, "scheduled", "cancelled")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transitioned).To(BeTrue())
+
+			messageFound, err := repo.FindByID(conn, message.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(messageFound.Status).To(Equal("cancelled"))
+		})
+
+		It("leaves the message alone when it is in another status", func() {
+			message, err := repo.Create(conn, message)
+			Expect(err).NotTo(HaveOccurred())
+
+			transitioned, err := repo.TransitionStatus(conn, message.ID, "scheduled", "cancelled")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transitioned).To(BeFalse())
+
+			messageFound, err := repo.FindByID(conn, message.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(messageFound.Status).To(Equal(common.StatusDelivered))
+		})
+	})
+
+	Describe("List", func() {
+		BeforeEach(func() {
+			guidGenerator.GenerateCall.Returns.IDs = []string{"message-1", "message-2", "message-3", "message-4"}
+			now := time.Now().Truncate(time.Second).UTC()
+
+			for _, m := range []models.Message{
+				{ClientID: "client-1", KindID: "kind-1", UserGUID: "user-1", Email: "one@example.com", CreatedAt: now.Add(-48 * time.Hour)},
+				{ClientID: "client-1", KindID: "kind-1", UserGUID: "user-2", Email: "two@example.com", CreatedAt: now.Add(-2 * time.Hour)},
+				{ClientID: "client-1", KindID: "kind-2", UserGUID: "user-1", Email: "one@example.com", CreatedAt: now.Add(-1 * time.Hour)},
+				{ClientID: "client-2", KindID: "kind-1", UserGUID: "user-1", Email: "one@example.com", CreatedAt: now},
+			} {
+				m.Status = common.StatusQueued
+				_, err := repo.Create(conn, m)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns the most recent messages first along with the total", func() {
+			messages, total, err := repo.List(conn, models.MessagesFilter{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(total).To(Equal(4))
+			Expect(messages).To(HaveLen(4))
+			Expect(messages[0].ID).To(Equal("message-4"))
+			Expect(messages[3].ID).To(Equal("message-1"))
+		})
+
+		It("filters by client, kind and recipient", func() {
+			messages, total, err := repo.List(conn, models.MessagesFilter{
+				ClientID: "client-1",
+				KindID:   "kind-1",
+				UserGUID: "user-1",
+				Limit:    10,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(total).To(Equal(1))
+			Expect(messages).To(HaveLen(1))
+			Expect(messages[0].ID).To(Equal("message-1"))
+			Expect(messages[0].Email).To(Equal("one@example.com"))
+		})
+
+		It("filters by creation time", func() {
+			messages, total, err := repo.List(conn, models.MessagesFilter{
+				CreatedAfter:  time.Now().Add(-24 * time.Hour),
+				CreatedBefore: time.Now().Add(-30 * time.Minute),
+				Limit:         10,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(total).To(Equal(2))
+			Expect(messages[0].ID).To(Equal("message-3"))
+			Expect(messages[1].ID).To(Equal("message-2"))
+		})
+
+		It("paginates the results", func() {
+			messages, total, err := repo.List(conn, models.MessagesFilter{
+				Email:  "one@example.com",
+				Limit:  1,
+				Offset: 1,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(total).To(Equal(3))
+			Expect(messages).To(HaveLen(1))
+			Expect(messages[0].ID).To(Equal("message-3"))
 		})
 	})
 
-	Describe("DeleteBefore", func() {
+	Describe("DeleteBatch", func() {
 		It("Deletes messages older than the input time", func() {
 			message, err := repo.Create(conn, message)
 			Expect(err).NotTo(HaveOccurred())
 
-			itemsDeleted, err := repo.DeleteBefore(conn, time.Now().Add(1*time.Hour))
+			itemsDeleted, err := repo.DeleteBatch(conn, models.MessagesDeletion{
+				Before: time.Now().Add(1 * time.Hour),
+				Limit:  10,
+			})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(itemsDeleted).To(Equal(1))
 
 			_, err = repo.FindByID(conn, message.ID)
 			Expect(err).To(MatchError(models.NotFoundError{Err: fmt.Errorf("Message with ID %q could not be found", message.ID)}))
+		})
+
+		It("deletes the events of the deleted messages", func() {
+			message, err := repo.Create(conn, message)
+			Expect(err).NotTo(HaveOccurred())
+
+			eventsRepo := models.NewMessageEventsRepo()
+			_, err = eventsRepo.Create(conn, models.MessageEvent{
+				MessageID: message.ID,
+				Event:     models.MessageEventQueued,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.DeleteBatch(conn, models.MessagesDeletion{
+				Before: time.Now().Add(1 * time.Hour),
+				Limit:  10,
+			})
+			Expect(err).ToNot(HaveOccurred())
 
+			events, err := eventsRepo.ListByMessageID(conn, message.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(BeEmpty())
 		})
 
 		It("Does not delete messages younger than the input time", func() {
 			message, err := repo.Create(conn, message)
 			Expect(err).NotTo(HaveOccurred())
 
-			itemsDeleted, err := repo.DeleteBefore(conn, time.Now().Add(-1*time.Hour))
+			itemsDeleted, err := repo.DeleteBatch(conn, models.MessagesDeletion{
+				Before: time.Now().Add(-1 * time.Hour),
+				Limit:  10,
+			})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(itemsDeleted).To(Equal(0))
 
 			_, err = repo.FindByID(conn, message.ID)
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		It("keeps messages with an unsent status until their send time has passed", func() {
+			eventsRepo := models.NewMessageEventsRepo()
+
+			for id, sendAt := range map[string]time.Time{
+				"pending-message": time.Now().Add(48 * time.Hour),
+				"sent-message":    time.Now().Add(-48 * time.Hour),
+			} {
+				_, err := repo.Create(conn, models.Message{ID: id, Status: common.StatusCancelled})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = eventsRepo.Create(conn, models.MessageEvent{
+					MessageID: id,
+					Event:     models.MessageEventScheduled,
+					Detail:    sendAt.UTC().Format(time.RFC3339),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			itemsDeleted, err := repo.DeleteBatch(conn, models.MessagesDeletion{
+				Before:         time.Now().Add(1 * time.Hour),
+				UnsentStatuses: []string{common.StatusCancelled},
+				Limit:          10,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(itemsDeleted).To(Equal(1))
+
+			_, err = repo.FindByID(conn, "pending-message")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.FindByID(conn, "sent-message")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+
+		It("deletes at most the given number of messages", func() {
+			guidGenerator.GenerateCall.Returns.IDs = []string{"message-1", "message-2", "message-3"}
+			for i := 0; i < 3; i++ {
+				_, err := repo.Create(conn, models.Message{Status: common.StatusDelivered})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			itemsDeleted, err := repo.DeleteBatch(conn, models.MessagesDeletion{
+				Before: time.Now().Add(1 * time.Hour),
+				Limit:  2,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(itemsDeleted).To(Equal(2))
+
+			messages, total, err := repo.List(conn, models.MessagesFilter{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(total).To(Equal(1))
+			Expect(messages).To(HaveLen(1))
+		})
+
+		It("restricts the deletion to or away from the given statuses", func() {
+			guidGenerator.GenerateCall.Returns.IDs = []string{"delivered-message", "failed-message"}
+			_, err := repo.Create(conn, models.Message{Status: common.StatusDelivered})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = repo.Create(conn, models.Message{Status: common.StatusFailed})
+			Expect(err).NotTo(HaveOccurred())
+
+			itemsDeleted, err := repo.DeleteBatch(conn, models.MessagesDeletion{
+				Before:           time.Now().Add(1 * time.Hour),
+				ExcludedStatuses: []string{common.StatusFailed},
+				Limit:            10,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(itemsDeleted).To(Equal(1))
+
+			_, err = repo.FindByID(conn, "failed-message")
+			Expect(err).ToNot(HaveOccurred())
+
+			itemsDeleted, err = repo.DeleteBatch(conn, models.MessagesDeletion{
+				Before:   time.Now().Add(1 * time.Hour),
+				Statuses: []string{common.StatusFailed},
+				Limit:    10,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(itemsDeleted).To(Equal(1))
+		})
 	})
 })
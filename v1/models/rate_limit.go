@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+// RateLimit overrides the default sending limits for a client. A limit of
+// zero means the client is not limited.
+type RateLimit struct {
+	Primary           int       `db:"primary"`
+	ClientID          string    `db:"client_id"`
+	RequestsPerMinute int       `db:"requests_per_minute"`
+	RecipientsPerHour int       `db:"recipients_per_hour"`
+	UpdatedAt         time.Time `db:"updated_at"`
+}
+
+func (r *RateLimit) PreInsert(s gorp.SqlExecutor) error {
+	r.UpdatedAt = time.Now().Truncate(1 * time.Second).UTC()
+
+	return nil
+}
+
+func (r *RateLimit) PreUpdate(s gorp.SqlExecutor) error {
+	r.UpdatedAt = time.Now().Truncate(1 * time.Second).UTC()
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type RateLimitsRepo struct{}
+
+func NewRateLimitsRepo() RateLimitsRepo {
+	return RateLimitsRepo{}
+}
+
+func (repo RateLimitsRepo) Find(conn ConnectionInterface, clientID string) (RateLimit, error) {
+	rateLimit := RateLimit{}
+	err := conn.SelectOne(&rateLimit, "SELECT * FROM `rate_limits` WHERE `client_id` = ?", clientID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = NotFoundError{fmt.Errorf("Rate limit for client %q could not be found", clientID)}
+		}
+		return RateLimit{}, err
+	}
+
+	return rateLimit, nil
+}
+
+func (repo RateLimitsRepo) Upsert(conn ConnectionInterface, rateLimit RateLimit) (RateLimit, error) {
+	existing, err := repo.Find(conn, rateLimit.ClientID)
+
+	switch err.(type) {
+	case NotFoundError:
+		err = conn.Insert(&rateLimit)
+	case nil:
+		rateLimit.Primary = existing.Primary
+		_, err = conn.Update(&rateLimit)
+	}
+	if err != nil {
+		return RateLimit{}, err
+	}
+
+	return rateLimit, nil
+}
+
+func (repo RateLimitsRepo) Delete(conn ConnectionInterface, clientID string) error {
+	result, err := conn.Exec("DELETE FROM `rate_limits` WHERE `client_id` = ?", clientID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return NotFoundError{fmt.Errorf("Rate limit for client %q could not be found", clientID)}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimitsRepo", func() {
+	var (
+		repo models.RateLimitsRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+
+		repo = models.NewRateLimitsRepo()
+	})
+
+	Describe("Upsert", func() {
+		It("creates the rate limit for a client", func() {
+			_, err := repo.Upsert(conn, models.RateLimit{
+				ClientID:          "some-client",
+				RequestsPerMinute: 60,
+				RecipientsPerHour: 1000,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			rateLimit, err := repo.Find(conn, "some-client")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rateLimit.RequestsPerMinute).To(Equal(60))
+			Expect(rateLimit.RecipientsPerHour).To(Equal(1000))
+		})
+
+		It("replaces an existing rate limit", func() {
+			_, err := repo.Upsert(conn, models.RateLimit{ClientID: "some-client", RequestsPerMinute: 60})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Upsert(conn, models.RateLimit{ClientID: "some-client", RecipientsPerHour: 10})
+			Expect(err).NotTo(HaveOccurred())
+
+			rateLimit, err := repo.Find(conn, "some-client")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rateLimit.RequestsPerMinute).To(Equal(0))
+			Expect(rateLimit.RecipientsPerHour).To(Equal(10))
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns a not found error when the client has no rate limit", func() {
+			_, err := repo.Find(conn, "missing-client")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("removes the rate limit", func() {
+			_, err := repo.Upsert(conn, models.RateLimit{ClientID: "some-client", RequestsPerMinute: 60})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(repo.Delete(conn, "some-client")).To(Succeed())
+
+			_, err = repo.Find(conn, "some-client")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+
+		It("returns a not found error when the client has no rate limit", func() {
+			err := repo.Delete(conn, "missing-client")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+})
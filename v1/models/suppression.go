@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+type Suppression struct {
+	Primary   int       `db:"primary"`
+	Email     string    `db:"email"`
+	Reason    string    `db:"reason"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (s *Suppression) PreInsert(executor gorp.SqlExecutor) error {
+	if (s.CreatedAt == time.Time{}) {
+		s.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type SuppressionsRepo struct{}
+
+func NewSuppressionsRepo() SuppressionsRepo {
+	return SuppressionsRepo{}
+}
+
+func (repo SuppressionsRepo) Suppress(conn ConnectionInterface, email, reason string) error {
+	suppression := Suppression{
+		Email:  normalizeEmail(email),
+		Reason: reason,
+	}
+
+	err := conn.Insert(&suppression)
+	if err != nil && !strings.Contains(err.Error(), "Duplicate entry") {
+		return err
+	}
+
+	return nil
+}
+
+func (repo SuppressionsRepo) IsSuppressed(conn ConnectionInterface, email string) (bool, error) {
+	_, err := repo.Find(conn, email)
+	if err != nil {
+		if _, ok := err.(NotFoundError); ok {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo SuppressionsRepo) Find(conn ConnectionInterface, email string) (Suppression, error) {
+	suppression := Suppression{}
+	err := conn.SelectOne(&suppression, "SELECT * FROM `suppressions` WHERE `email` = ?", normalizeEmail(email))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = NotFoundError{fmt.Errorf("Suppression for %q could not be found", email)}
+		}
+		return Suppression{}, err
+	}
+
+	return suppression, nil
+}
+
+func (repo SuppressionsRepo) FindAll(conn ConnectionInterface) ([]Suppression, error) {
+	suppressions := []Suppression{}
+	_, err := conn.Select(&suppressions, "SELECT * FROM `suppressions` ORDER BY `created_at` DESC, `primary` DESC")
+	if err != nil {
+		return nil, err
+	}
+
+	return suppressions, nil
+}
+
+func (repo SuppressionsRepo) Delete(conn ConnectionInterface, email string) error {
+	result, err := conn.Exec("DELETE FROM `suppressions` WHERE `email` = ?", normalizeEmail(email))
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return NotFoundError{fmt.Errorf("Suppression for %q could not be found", email)}
+	}
+
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,89 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SuppressionsRepo", func() {
+	var (
+		repo models.SuppressionsRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+
+		repo = models.NewSuppressionsRepo()
+	})
+
+	Describe("Suppress", func() {
+		It("records the normalized email address with a reason", func() {
+			err := repo.Suppress(conn, " User@Example.com ", "550 5.1.1 mailbox unavailable")
+			Expect(err).NotTo(HaveOccurred())
+
+			suppression, err := repo.Find(conn, "user@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppression.Email).To(Equal("user@example.com"))
+			Expect(suppression.Reason).To(Equal("550 5.1.1 mailbox unavailable"))
+			Expect(suppression.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+		})
+
+		It("keeps the original suppression when the address is suppressed again", func() {
+			Expect(repo.Suppress(conn, "user@example.com", "first")).To(Succeed())
+			Expect(repo.Suppress(conn, "user@example.com", "second")).To(Succeed())
+
+			suppressions, err := repo.FindAll(conn)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppressions).To(HaveLen(1))
+			Expect(suppressions[0].Reason).To(Equal("first"))
+		})
+	})
+
+	Describe("IsSuppressed", func() {
+		It("reports whether the address is suppressed, ignoring case", func() {
+			Expect(repo.Suppress(conn, "user@example.com", "bounced")).To(Succeed())
+
+			suppressed, err := repo.IsSuppressed(conn, "USER@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppressed).To(BeTrue())
+
+			suppressed, err = repo.IsSuppressed(conn, "other@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppressed).To(BeFalse())
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns a not found error when the address is not suppressed", func() {
+			_, err := repo.Find(conn, "user@example.com")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("clears the suppression", func() {
+			Expect(repo.Suppress(conn, "user@example.com", "bounced")).To(Succeed())
+
+			err := repo.Delete(conn, "User@Example.com")
+			Expect(err).NotTo(HaveOccurred())
+
+			suppressed, err := repo.IsSuppressed(conn, "user@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppressed).To(BeFalse())
+		})
+
+		It("returns a not found error when the address is not suppressed", func() {
+			err := repo.Delete(conn, "user@example.com")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+})
@@ -14,16 +14,19 @@ const (
 )
 
 type Template struct {
-	Primary    int       `db:"primary"`
-	ID         string    `db:"id"`
-	Name       string    `db:"name"`
-	Subject    string    `db:"subject"`
-	Text       string    `db:"text"`
-	HTML       string    `db:"html"`
-	Metadata   string    `db:"metadata"`
-	CreatedAt  time.Time `db:"created_at"`
-	UpdatedAt  time.Time `db:"updated_at"`
-	Overridden bool      `db:"overridden"`
+	Primary       int       `db:"primary"`
+	ID            string    `db:"id"`
+	Name          string    `db:"name"`
+	Subject       string    `db:"subject"`
+	Text          string    `db:"text"`
+	HTML          string    `db:"html"`
+	Metadata      string    `db:"metadata"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+	Overridden    bool      `db:"overridden"`
+	Version       int       `db:"version"`
+	Localizations string    `db:"localizations"`
+	Strict        bool      `db:"strict"`
 }
 
 func (t *Template) PreInsert(s gorp.SqlExecutor) error {
@@ -40,5 +43,13 @@ func (t *Template) PreInsert(s gorp.SqlExecutor) error {
 	}
 	t.UpdatedAt = t.CreatedAt
 
+	if t.Version == 0 {
+		t.Version = 1
+	}
+
+	if t.Localizations == "" {
+		t.Localizations = "{}"
+	}
+
 	return nil
 }
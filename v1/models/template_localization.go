@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var localeFormat = regexp.MustCompile(`^[a-z]{2,8}(-[a-z0-9]{1,8})*$`)
+
+type TemplateLocalization struct {
+	Subject string `json:"subject,omitempty"`
+	Text    string `json:"text,omitempty"`
+	HTML    string `json:"html,omitempty"`
+}
+
+// NormalizeLocale lowercases a locale and uses "-" as its separator, so that
+// "pt_BR", "pt-br" and "PT-BR" all compare equal.
+func NormalizeLocale(locale string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(locale), "_", "-", -1))
+}
+
+func ValidLocale(locale string) bool {
+	return localeFormat.MatchString(NormalizeLocale(locale))
+}
+
+// Localize returns the template with the content of the localization that
+// best matches the given locale. The exact locale is preferred, then its
+// language, and the default content is kept for anything left unmatched.
+func (t Template) Localize(locale string) (Template, error) {
+	locale = NormalizeLocale(locale)
+	if locale == "" || t.Localizations == "" {
+		return t, nil
+	}
+
+	var localizations map[string]TemplateLocalization
+	err := json.Unmarshal([]byte(t.Localizations), &localizations)
+	if err != nil {
+		return t, err
+	}
+
+	normalized := map[string]TemplateLocalization{}
+	for key, localization := range localizations {
+		normalized[NormalizeLocale(key)] = localization
+	}
+
+	language := strings.SplitN(locale, "-", 2)[0]
+
+	localization, ok := normalized[locale]
+	if !ok {
+		localization, ok = normalized[language]
+	}
+	if !ok {
+		return t, nil
+	}
+
+	if localization.Subject != "" {
+		t.Subject = localization.Subject
+	}
+	if localization.Text != "" {
+		t.Text = localization.Text
+	}
+	if localization.HTML != "" {
+		t.HTML = localization.HTML
+	}
+
+	return t, nil
+}
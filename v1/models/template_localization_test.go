@@ -0,0 +1,88 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Template localization", func() {
+	var template models.Template
+
+	BeforeEach(func() {
+		template = models.Template{
+			Subject: "Hello {{.Subject}}",
+			Text:    "hello text",
+			HTML:    "<p>hello html</p>",
+			Localizations: `{
+				"fr": {"subject": "Bonjour {{.Subject}}", "text": "bonjour texte", "html": "<p>bonjour html</p>"},
+				"fr-ca": {"subject": "Allo {{.Subject}}"},
+				"pt_BR": {"text": "ola texto"}
+			}`,
+		}
+	})
+
+	Describe("Localize", func() {
+		It("uses the localization for the exact locale", func() {
+			localized, err := template.Localize("fr-CA")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(localized.Subject).To(Equal("Allo {{.Subject}}"))
+		})
+
+		It("keeps the default content for parts the localization leaves out", func() {
+			localized, err := template.Localize("fr_ca")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(localized.Text).To(Equal("hello text"))
+			Expect(localized.HTML).To(Equal("<p>hello html</p>"))
+		})
+
+		It("falls back to the language when the exact locale is missing", func() {
+			localized, err := template.Localize("fr-BE")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(localized.Subject).To(Equal("Bonjour {{.Subject}}"))
+			Expect(localized.Text).To(Equal("bonjour texte"))
+			Expect(localized.HTML).To(Equal("<p>bonjour html</p>"))
+		})
+
+		It("matches localizations regardless of case and separator", func() {
+			localized, err := template.Localize("PT-br")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(localized.Text).To(Equal("ola texto"))
+		})
+
+		It("falls back to the default content when nothing matches", func() {
+			localized, err := template.Localize("de-DE")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(localized).To(Equal(template))
+		})
+
+		It("returns the template untouched when no locale is given", func() {
+			localized, err := template.Localize("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(localized).To(Equal(template))
+		})
+
+		It("returns an error when the localizations are malformed", func() {
+			template.Localizations = "%%%"
+
+			_, err := template.Localize("fr")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ValidLocale", func() {
+		It("accepts language and region tags", func() {
+			Expect(models.ValidLocale("en")).To(BeTrue())
+			Expect(models.ValidLocale("en_US")).To(BeTrue())
+			Expect(models.ValidLocale("zh-Hant-TW")).To(BeTrue())
+		})
+
+		It("rejects anything else", func() {
+			Expect(models.ValidLocale("")).To(BeFalse())
+			Expect(models.ValidLocale("e")).To(BeFalse())
+			Expect(models.ValidLocale("en US")).To(BeFalse())
+			Expect(models.ValidLocale("en--us")).To(BeFalse())
+		})
+	})
+})
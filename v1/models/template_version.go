@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+type TemplateVersion struct {
+	Primary       int       `db:"primary"`
+	TemplateID    string    `db:"template_id"`
+	Version       int       `db:"version"`
+	Name          string    `db:"name"`
+	Subject       string    `db:"subject"`
+	Text          string    `db:"text"`
+	HTML          string    `db:"html"`
+	Metadata      string    `db:"metadata"`
+	Localizations string    `db:"localizations"`
+	Strict        bool      `db:"strict"`
+	Overridden    bool      `db:"overridden"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+func NewTemplateVersion(template Template) TemplateVersion {
+	return TemplateVersion{
+		TemplateID:    template.ID,
+		Version:       template.Version,
+		Name:          template.Name,
+		Subject:       template.Subject,
+		Text:          template.Text,
+		HTML:          template.HTML,
+		Metadata:      template.Metadata,
+		Localizations: template.Localizations,
+		Strict:        template.Strict,
+		Overridden:    template.Overridden,
+		CreatedAt:     template.UpdatedAt,
+	}
+}
+
+func (v *TemplateVersion) PreInsert(s gorp.SqlExecutor) error {
+	if (v.CreatedAt == time.Time{}) {
+		v.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	}
+
+	return nil
+}
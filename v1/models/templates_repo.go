@@ -25,8 +25,40 @@ func (repo TemplatesRepo) FindByID(conn ConnectionInterface, templateID string)
 }
 
 func (repo TemplatesRepo) Update(conn ConnectionInterface, templateID string, template Template) (Template, error) {
-	existingTemplate, err := repo.FindByID(conn, templateID)
+	template.Overridden = true
+
+	return repo.save(conn, templateID, template)
+}
+
+// Restore saves the content of a prior version as a new version, including
+// whether the template was overridden at that version.
+func (repo TemplatesRepo) Restore(conn ConnectionInterface, templateID string, templateVersion TemplateVersion) (Template, error) {
+	return repo.save(conn, templateID, Template{
+		Name:          templateVersion.Name,
+		Subject:       templateVersion.Subject,
+		Text:          templateVersion.Text,
+		HTML:          templateVersion.HTML,
+		Metadata:      templateVersion.Metadata,
+		Localizations: templateVersion.Localizations,
+		Strict:        templateVersion.Strict,
+		Overridden:    templateVersion.Overridden,
+	})
+}
+
+// save bumps the version of the template and records it in the history in a
+// single transaction. The update only applies to the version that was read so
+// that concurrent updates cannot both claim the same version.
+func (repo TemplatesRepo) save(conn ConnectionInterface, templateID string, template Template) (Template, error) {
+	transaction := conn.Transaction()
+
+	err := transaction.Begin()
 	if err != nil {
+		return Template{}, err
+	}
+
+	existingTemplate, err := repo.FindByID(transaction, templateID)
+	if err != nil {
+		transaction.Rollback()
 		return existingTemplate, err
 	}
 
@@ -34,13 +66,38 @@ func (repo TemplatesRepo) Update(conn ConnectionInterface, templateID string, te
 	template.ID = existingTemplate.ID
 	template.CreatedAt = existingTemplate.CreatedAt
 	template.UpdatedAt = time.Now().Truncate(1 * time.Second).UTC()
-	template.Overridden = true
+	template.Version = existingTemplate.Version + 1
 
-	_, err = conn.Update(&template)
+	result, err := transaction.Exec("UPDATE `templates` SET `name` = ?, `subject` = ?, `text` = ?, `html` = ?, `metadata` = ?, `updated_at` = ?, `overridden` = ?, `version` = ?, `localizations` = ?, `strict` = ? WHERE `id` = ? AND `version` = ?",
+		template.Name, template.Subject, template.Text, template.HTML, template.Metadata, template.UpdatedAt, template.Overridden, template.Version, template.Localizations, template.Strict,
+		template.ID, existingTemplate.Version)
 	if err != nil {
+		transaction.Rollback()
 		return Template{}, TemplateUpdateError{err}
 	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		transaction.Rollback()
+		return Template{}, TemplateUpdateError{err}
+	}
+
+	if rowsAffected != 1 {
+		transaction.Rollback()
+		return Template{}, TemplateUpdateError{fmt.Errorf("Template with ID %q was changed by another request, please try again", templateID)}
+	}
+
+	err = repo.recordVersion(transaction, template)
+	if err != nil {
+		transaction.Rollback()
+		return Template{}, TemplateUpdateError{err}
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return Template{}, TransactionCommitError{err}
+	}
+
 	return template, nil
 }
 
@@ -53,12 +110,33 @@ func (repo TemplatesRepo) ListIDsAndNames(conn ConnectionInterface) ([]Template,
 	return templates, nil
 }
 
+// Create inserts the template and records its first version in a single
+// transaction, so that a template never exists without its history.
 func (repo TemplatesRepo) Create(conn ConnectionInterface, template Template) (Template, error) {
-	err := conn.Insert(&template)
+	transaction := conn.Transaction()
+
+	err := transaction.Begin()
+	if err != nil {
+		return Template{}, err
+	}
+
+	err = transaction.Insert(&template)
+	if err != nil {
+		transaction.Rollback()
+		return Template{}, err
+	}
+
+	err = repo.recordVersion(transaction, template)
 	if err != nil {
+		transaction.Rollback()
 		return Template{}, err
 	}
 
+	err = transaction.Commit()
+	if err != nil {
+		return Template{}, TransactionCommitError{err}
+	}
+
 	return template, nil
 }
 
@@ -69,6 +147,44 @@ func (repo TemplatesRepo) Destroy(conn ConnectionInterface, templateID string) e
 	}
 
 	_, err = conn.Delete(&template)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec("DELETE FROM `template_versions` WHERE `template_id` = ?", templateID)
 
 	return err
 }
+
+func (repo TemplatesRepo) ListVersions(conn ConnectionInterface, templateID string) ([]TemplateVersion, error) {
+	_, err := repo.FindByID(conn, templateID)
+	if err != nil {
+		return []TemplateVersion{}, err
+	}
+
+	versions := []TemplateVersion{}
+	_, err = conn.Select(&versions, "SELECT * FROM `template_versions` WHERE `template_id` = ? ORDER BY `version` DESC", templateID)
+	if err != nil {
+		return []TemplateVersion{}, err
+	}
+
+	return versions, nil
+}
+
+func (repo TemplatesRepo) FindVersion(conn ConnectionInterface, templateID string, version int) (TemplateVersion, error) {
+	templateVersion := TemplateVersion{}
+	err := conn.SelectOne(&templateVersion, "SELECT * FROM `template_versions` WHERE `template_id` = ? AND `version` = ?", templateID, version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return templateVersion, NotFoundError{fmt.Errorf("Version %d of template with ID %q could not be found", version, templateID)}
+		}
+		return templateVersion, err
+	}
+
+	return templateVersion, nil
+}
+
+func (repo TemplatesRepo) recordVersion(conn ConnectionInterface, template Template) error {
+	version := NewTemplateVersion(template)
+	return conn.Insert(&version)
+}
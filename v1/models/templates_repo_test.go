@@ -86,6 +86,39 @@ var _ = Describe("TemplatesRepo", func() {
 			Expect(foundTemplate.HTML).To(Equal(newTemplate.HTML))
 			Expect(foundTemplate.CreatedAt).To(BeTemporally("~", time.Now().UTC(), 2*time.Second))
 			Expect(foundTemplate.UpdatedAt).To(BeTemporally("~", time.Now().UTC(), 2*time.Second))
+			Expect(foundTemplate.Version).To(Equal(1))
+		})
+
+		It("records the first version of the template", func() {
+			createdTemplate, err := repo.Create(conn, models.Template{
+				Name:    "A Nice Template",
+				Subject: "Kind Words",
+				Text:    "Some kind of compliment.",
+				HTML:    "<h1>Genuine Smile</h1>",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			version, err := repo.FindVersion(conn, createdTemplate.ID, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(version.TemplateID).To(Equal(createdTemplate.ID))
+			Expect(version.Name).To(Equal("A Nice Template"))
+			Expect(version.Subject).To(Equal("Kind Words"))
+			Expect(version.Text).To(Equal("Some kind of compliment."))
+			Expect(version.HTML).To(Equal("<h1>Genuine Smile</h1>"))
+		})
+
+		It("does not create the template when its version cannot be recorded", func() {
+			version := models.NewTemplateVersion(models.Template{ID: "orphan-template", Version: 1})
+			Expect(conn.Insert(&version)).To(Succeed())
+
+			_, err := repo.Create(conn, models.Template{
+				ID:   "orphan-template",
+				Name: "A Nice Template",
+			})
+			Expect(err).To(HaveOccurred())
+
+			_, err = repo.FindByID(conn, "orphan-template")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
 		})
 	})
 
@@ -121,6 +154,27 @@ var _ = Describe("TemplatesRepo", func() {
 				Expect(foundTemplate.UpdatedAt).ToNot(Equal(createdAt))
 				Expect(foundTemplate.UpdatedAt).To(BeTemporally(">", createdAt))
 				Expect(foundTemplate.Overridden).To(BeTrue())
+				Expect(foundTemplate.Version).To(Equal(2))
+			})
+
+			It("records an immutable version for every update", func() {
+				_, err := repo.Update(conn, template.ID, aNewTemplate)
+				Expect(err).ToNot(HaveOccurred())
+
+				aNewTemplate.Subject = "An even newer subject"
+				updatedTemplate, err := repo.Update(conn, template.ID, aNewTemplate)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedTemplate.Version).To(Equal(3))
+
+				version, err := repo.FindVersion(conn, template.ID, 2)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(version.Subject).To(Equal("Some new subject"))
+				Expect(version.Metadata).To(Equal("{\"cloudy\": true}"))
+
+				version, err = repo.FindVersion(conn, template.ID, 3)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(version.Subject).To(Equal("An even newer subject"))
+				Expect(version.Overridden).To(BeTrue())
 			})
 		})
 
@@ -132,6 +186,42 @@ var _ = Describe("TemplatesRepo", func() {
 		})
 	})
 
+	Describe("#Restore", func() {
+		It("saves the version as a new version, keeping its overridden flag", func() {
+			_, err := repo.Update(conn, template.ID, models.Template{Name: "second", Subject: "two"})
+			Expect(err).ToNot(HaveOccurred())
+
+			restoredTemplate, err := repo.Restore(conn, template.ID, models.TemplateVersion{
+				TemplateID: template.ID,
+				Version:    1,
+				Name:       "first",
+				Subject:    "one",
+				Overridden: false,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restoredTemplate.Version).To(Equal(3))
+
+			foundTemplate, err := repo.FindByID(conn, template.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foundTemplate.Name).To(Equal("first"))
+			Expect(foundTemplate.Subject).To(Equal("one"))
+			Expect(foundTemplate.Overridden).To(BeFalse())
+			Expect(foundTemplate.Version).To(Equal(3))
+
+			version, err := repo.FindVersion(conn, template.ID, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(version.Subject).To(Equal("one"))
+			Expect(version.Overridden).To(BeFalse())
+		})
+
+		Context("when the template does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := repo.Restore(conn, "missing-template", models.TemplateVersion{Version: 1})
+				Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("Template with ID \"missing-template\" could not be found")}))
+			})
+		})
+	})
+
 	Describe("#ListIDsAndNames", func() {
 		Context("there are templates in the database", func() {
 			It("returns a list of templates - ID and Name only", func() {
@@ -166,6 +256,40 @@ var _ = Describe("TemplatesRepo", func() {
 		})
 	})
 
+	Describe("#ListVersions", func() {
+		It("returns the versions of the template, newest first", func() {
+			_, err := repo.Update(conn, template.ID, models.Template{Name: "second", Subject: "two"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = repo.Update(conn, template.ID, models.Template{Name: "third", Subject: "three"})
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, err := repo.ListVersions(conn, template.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(HaveLen(2))
+			Expect(versions[0].Version).To(Equal(3))
+			Expect(versions[0].Subject).To(Equal("three"))
+			Expect(versions[1].Version).To(Equal(2))
+			Expect(versions[1].Subject).To(Equal("two"))
+		})
+
+		Context("when the template does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := repo.ListVersions(conn, "missing-template")
+				Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("Template with ID \"missing-template\" could not be found")}))
+			})
+		})
+	})
+
+	Describe("#FindVersion", func() {
+		Context("when the version does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := repo.FindVersion(conn, template.ID, 42)
+				Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("Version 42 of template with ID \"raptor_template\" could not be found")}))
+			})
+		})
+	})
+
 	Describe("#Destroy", func() {
 		Context("the template exists in the database", func() {
 			It("deletes the template by templateID", func() {
@@ -180,6 +304,17 @@ var _ = Describe("TemplatesRepo", func() {
 				_, err = repo.FindByID(conn, template.ID)
 				Expect(err).To(MatchError(models.NotFoundError{Err: fmt.Errorf("Template with ID %q could not be found", template.ID)}))
 			})
+
+			It("deletes the versions of the template", func() {
+				_, err := repo.Update(conn, template.ID, models.Template{Name: "second"})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = repo.Destroy(conn, template.ID)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = repo.FindVersion(conn, template.ID, 2)
+				Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+			})
 		})
 
 		Context("the template does not exist in the database", func() {
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+const StatusBounced = "bounced"
+
+type bouncedMessagesRepo interface {
+	FindByID(models.ConnectionInterface, string) (models.Message, error)
+	Update(models.ConnectionInterface, models.Message) (models.Message, error)
+}
+
+type addressSuppressor interface {
+	Suppress(connection models.ConnectionInterface, email, reason string) error
+}
+
+type BounceRecorder struct {
+	messagesRepo      bouncedMessagesRepo
+	messageEventsRepo messageEventsCreator
+	suppressionsRepo  addressSuppressor
+}
+
+func NewBounceRecorder(messagesRepo bouncedMessagesRepo, messageEventsRepo messageEventsCreator, suppressionsRepo addressSuppressor) BounceRecorder {
+	return BounceRecorder{
+		messagesRepo:      messagesRepo,
+		messageEventsRepo: messageEventsRepo,
+		suppressionsRepo:  suppressionsRepo,
+	}
+}
+
+// Record marks the message a DSN refers to as bounced when any recipient
+// failed, and suppresses recipients whose failure is permanent. Delayed and
+// successful delivery reports are ignored.
+func (r BounceRecorder) Record(conn ConnectionInterface, dsn mail.DSN) error {
+	if dsn.MessageID == "" {
+		return UncorrelatedBounceError{}
+	}
+
+	message, err := r.messagesRepo.FindByID(conn, dsn.MessageID)
+	if err != nil {
+		return err
+	}
+
+	var bounced bool
+	for _, recipient := range dsn.Recipients {
+		if !recipient.IsFailure() {
+			continue
+		}
+		bounced = true
+
+		_, err = r.messageEventsRepo.Create(conn, models.MessageEvent{
+			MessageID: message.ID,
+			Event:     models.MessageEventBounced,
+			Detail:    recipient.Reason(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if !recipient.IsPermanentFailure() {
+			continue
+		}
+
+		email := recipient.Recipient
+		if email == "" {
+			email = message.Email
+		}
+
+		err = r.suppressionsRepo.Suppress(conn, email, recipient.Reason())
+		if err != nil {
+			return err
+		}
+	}
+
+	if !bounced {
+		return nil
+	}
+
+	message.Status = StatusBounced
+	_, err = r.messagesRepo.Update(conn, message)
+
+	return err
+}
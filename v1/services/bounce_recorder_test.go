@@ -0,0 +1,122 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BounceRecorder", func() {
+	var (
+		recorder          services.BounceRecorder
+		messagesRepo      *mocks.MessagesRepo
+		messageEventsRepo *mocks.MessageEventsRepo
+		suppressionsRepo  *mocks.SuppressionsRepo
+		conn              *mocks.Connection
+		dsn               mail.DSN
+	)
+
+	BeforeEach(func() {
+		messagesRepo = mocks.NewMessagesRepo()
+		messagesRepo.FindByIDCall.Returns.Message = models.Message{
+			ID:     "some-message-id",
+			Status: "delivered",
+			Email:  "gone@example.com",
+		}
+		messageEventsRepo = mocks.NewMessageEventsRepo()
+		suppressionsRepo = mocks.NewSuppressionsRepo()
+		conn = mocks.NewConnection()
+
+		dsn = mail.DSN{
+			MessageID: "some-message-id",
+			Recipients: []mail.DSNRecipient{
+				{
+					Recipient:      "gone@example.com",
+					Action:         "failed",
+					Status:         "5.1.1",
+					DiagnosticCode: "550 5.1.1 mailbox unavailable",
+				},
+			},
+		}
+
+		recorder = services.NewBounceRecorder(messagesRepo, messageEventsRepo, suppressionsRepo)
+	})
+
+	It("marks the message bounced, records the event and suppresses the address", func() {
+		err := recorder.Record(conn, dsn)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(messagesRepo.FindByIDCall.Receives.Connection).To(Equal(conn))
+		Expect(messagesRepo.FindByIDCall.Receives.MessageID).To(Equal("some-message-id"))
+
+		Expect(messageEventsRepo.CreateCall.Receives.Events).To(Equal([]models.MessageEvent{
+			{MessageID: "some-message-id", Event: models.MessageEventBounced, Detail: "550 5.1.1 mailbox unavailable"},
+		}))
+
+		Expect(suppressionsRepo.SuppressCall.Receives.Connection).To(Equal(conn))
+		Expect(suppressionsRepo.SuppressCall.Receives.Email).To(Equal("gone@example.com"))
+		Expect(suppressionsRepo.SuppressCall.Receives.Reason).To(Equal("550 5.1.1 mailbox unavailable"))
+
+		Expect(messagesRepo.UpdateCall.Receives.Messages).To(Equal([]models.Message{
+			{ID: "some-message-id", Status: services.StatusBounced, Email: "gone@example.com"},
+		}))
+	})
+
+	It("marks the message bounced without suppressing the address when the failure is not permanent", func() {
+		dsn.Recipients[0].Status = "4.4.7"
+
+		err := recorder.Record(conn, dsn)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(suppressionsRepo.SuppressCall.WasCalled).To(BeFalse())
+		Expect(messageEventsRepo.CreateCall.Receives.Events).To(HaveLen(1))
+		Expect(messagesRepo.UpdateCall.Receives.Messages[0].Status).To(Equal(services.StatusBounced))
+	})
+
+	It("suppresses the message's address when the recipient is not reported", func() {
+		dsn.Recipients[0].Recipient = ""
+
+		err := recorder.Record(conn, dsn)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(suppressionsRepo.SuppressCall.Receives.Email).To(Equal("gone@example.com"))
+	})
+
+	It("ignores reports without failed recipients", func() {
+		dsn.Recipients[0].Action = "delayed"
+
+		err := recorder.Record(conn, dsn)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(messageEventsRepo.CreateCall.Receives.Events).To(BeEmpty())
+		Expect(suppressionsRepo.SuppressCall.WasCalled).To(BeFalse())
+		Expect(messagesRepo.UpdateCall.Receives.Messages).To(BeEmpty())
+	})
+
+	It("returns an uncorrelated bounce error when the message ID is missing", func() {
+		dsn.MessageID = ""
+
+		err := recorder.Record(conn, dsn)
+		Expect(err).To(MatchError(services.UncorrelatedBounceError{}))
+	})
+
+	It("returns errors from finding the message", func() {
+		messagesRepo.FindByIDCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		err := recorder.Record(conn, dsn)
+		Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+	})
+
+	It("returns errors from suppressing the address", func() {
+		suppressionsRepo.SuppressCall.Returns.Error = errors.New("BOOM!")
+
+		err := recorder.Record(conn, dsn)
+		Expect(err).To(MatchError(errors.New("BOOM!")))
+		Expect(messagesRepo.UpdateCall.Receives.Messages).To(BeEmpty())
+	})
+})
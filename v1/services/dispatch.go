@@ -10,6 +10,7 @@ type Dispatch struct {
 	UAAHost    string
 	TemplateID string
 	CampaignID string
+	SendAt     time.Time
 
 	VCAPRequest DispatchVCAPRequest
 	Message     DispatchMessage
@@ -34,6 +35,7 @@ type DispatchMessage struct {
 	ReplyTo string
 	Subject string
 	Text    string
+	Locale  string
 	HTML    HTML
 }
 
@@ -45,4 +47,5 @@ type DispatchClient struct {
 type DispatchKind struct {
 	ID          string
 	Description string
+	Critical    bool
 }
@@ -41,11 +41,13 @@ var _ = Describe("EmailStrategy", func() {
 						Description: "description of a kind",
 					},
 					TemplateID: "some-template-id",
+					SendAt:     time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
 					Message: services.DispatchMessage{
 						ReplyTo: "reply-to@example.com",
 						Subject: "this is the subject",
 						To:      "dr@strangelove.com",
 						Text:    "email text",
+						Locale:  "fr-CA",
 						HTML: services.HTML{
 							BodyContent:    "some html body content",
 							BodyAttributes: "some html body attributes",
@@ -71,6 +73,8 @@ var _ = Describe("EmailStrategy", func() {
 					SourceDescription: "description of a client",
 					Text:              "email text",
 					TemplateID:        "some-template-id",
+					SendAt:            time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
+					Locale:            "fr-CA",
 					HTML: services.HTML{
 						BodyContent:    "some html body content",
 						BodyAttributes: "some html body attributes",
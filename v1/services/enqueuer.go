@@ -10,12 +10,20 @@ import (
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 )
 
-const StatusQueued = "queued"
+const (
+	StatusQueued    = "queued"
+	StatusScheduled = "scheduled"
+	StatusCancelled = "cancelled"
+
+	CampaignJobType   = "campaign"
+	CampaignChunkSize = 200
+)
 
 type Options struct {
 	ReplyTo           string
 	Subject           string
 	KindDescription   string
+	Critical          bool
 	SourceDescription string
 	Text              string
 	HTML              HTML
@@ -24,6 +32,8 @@ type Options struct {
 	Role              string
 	Endorsement       string
 	TemplateID        string
+	SendAt            time.Time
+	Locale            string
 }
 
 type Delivery struct {
@@ -40,12 +50,35 @@ type Delivery struct {
 	RequestReceived time.Time
 }
 
+type Campaign struct {
+	JobType         string
+	Options         Options
+	Recipients      []CampaignRecipient
+	Space           cf.CloudControllerSpace
+	Organization    cf.CloudControllerOrganization
+	ClientID        string
+	UAAHost         string
+	Scope           string
+	VCAPRequestID   string
+	RequestReceived time.Time
+}
+
+type CampaignRecipient struct {
+	UserGUID  string
+	MessageID string
+}
+
 type messagesRepoUpserter interface {
 	Upsert(models.ConnectionInterface, models.Message) (models.Message, error)
 }
 
+type messageEventsCreator interface {
+	Create(models.ConnectionInterface, models.MessageEvent) (models.MessageEvent, error)
+}
+
 type queueInterface interface {
 	Enqueue(job *gobble.Job, transaction gobble.ConnectionInterface) (*gobble.Job, error)
+	EnqueueAll(jobs []*gobble.Job, transaction gobble.ConnectionInterface) ([]*gobble.Job, error)
 }
 
 type gobbleInitializer interface {
@@ -55,13 +88,15 @@ type gobbleInitializer interface {
 type Enqueuer struct {
 	queue             queueInterface
 	messagesRepo      messagesRepoUpserter
+	messageEventsRepo messageEventsCreator
 	gobbleInitializer gobbleInitializer
 }
 
-func NewEnqueuer(queue queueInterface, messagesRepo messagesRepoUpserter, gobbleInitializer gobbleInitializer) Enqueuer {
+func NewEnqueuer(queue queueInterface, messagesRepo messagesRepoUpserter, messageEventsRepo messageEventsCreator, gobbleInitializer gobbleInitializer) Enqueuer {
 	return Enqueuer{
 		queue:             queue,
 		messagesRepo:      messagesRepo,
+		messageEventsRepo: messageEventsRepo,
 		gobbleInitializer: gobbleInitializer,
 	}
 }
@@ -88,9 +123,13 @@ func (enqueuer Enqueuer) Enqueue(
 	}
 
 	for _, user := range users {
-		message, err := enqueuer.messagesRepo.Upsert(transaction, models.Message{
-			Status: StatusQueued,
-		})
+		message, err := enqueuer.createQueuedMessage(transaction, models.Message{
+			ClientID:      clientID,
+			KindID:        options.KindID,
+			UserGUID:      user.GUID,
+			Email:         user.Email,
+			VCAPRequestID: vcapRequestID,
+		}, scheduledAt(options, reqReceived))
 		if err != nil {
 			transaction.Rollback()
 			return []Response{}, err
@@ -109,6 +148,9 @@ func (enqueuer Enqueuer) Enqueue(
 			VCAPRequestID:   vcapRequestID,
 			RequestReceived: reqReceived,
 		})
+		job.ActiveAt = scheduledAt(options, reqReceived)
+		job.ClientID = clientID
+		job.Priority = priority(options)
 
 		_, err = enqueuer.queue.Enqueue(job, transaction)
 		if err != nil {
@@ -116,8 +158,6 @@ func (enqueuer Enqueuer) Enqueue(
 			return []Response{}, err
 		}
 
-		//TODO: don't append to responses if job returned is nil?
-
 		recipient := user.Email
 		if recipient == "" {
 			recipient = user.GUID
@@ -137,3 +177,147 @@ func (enqueuer Enqueuer) Enqueue(
 
 	return responses, nil
 }
+
+// EnqueueCampaign splits the users into chunked campaign jobs, but creates
+// every message and job in one transaction so that a failed campaign can be
+// retried without sending anyone a duplicate.
+func (enqueuer Enqueuer) EnqueueCampaign(
+	conn ConnectionInterface,
+	users []User,
+	options Options,
+	space cf.CloudControllerSpace,
+	organization cf.CloudControllerOrganization,
+	clientID,
+	uaaHost,
+	scope,
+	vcapRequestID string,
+	reqReceived time.Time) ([]Response, error) {
+
+	responses := []Response{}
+	var jobs []*gobble.Job
+
+	transaction := conn.Transaction()
+	enqueuer.gobbleInitializer.InitializeDBMap(transaction.GetDbMap())
+
+	if err := transaction.Begin(); err != nil {
+		return []Response{}, err
+	}
+
+	for start := 0; start < len(users); start += CampaignChunkSize {
+		end := start + CampaignChunkSize
+		if end > len(users) {
+			end = len(users)
+		}
+
+		campaign := Campaign{
+			JobType:         CampaignJobType,
+			Options:         options,
+			Space:           space,
+			Organization:    organization,
+			ClientID:        clientID,
+			UAAHost:         uaaHost,
+			Scope:           scope,
+			VCAPRequestID:   vcapRequestID,
+			RequestReceived: reqReceived,
+		}
+
+		job, chunkResponses, err := enqueuer.campaignChunkJob(transaction, users[start:end], campaign)
+		if err != nil {
+			transaction.Rollback()
+			return []Response{}, err
+		}
+
+		jobs = append(jobs, job)
+		responses = append(responses, chunkResponses...)
+	}
+
+	_, err := enqueuer.queue.EnqueueAll(jobs, transaction)
+	if err != nil {
+		transaction.Rollback()
+		return []Response{}, err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return []Response{}, err
+	}
+
+	return responses, nil
+}
+
+func (enqueuer Enqueuer) campaignChunkJob(transaction ConnectionInterface, users []User, campaign Campaign) (*gobble.Job, []Response, error) {
+	var responses []Response
+
+	for _, user := range users {
+		message, err := enqueuer.createQueuedMessage(transaction, models.Message{
+			ClientID:      campaign.ClientID,
+			KindID:        campaign.Options.KindID,
+			UserGUID:      user.GUID,
+			Email:         user.Email,
+			VCAPRequestID: campaign.VCAPRequestID,
+		}, scheduledAt(campaign.Options, campaign.RequestReceived))
+		if err != nil {
+			return nil, []Response{}, err
+		}
+
+		campaign.Recipients = append(campaign.Recipients, CampaignRecipient{
+			UserGUID:  user.GUID,
+			MessageID: message.ID,
+		})
+
+		responses = append(responses, Response{
+			Status:         message.Status,
+			NotificationID: message.ID,
+			Recipient:      user.GUID,
+			VCAPRequestID:  campaign.VCAPRequestID,
+		})
+	}
+
+	job := gobble.NewJob(campaign)
+	job.ActiveAt = scheduledAt(campaign.Options, campaign.RequestReceived)
+	job.ClientID = campaign.ClientID
+	job.Priority = priority(campaign.Options)
+
+	return job, responses, nil
+}
+
+func (enqueuer Enqueuer) createQueuedMessage(transaction ConnectionInterface, message models.Message, sendAt time.Time) (models.Message, error) {
+	event := models.MessageEvent{Event: models.MessageEventQueued}
+	message.Status = StatusQueued
+
+	if !sendAt.IsZero() {
+		event = models.MessageEvent{
+			Event:  models.MessageEventScheduled,
+			Detail: sendAt.UTC().Format(time.RFC3339),
+		}
+		message.Status = StatusScheduled
+	}
+
+	message, err := enqueuer.messagesRepo.Upsert(transaction, message)
+	if err != nil {
+		return models.Message{}, err
+	}
+
+	event.MessageID = message.ID
+	_, err = enqueuer.messageEventsRepo.Create(transaction, event)
+	if err != nil {
+		return models.Message{}, err
+	}
+
+	return message, nil
+}
+
+func scheduledAt(options Options, reqReceived time.Time) time.Time {
+	if options.SendAt.After(reqReceived) {
+		return options.SendAt
+	}
+
+	return time.Time{}
+}
+
+func priority(options Options) int {
+	if options.Critical {
+		return gobble.PriorityCritical
+	}
+
+	return gobble.PriorityDefault
+}
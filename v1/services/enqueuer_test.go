@@ -2,9 +2,11 @@ package services_test
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
 	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
@@ -24,6 +26,7 @@ var _ = Describe("Enqueuer", func() {
 		org               cf.CloudControllerOrganization
 		reqReceived       time.Time
 		messagesRepo      *mocks.MessagesRepo
+		messageEventsRepo *mocks.MessageEventsRepo
 	)
 
 	BeforeEach(func() {
@@ -61,7 +64,9 @@ var _ = Describe("Enqueuer", func() {
 			},
 		}
 
-		enqueuer = services.NewEnqueuer(queue, messagesRepo, gobbleInitializer)
+		messageEventsRepo = mocks.NewMessageEventsRepo()
+
+		enqueuer = services.NewEnqueuer(queue, messagesRepo, messageEventsRepo, gobbleInitializer)
 	})
 
 	Describe("Enqueue", func() {
@@ -99,6 +104,67 @@ var _ = Describe("Enqueuer", func() {
 			}))
 		})
 
+		It("records a queued event for each message in the same transaction", func() {
+			users := []services.User{{GUID: "user-1"}, {GUID: "user-2"}}
+			_, err := enqueuer.Enqueue(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(messageEventsRepo.CreateCall.Receives.Connection).To(Equal(transaction))
+			Expect(messageEventsRepo.CreateCall.Receives.Events).To(Equal([]models.MessageEvent{
+				{MessageID: "first-random-guid", Event: models.MessageEventQueued},
+				{MessageID: "second-random-guid", Event: models.MessageEventQueued},
+			}))
+		})
+
+		It("rolls back the transaction when the queued event cannot be recorded", func() {
+			messageEventsRepo.CreateCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := enqueuer.Enqueue(conn, []services.User{{GUID: "user-1"}}, services.Options{}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		})
+
+		It("schedules the jobs and messages when the send time is in the future", func() {
+			sendAt := reqReceived.Add(2 * time.Hour)
+
+			_, err := enqueuer.Enqueue(conn, []services.User{{GUID: "user-1"}}, services.Options{KindID: "the-kind", SendAt: sendAt}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.EnqueueCall.Receives.Jobs).To(HaveLen(1))
+			Expect(queue.EnqueueCall.Receives.Jobs[0].ActiveAt).To(Equal(sendAt))
+
+			Expect(messagesRepo.UpsertCall.Receives.Messages).To(Equal([]models.Message{
+				{Status: services.StatusScheduled, ClientID: "the-client", KindID: "the-kind", UserGUID: "user-1", VCAPRequestID: "some-request-id"},
+			}))
+			Expect(messageEventsRepo.CreateCall.Receives.Events).To(Equal([]models.MessageEvent{
+				{MessageID: "first-random-guid", Event: models.MessageEventScheduled, Detail: sendAt.UTC().Format(time.RFC3339)},
+			}))
+		})
+
+		It("queues the jobs immediately when the send time has already passed", func() {
+			_, err := enqueuer.Enqueue(conn, []services.User{{GUID: "user-1"}}, services.Options{SendAt: reqReceived.Add(-time.Hour)}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.EnqueueCall.Receives.Jobs[0].ActiveAt.IsZero()).To(BeTrue())
+			Expect(messagesRepo.UpsertCall.Receives.Messages[0].Status).To(Equal(services.StatusQueued))
+		})
+
+		It("tags the jobs with the client and a default priority", func() {
+			_, err := enqueuer.Enqueue(conn, []services.User{{GUID: "user-1"}}, services.Options{}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.EnqueueCall.Receives.Jobs[0].ClientID).To(Equal("the-client"))
+			Expect(queue.EnqueueCall.Receives.Jobs[0].Priority).To(Equal(gobble.PriorityDefault))
+		})
+
+		It("gives jobs for critical kinds the critical priority", func() {
+			_, err := enqueuer.Enqueue(conn, []services.User{{GUID: "user-1"}}, services.Options{Critical: true}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.EnqueueCall.Receives.Jobs[0].Priority).To(Equal(gobble.PriorityCritical))
+		})
+
 		It("enqueues jobs with the deliveries", func() {
 			users := []services.User{
 				{GUID: "user-1"},
@@ -171,17 +237,17 @@ var _ = Describe("Enqueuer", func() {
 			}))
 		})
 
-		It("upserts a StatusQueued for each of the jobs", func() {
-			users := []services.User{{GUID: "user-1"}, {GUID: "user-2"}, {GUID: "user-3"}, {GUID: "user-4"}}
-			enqueuer.Enqueue(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+		It("upserts a StatusQueued message with its metadata for each of the jobs", func() {
+			users := []services.User{{GUID: "user-1"}, {GUID: "user-2"}, {GUID: "user-3"}, {Email: "user-4@example.com"}}
+			enqueuer.Enqueue(conn, users, services.Options{KindID: "the-kind"}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
 
 			messages := messagesRepo.UpsertCall.Receives.Messages
 			Expect(messages).To(HaveLen(4))
 			Expect(messages).To(Equal([]models.Message{
-				{Status: services.StatusQueued},
-				{Status: services.StatusQueued},
-				{Status: services.StatusQueued},
-				{Status: services.StatusQueued},
+				{Status: services.StatusQueued, ClientID: "the-client", KindID: "the-kind", UserGUID: "user-1", VCAPRequestID: "some-request-id"},
+				{Status: services.StatusQueued, ClientID: "the-client", KindID: "the-kind", UserGUID: "user-2", VCAPRequestID: "some-request-id"},
+				{Status: services.StatusQueued, ClientID: "the-client", KindID: "the-kind", UserGUID: "user-3", VCAPRequestID: "some-request-id"},
+				{Status: services.StatusQueued, ClientID: "the-client", KindID: "the-kind", Email: "user-4@example.com", VCAPRequestID: "some-request-id"},
 			}))
 		})
 
@@ -237,6 +303,15 @@ var _ = Describe("Enqueuer", func() {
 				Expect(err).To(HaveOccurred())
 			})
 
+			It("returns the queue full error without any responses when the queue is full", func() {
+				queue.EnqueueCall.Returns.Error = gobble.QueueFullError{Length: 3, MaxLength: 3}
+				responses, err := enqueuer.Enqueue(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+
+				Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+				Expect(responses).To(BeEmpty())
+				Expect(err).To(MatchError(gobble.QueueFullError{Length: 3, MaxLength: 3}))
+			})
+
 			It("uses the same transaction for the queue as it did for the messages repo", func() {
 				enqueuer.Enqueue(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
 
@@ -265,4 +340,163 @@ var _ = Describe("Enqueuer", func() {
 			})
 		})
 	})
+
+	Describe("EnqueueCampaign", func() {
+		var users []services.User
+
+		BeforeEach(func() {
+			users = []services.User{{GUID: "user-1"}, {GUID: "user-2"}, {GUID: "user-3"}}
+		})
+
+		It("returns a queued response for each user", func() {
+			responses, err := enqueuer.EnqueueCampaign(conn, users, services.Options{KindID: "the-kind"}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responses).To(Equal([]services.Response{
+				{
+					Status:         "queued",
+					Recipient:      "user-1",
+					NotificationID: "first-random-guid",
+					VCAPRequestID:  "some-request-id",
+				},
+				{
+					Status:         "queued",
+					Recipient:      "user-2",
+					NotificationID: "second-random-guid",
+					VCAPRequestID:  "some-request-id",
+				},
+				{
+					Status:         "queued",
+					Recipient:      "user-3",
+					NotificationID: "third-random-guid",
+					VCAPRequestID:  "some-request-id",
+				},
+			}))
+		})
+
+		It("enqueues a single campaign job carrying every recipient", func() {
+			_, err := enqueuer.EnqueueCampaign(conn, users, services.Options{KindID: "the-kind"}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.EnqueueAllCall.Receives.Jobs).To(HaveLen(1))
+			Expect(queue.EnqueueAllCall.Receives.Connection).To(Equal(transaction))
+
+			var campaign services.Campaign
+			Expect(queue.EnqueueAllCall.Receives.Jobs[0].Unmarshal(&campaign)).To(Succeed())
+			Expect(campaign).To(Equal(services.Campaign{
+				JobType: services.CampaignJobType,
+				Options: services.Options{KindID: "the-kind"},
+				Recipients: []services.CampaignRecipient{
+					{UserGUID: "user-1", MessageID: "first-random-guid"},
+					{UserGUID: "user-2", MessageID: "second-random-guid"},
+					{UserGUID: "user-3", MessageID: "third-random-guid"},
+				},
+				Space:           space,
+				Organization:    org,
+				ClientID:        "the-client",
+				UAAHost:         "my-uaa-host",
+				Scope:           "my.scope",
+				VCAPRequestID:   "some-request-id",
+				RequestReceived: reqReceived,
+			}))
+
+			Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+		})
+
+		It("upserts a StatusQueued message with its metadata for each recipient", func() {
+			_, err := enqueuer.EnqueueCampaign(conn, users, services.Options{KindID: "the-kind"}, space, org, "the-client", "my-uaa-host", "", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(messagesRepo.UpsertCall.Receives.Messages).To(Equal([]models.Message{
+				{Status: services.StatusQueued, ClientID: "the-client", KindID: "the-kind", UserGUID: "user-1", VCAPRequestID: "some-request-id"},
+				{Status: services.StatusQueued, ClientID: "the-client", KindID: "the-kind", UserGUID: "user-2", VCAPRequestID: "some-request-id"},
+				{Status: services.StatusQueued, ClientID: "the-client", KindID: "the-kind", UserGUID: "user-3", VCAPRequestID: "some-request-id"},
+			}))
+		})
+
+		It("records a queued event for each recipient", func() {
+			_, err := enqueuer.EnqueueCampaign(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(messageEventsRepo.CreateCall.Receives.Events).To(Equal([]models.MessageEvent{
+				{MessageID: "first-random-guid", Event: models.MessageEventQueued},
+				{MessageID: "second-random-guid", Event: models.MessageEventQueued},
+				{MessageID: "third-random-guid", Event: models.MessageEventQueued},
+			}))
+		})
+
+		It("schedules the campaign job and its messages when the send time is in the future", func() {
+			sendAt := reqReceived.Add(2 * time.Hour)
+
+			_, err := enqueuer.EnqueueCampaign(conn, users, services.Options{SendAt: sendAt}, space, org, "the-client", "my-uaa-host", "", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.EnqueueAllCall.Receives.Jobs).To(HaveLen(1))
+			Expect(queue.EnqueueAllCall.Receives.Jobs[0].ActiveAt).To(Equal(sendAt))
+
+			for _, message := range messagesRepo.UpsertCall.Receives.Messages {
+				Expect(message.Status).To(Equal(services.StatusScheduled))
+			}
+			for _, event := range messageEventsRepo.CreateCall.Receives.Events {
+				Expect(event.Event).To(Equal(models.MessageEventScheduled))
+			}
+		})
+
+		It("tags the campaign job with the client and priority", func() {
+			_, err := enqueuer.EnqueueCampaign(conn, users, services.Options{Critical: true}, space, org, "the-client", "my-uaa-host", "", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.EnqueueAllCall.Receives.Jobs).To(HaveLen(1))
+			Expect(queue.EnqueueAllCall.Receives.Jobs[0].ClientID).To(Equal("the-client"))
+			Expect(queue.EnqueueAllCall.Receives.Jobs[0].Priority).To(Equal(gobble.PriorityCritical))
+		})
+
+		It("splits large recipient lists into chunked campaign jobs", func() {
+			users = []services.User{}
+			messagesRepo.UpsertCall.Returns.Messages = []models.Message{}
+			for i := 0; i < services.CampaignChunkSize+1; i++ {
+				users = append(users, services.User{GUID: fmt.Sprintf("user-%d", i)})
+				messagesRepo.UpsertCall.Returns.Messages = append(messagesRepo.UpsertCall.Returns.Messages, models.Message{
+					ID:     fmt.Sprintf("message-%d", i),
+					Status: services.StatusQueued,
+				})
+			}
+
+			responses, err := enqueuer.EnqueueCampaign(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "", "some-request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responses).To(HaveLen(services.CampaignChunkSize + 1))
+
+			Expect(queue.EnqueueAllCall.Receives.Jobs).To(HaveLen(2))
+			Expect(queue.EnqueueAllCall.Receives.Connection).To(Equal(transaction))
+			Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+
+			var first, second services.Campaign
+			Expect(queue.EnqueueAllCall.Receives.Jobs[0].Unmarshal(&first)).To(Succeed())
+			Expect(queue.EnqueueAllCall.Receives.Jobs[1].Unmarshal(&second)).To(Succeed())
+
+			Expect(first.Recipients).To(HaveLen(services.CampaignChunkSize))
+			Expect(second.Recipients).To(Equal([]services.CampaignRecipient{
+				{UserGUID: fmt.Sprintf("user-%d", services.CampaignChunkSize), MessageID: fmt.Sprintf("message-%d", services.CampaignChunkSize)},
+			}))
+		})
+
+		It("rolls back the campaign when a message cannot be created", func() {
+			messagesRepo.UpsertCall.Returns.Error = errors.New("BOOM!")
+
+			responses, err := enqueuer.EnqueueCampaign(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "", "some-request-id", reqReceived)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+			Expect(responses).To(BeEmpty())
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		})
+
+		It("rolls back the campaign when its jobs cannot be enqueued", func() {
+			queue.EnqueueAllCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := enqueuer.EnqueueCampaign(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "", "some-request-id", reqReceived)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		})
+	})
 })
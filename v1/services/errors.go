@@ -1,7 +1,10 @@
 package services
 
 import (
+	"fmt"
+	"math"
 	"net/http"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
 )
@@ -64,6 +67,75 @@ func (e CCDownError) Error() string {
 	return e.Err.Error()
 }
 
+type MessageNotCancellableError struct {
+	MessageID string
+	Status    string
+}
+
+func (e MessageNotCancellableError) Error() string {
+	return fmt.Sprintf("Message %q cannot be cancelled because it is %s", e.MessageID, e.Status)
+}
+
+type IdempotencyKeyConflictError struct {
+	Key string
+}
+
+func (e IdempotencyKeyConflictError) Error() string {
+	return fmt.Sprintf("Idempotency-Key %q has already been used for a different request", e.Key)
+}
+
+type IdempotencyKeyInProgressError struct {
+	Key string
+}
+
+func (e IdempotencyKeyInProgressError) Error() string {
+	return fmt.Sprintf("A request with Idempotency-Key %q is still being processed", e.Key)
+}
+
+type InvalidUnsubscribeIDError struct{}
+
+func (e InvalidUnsubscribeIDError) Error() string {
+	return "The unsubscribe link is not valid"
+}
+
+type UncorrelatedBounceError struct{}
+
+func (e UncorrelatedBounceError) Error() string {
+	return "The delivery status notification does not include the X-CF-Notification-ID of the original message"
+}
+
+type RateLimitExceededError struct {
+	ClientID   string
+	Limit      string
+	RetryAfter time.Duration
+}
+
+func (e RateLimitExceededError) Error() string {
+	return fmt.Sprintf("Client %q has exceeded its rate limit of %s", e.ClientID, e.Limit)
+}
+
+// RetryAfterSeconds rounds the wait up to whole seconds for the Retry-After header.
+func (e RateLimitExceededError) RetryAfterSeconds() int {
+	seconds := int(math.Ceil(e.RetryAfter.Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+
+	return seconds
+}
+
+// RecipientsExceedRateLimitError is returned for a send that has more
+// recipients than its client may notify in an hour, which waiting cannot fix.
+type RecipientsExceedRateLimitError struct {
+	ClientID          string
+	Recipients        int
+	RecipientsPerHour int
+}
+
+func (e RecipientsExceedRateLimitError) Error() string {
+	return fmt.Sprintf("Client %q cannot notify %d recipients at once, it is limited to %d recipients_per_hour", e.ClientID, e.Recipients, e.RecipientsPerHour)
+}
+
 type DefaultScopeError struct{}
 
 func (d DefaultScopeError) Error() string {
@@ -1,6 +1,10 @@
 package services
 
-import "github.com/cloudfoundry-incubator/notifications/cf"
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+)
 
 const EveryoneEndorsement = "This message was sent to everyone."
 
@@ -8,6 +12,20 @@ type allUserGUIDsGetter interface {
 	AllUserGUIDs(token string) (userGUIDs []string, err error)
 }
 
+type campaignEnqueuer interface {
+	EnqueueCampaign(
+		conn ConnectionInterface,
+		users []User,
+		opts Options,
+		space cf.CloudControllerSpace,
+		org cf.CloudControllerOrganization,
+		clientID string,
+		uaaHost string,
+		scope string,
+		vcapRequestID string,
+		reqReceived time.Time) ([]Response, error)
+}
+
 type loadsTokens interface {
 	Load(host string) (token string, err error)
 }
@@ -15,10 +33,10 @@ type loadsTokens interface {
 type EveryoneStrategy struct {
 	tokenLoader loadsTokens
 	allUsers    allUserGUIDsGetter
-	enqueuer    enqueuer
+	enqueuer    campaignEnqueuer
 }
 
-func NewEveryoneStrategy(tokenLoader loadsTokens, allUsers allUserGUIDsGetter, enqueuer enqueuer) EveryoneStrategy {
+func NewEveryoneStrategy(tokenLoader loadsTokens, allUsers allUserGUIDsGetter, enqueuer campaignEnqueuer) EveryoneStrategy {
 	return EveryoneStrategy{
 		tokenLoader: tokenLoader,
 		allUsers:    allUsers,
@@ -36,9 +54,12 @@ func (strategy EveryoneStrategy) Dispatch(dispatch Dispatch) ([]Response, error)
 		Endorsement:       EveryoneEndorsement,
 		KindID:            dispatch.Kind.ID,
 		KindDescription:   dispatch.Kind.Description,
+		Critical:          dispatch.Kind.Critical,
 		SourceDescription: dispatch.Client.Description,
 		Text:              dispatch.Message.Text,
 		TemplateID:        dispatch.TemplateID,
+		SendAt:            dispatch.SendAt,
+		Locale:            dispatch.Message.Locale,
 		HTML: HTML{
 			BodyContent:    dispatch.Message.HTML.BodyContent,
 			BodyAttributes: dispatch.Message.HTML.BodyAttributes,
@@ -63,7 +84,7 @@ func (strategy EveryoneStrategy) Dispatch(dispatch Dispatch) ([]Response, error)
 		users = append(users, User{GUID: guid})
 	}
 
-	return strategy.enqueuer.Enqueue(
+	return strategy.enqueuer.EnqueueCampaign(
 		dispatch.Connection,
 		users,
 		options,
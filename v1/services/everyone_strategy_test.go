@@ -48,7 +48,7 @@ var _ = Describe("Everyone Strategy", func() {
 
 	Describe("Dispatch", func() {
 		Context("when the dispatch JobType is unspecified", func() {
-			It("call enqueuer.Enqueue with the correct arguments for an organization", func() {
+			It("call enqueuer.EnqueueCampaign with the correct arguments for an organization", func() {
 				_, err := strategy.Dispatch(services.Dispatch{
 					Connection: conn,
 					Kind: services.DispatchKind{
@@ -56,6 +56,7 @@ var _ = Describe("Everyone Strategy", func() {
 						Description: "Your Official Welcome",
 					},
 					TemplateID: "some-template-id",
+					SendAt:     time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
 					Client: services.DispatchClient{
 						ID:          "my-client",
 						Description: "Welcome system",
@@ -85,9 +86,9 @@ var _ = Describe("Everyone Strategy", func() {
 					users = append(users, services.User{GUID: guid})
 				}
 
-				Expect(enqueuer.EnqueueCall.Receives.Connection).To(Equal(conn))
-				Expect(enqueuer.EnqueueCall.Receives.Users).To(Equal(users))
-				Expect(enqueuer.EnqueueCall.Receives.Options).To(Equal(services.Options{
+				Expect(enqueuer.EnqueueCampaignCall.Receives.Connection).To(Equal(conn))
+				Expect(enqueuer.EnqueueCampaignCall.Receives.Users).To(Equal(users))
+				Expect(enqueuer.EnqueueCampaignCall.Receives.Options).To(Equal(services.Options{
 					ReplyTo:           "reply-to@example.com",
 					Subject:           "this is the subject",
 					To:                "dr@strangelove.com",
@@ -96,6 +97,7 @@ var _ = Describe("Everyone Strategy", func() {
 					SourceDescription: "Welcome system",
 					Text:              "Welcome to the system, now get off my lawn.",
 					TemplateID:        "some-template-id",
+					SendAt:            time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
 					HTML: services.HTML{
 						BodyContent:    "<p>Welcome to the system, now get off my lawn.</p>",
 						BodyAttributes: "some-html-body-attributes",
@@ -104,13 +106,13 @@ var _ = Describe("Everyone Strategy", func() {
 					},
 					Endorsement: services.EveryoneEndorsement,
 				}))
-				Expect(enqueuer.EnqueueCall.Receives.Space).To(Equal(cf.CloudControllerSpace{}))
-				Expect(enqueuer.EnqueueCall.Receives.Org).To(Equal(cf.CloudControllerOrganization{}))
-				Expect(enqueuer.EnqueueCall.Receives.Client).To(Equal("my-client"))
-				Expect(enqueuer.EnqueueCall.Receives.Scope).To(Equal(""))
-				Expect(enqueuer.EnqueueCall.Receives.VCAPRequestID).To(Equal("some-vcap-request-id"))
-				Expect(enqueuer.EnqueueCall.Receives.UAAHost).To(Equal("my-uaa-host"))
-				Expect(enqueuer.EnqueueCall.Receives.RequestReceived).To(Equal(requestReceivedTime))
+				Expect(enqueuer.EnqueueCampaignCall.Receives.Space).To(Equal(cf.CloudControllerSpace{}))
+				Expect(enqueuer.EnqueueCampaignCall.Receives.Org).To(Equal(cf.CloudControllerOrganization{}))
+				Expect(enqueuer.EnqueueCampaignCall.Receives.Client).To(Equal("my-client"))
+				Expect(enqueuer.EnqueueCampaignCall.Receives.Scope).To(Equal(""))
+				Expect(enqueuer.EnqueueCampaignCall.Receives.VCAPRequestID).To(Equal("some-vcap-request-id"))
+				Expect(enqueuer.EnqueueCampaignCall.Receives.UAAHost).To(Equal("my-uaa-host"))
+				Expect(enqueuer.EnqueueCampaignCall.Receives.RequestReceived).To(Equal(requestReceivedTime))
 				Expect(allUsers.AllUserGUIDsCall.Receives.Token).To(Equal(token))
 
 				Expect(tokenLoader.LoadCall.Receives.UAAHost).To(Equal("my-uaa-host"))
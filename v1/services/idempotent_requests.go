@@ -0,0 +1,103 @@
+package services
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+const IdempotencyReservationTimeout = 5 * time.Minute
+
+type idempotencyKeysRepo interface {
+	Create(models.ConnectionInterface, models.IdempotencyKey) (models.IdempotencyKey, error)
+	Find(conn models.ConnectionInterface, clientID, key string) (models.IdempotencyKey, error)
+	Update(models.ConnectionInterface, models.IdempotencyKey) (models.IdempotencyKey, error)
+	TakeOver(conn models.ConnectionInterface, key models.IdempotencyKey, reservedAt time.Time) (bool, error)
+	Delete(conn models.ConnectionInterface, clientID, key string) error
+	DeleteBefore(conn models.ConnectionInterface, clientID string, before time.Time) (int, error)
+}
+
+type clock interface {
+	Now() time.Time
+}
+
+type IdempotentRequests struct {
+	repo  idempotencyKeysRepo
+	clock clock
+	ttl   time.Duration
+}
+
+func NewIdempotentRequests(repo idempotencyKeysRepo, clock clock, ttl time.Duration) IdempotentRequests {
+	return IdempotentRequests{
+		repo:  repo,
+		clock: clock,
+		ttl:   ttl,
+	}
+}
+
+func (r IdempotentRequests) Begin(conn ConnectionInterface, clientID, key, requestHash string) ([]byte, bool, error) {
+	now := r.clock.Now().UTC()
+
+	_, err := r.repo.DeleteBefore(conn, clientID, now.Add(-r.ttl))
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = r.repo.Create(conn, models.IdempotencyKey{
+		ClientID:    clientID,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now.Truncate(time.Second),
+	})
+	switch err.(type) {
+	case nil:
+		return nil, false, nil
+	case models.DuplicateError:
+	default:
+		return nil, false, err
+	}
+
+	existing, err := r.repo.Find(conn, clientID, key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if existing.RequestHash != requestHash {
+		return nil, false, IdempotencyKeyConflictError{Key: key}
+	}
+
+	if existing.Response == "" {
+		if existing.CreatedAt.After(now.Add(-IdempotencyReservationTimeout)) {
+			return nil, false, IdempotencyKeyInProgressError{Key: key}
+		}
+
+		takenOver, err := r.repo.TakeOver(conn, existing, now.Truncate(time.Second))
+		if err != nil {
+			return nil, false, err
+		}
+
+		if !takenOver {
+			return nil, false, IdempotencyKeyInProgressError{Key: key}
+		}
+
+		return nil, false, nil
+	}
+
+	return []byte(existing.Response), true, nil
+}
+
+func (r IdempotentRequests) Finish(conn ConnectionInterface, clientID, key string, response []byte) error {
+	existing, err := r.repo.Find(conn, clientID, key)
+	if err != nil {
+		return err
+	}
+
+	existing.Response = string(response)
+	_, err = r.repo.Update(conn, existing)
+
+	return err
+}
+
+func (r IdempotentRequests) Abandon(conn ConnectionInterface, clientID, key string) error {
+	return r.repo.Delete(conn, clientID, key)
+}
@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IdempotentRequests", func() {
+	var (
+		requests services.IdempotentRequests
+		repo     *mocks.IdempotencyKeysRepo
+		clock    *mocks.Clock
+		conn     *mocks.Connection
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2015, 6, 8, 14, 40, 12, 0, time.UTC)
+		clock = mocks.NewClock()
+		clock.NowCall.Returns.Time = now
+
+		repo = mocks.NewIdempotencyKeysRepo()
+		conn = mocks.NewConnection()
+
+		requests = services.NewIdempotentRequests(repo, clock, time.Hour)
+	})
+
+	Describe("Begin", func() {
+		It("reserves an unused key", func() {
+			response, replayed, err := requests.Begin(conn, "some-client", "some-key", "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replayed).To(BeFalse())
+			Expect(response).To(BeNil())
+
+			Expect(repo.CreateCall.Receives.Connection).To(Equal(conn))
+			Expect(repo.CreateCall.Receives.Key).To(Equal(models.IdempotencyKey{
+				ClientID:    "some-client",
+				Key:         "some-key",
+				RequestHash: "some-hash",
+				CreatedAt:   now,
+			}))
+		})
+
+		It("purges the client's expired keys first", func() {
+			_, _, err := requests.Begin(conn, "some-client", "some-key", "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(repo.DeleteBeforeCall.Receives.Connection).To(Equal(conn))
+			Expect(repo.DeleteBeforeCall.Receives.ClientID).To(Equal("some-client"))
+			Expect(repo.DeleteBeforeCall.Receives.Before).To(Equal(now.Add(-time.Hour)))
+		})
+
+		Context("when the key has already been used", func() {
+			BeforeEach(func() {
+				repo.CreateCall.Returns.Error = models.DuplicateError{Err: errors.New("duplicate record")}
+				repo.FindCall.Returns.Key = models.IdempotencyKey{
+					ClientID:    "some-client",
+					Key:         "some-key",
+					RequestHash: "some-hash",
+					Response:    `[{"status":"queued"}]`,
+					CreatedAt:   now.Add(-time.Minute),
+				}
+			})
+
+			It("replays the stored response for the same request", func() {
+				response, replayed, err := requests.Begin(conn, "some-client", "some-key", "some-hash")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(replayed).To(BeTrue())
+				Expect(response).To(Equal([]byte(`[{"status":"queued"}]`)))
+
+				Expect(repo.FindCall.Receives.ClientID).To(Equal("some-client"))
+				Expect(repo.FindCall.Receives.Key).To(Equal("some-key"))
+			})
+
+			It("returns a conflict error for a different request", func() {
+				_, _, err := requests.Begin(conn, "some-client", "some-key", "other-hash")
+				Expect(err).To(MatchError(services.IdempotencyKeyConflictError{Key: "some-key"}))
+			})
+
+			It("returns an in progress error while the original request is still running", func() {
+				repo.FindCall.Returns.Key.Response = ""
+
+				_, _, err := requests.Begin(conn, "some-client", "some-key", "some-hash")
+				Expect(err).To(MatchError(services.IdempotencyKeyInProgressError{Key: "some-key"}))
+				Expect(repo.TakeOverCall.WasCalled).To(BeFalse())
+			})
+
+			Context("when the reservation was abandoned without a response", func() {
+				BeforeEach(func() {
+					repo.FindCall.Returns.Key.Response = ""
+					repo.FindCall.Returns.Key.CreatedAt = now.Add(-services.IdempotencyReservationTimeout - time.Second)
+				})
+
+				It("takes over the reservation", func() {
+					repo.TakeOverCall.Returns.TakenOver = true
+
+					response, replayed, err := requests.Begin(conn, "some-client", "some-key", "some-hash")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(replayed).To(BeFalse())
+					Expect(response).To(BeNil())
+
+					Expect(repo.TakeOverCall.Receives.Connection).To(Equal(conn))
+					Expect(repo.TakeOverCall.Receives.Key).To(Equal(repo.FindCall.Returns.Key))
+					Expect(repo.TakeOverCall.Receives.ReservedAt).To(Equal(now))
+				})
+
+				It("returns an in progress error when another request took it over first", func() {
+					repo.TakeOverCall.Returns.TakenOver = false
+
+					_, _, err := requests.Begin(conn, "some-client", "some-key", "some-hash")
+					Expect(err).To(MatchError(services.IdempotencyKeyInProgressError{Key: "some-key"}))
+				})
+
+				It("returns errors from the repo", func() {
+					repo.TakeOverCall.Returns.Error = errors.New("BOOM!")
+
+					_, _, err := requests.Begin(conn, "some-client", "some-key", "some-hash")
+					Expect(err).To(MatchError(errors.New("BOOM!")))
+				})
+			})
+		})
+
+		It("returns errors from the repo", func() {
+			repo.CreateCall.Returns.Error = errors.New("BOOM!")
+
+			_, _, err := requests.Begin(conn, "some-client", "some-key", "some-hash")
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+
+	Describe("Finish", func() {
+		It("stores the response with the key", func() {
+			repo.FindCall.Returns.Key = models.IdempotencyKey{Primary: 3, ClientID: "some-client", Key: "some-key", RequestHash: "some-hash"}
+
+			err := requests.Finish(conn, "some-client", "some-key", []byte(`[{"status":"queued"}]`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(repo.UpdateCall.Receives.Connection).To(Equal(conn))
+			Expect(repo.UpdateCall.Receives.Key).To(Equal(models.IdempotencyKey{
+				Primary:     3,
+				ClientID:    "some-client",
+				Key:         "some-key",
+				RequestHash: "some-hash",
+				Response:    `[{"status":"queued"}]`,
+			}))
+		})
+	})
+
+	Describe("Abandon", func() {
+		It("deletes the reservation so the request can be retried", func() {
+			err := requests.Abandon(conn, "some-client", "some-key")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(repo.DeleteCall.Receives.Connection).To(Equal(conn))
+			Expect(repo.DeleteCall.Receives.ClientID).To(Equal("some-client"))
+			Expect(repo.DeleteCall.Receives.Key).To(Equal("some-key"))
+		})
+	})
+})
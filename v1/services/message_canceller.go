@@ -0,0 +1,56 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type messagesRepoCanceller interface {
+	FindByID(models.ConnectionInterface, string) (models.Message, error)
+	TransitionStatus(conn models.ConnectionInterface, messageID, fromStatus, toStatus string) (bool, error)
+}
+
+type MessageCanceller struct {
+	repo       messagesRepoCanceller
+	eventsRepo messageEventsCreator
+}
+
+func NewMessageCanceller(repo messagesRepoCanceller, eventsRepo messageEventsCreator) MessageCanceller {
+	return MessageCanceller{
+		repo:       repo,
+		eventsRepo: eventsRepo,
+	}
+}
+
+func (canceller MessageCanceller) Cancel(database DatabaseInterface, messageID, clientID string) error {
+	connection := database.Connection()
+
+	message, err := canceller.repo.FindByID(connection, messageID)
+	if err != nil {
+		return err
+	}
+
+	if message.ClientID != "" && message.ClientID != clientID {
+		return models.NotFoundError{Err: fmt.Errorf("Message with ID %q could not be found", messageID)}
+	}
+
+	cancelled, err := canceller.repo.TransitionStatus(connection, messageID, StatusScheduled, StatusCancelled)
+	if err != nil {
+		return err
+	}
+
+	if !cancelled {
+		return MessageNotCancellableError{
+			MessageID: messageID,
+			Status:    message.Status,
+		}
+	}
+
+	_, err = canceller.eventsRepo.Create(connection, models.MessageEvent{
+		MessageID: messageID,
+		Event:     models.MessageEventCancelled,
+	})
+
+	return err
+}
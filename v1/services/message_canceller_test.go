@@ -0,0 +1,93 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MessageCanceller", func() {
+	var (
+		canceller         services.MessageCanceller
+		messagesRepo      *mocks.MessagesRepo
+		messageEventsRepo *mocks.MessageEventsRepo
+		database          *mocks.Database
+		conn              *mocks.Connection
+	)
+
+	BeforeEach(func() {
+		messagesRepo = mocks.NewMessagesRepo()
+		messagesRepo.FindByIDCall.Returns.Message = models.Message{
+			ID:       "a-message-id",
+			Status:   services.StatusScheduled,
+			ClientID: "some-client",
+		}
+		messagesRepo.TransitionStatusCall.Returns.Transitioned = true
+
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		messageEventsRepo = mocks.NewMessageEventsRepo()
+
+		canceller = services.NewMessageCanceller(messagesRepo, messageEventsRepo)
+	})
+
+	Describe("Cancel", func() {
+		It("cancels a scheduled message and records the cancellation", func() {
+			err := canceller.Cancel(database, "a-message-id", "some-client")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(messagesRepo.FindByIDCall.Receives.Connection).To(Equal(conn))
+			Expect(messagesRepo.FindByIDCall.Receives.MessageID).To(Equal("a-message-id"))
+
+			Expect(messagesRepo.TransitionStatusCall.Receives.Connection).To(Equal(conn))
+			Expect(messagesRepo.TransitionStatusCall.Receives.MessageID).To(Equal("a-message-id"))
+			Expect(messagesRepo.TransitionStatusCall.Receives.FromStatus).To(Equal(services.StatusScheduled))
+			Expect(messagesRepo.TransitionStatusCall.Receives.ToStatus).To(Equal(services.StatusCancelled))
+
+			Expect(messageEventsRepo.CreateCall.Receives.Connection).To(Equal(conn))
+			Expect(messageEventsRepo.CreateCall.Receives.Events).To(Equal([]models.MessageEvent{
+				{MessageID: "a-message-id", Event: models.MessageEventCancelled},
+			}))
+		})
+
+		It("returns a not cancellable error when the message is no longer scheduled", func() {
+			messagesRepo.FindByIDCall.Returns.Message.Status = common.StatusDelivered
+			messagesRepo.TransitionStatusCall.Returns.Transitioned = false
+
+			err := canceller.Cancel(database, "a-message-id", "some-client")
+			Expect(err).To(MatchError(services.MessageNotCancellableError{
+				MessageID: "a-message-id",
+				Status:    common.StatusDelivered,
+			}))
+			Expect(messageEventsRepo.CreateCall.Receives.Events).To(BeEmpty())
+		})
+
+		It("does not let a client cancel another client's message", func() {
+			err := canceller.Cancel(database, "a-message-id", "other-client")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+			Expect(messagesRepo.TransitionStatusCall.Receives.MessageID).To(BeEmpty())
+		})
+
+		It("bubbles up errors from finding the message", func() {
+			messagesRepo.FindByIDCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			err := canceller.Cancel(database, "a-message-id", "some-client")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+		})
+
+		It("bubbles up errors from updating the message", func() {
+			messagesRepo.TransitionStatusCall.Returns.Error = errors.New("BOOM!")
+
+			err := canceller.Cancel(database, "a-message-id", "some-client")
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+})
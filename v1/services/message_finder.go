@@ -1,22 +1,48 @@
 package services
 
-import "github.com/cloudfoundry-incubator/notifications/v1/models"
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
 
 type Message struct {
-	Status string
+	ID            string
+	Status        string
+	ClientID      string
+	KindID        string
+	UserGUID      string
+	Email         string
+	VCAPRequestID string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Events        []MessageEvent
+}
+
+type MessageEvent struct {
+	Event     string
+	Detail    string
+	CreatedAt time.Time
 }
 
 type messagesRepoFinder interface {
 	FindByID(models.ConnectionInterface, string) (models.Message, error)
+	List(models.ConnectionInterface, models.MessagesFilter) ([]models.Message, int, error)
+}
+
+type messageEventsLister interface {
+	ListByMessageID(models.ConnectionInterface, string) ([]models.MessageEvent, error)
 }
 
 type MessageFinder struct {
-	repo messagesRepoFinder
+	repo       messagesRepoFinder
+	eventsRepo messageEventsLister
 }
 
-func NewMessageFinder(repo messagesRepoFinder) MessageFinder {
+func NewMessageFinder(repo messagesRepoFinder, eventsRepo messageEventsLister) MessageFinder {
 	return MessageFinder{
-		repo: repo,
+		repo:       repo,
+		eventsRepo: eventsRepo,
 	}
 }
 
@@ -28,3 +54,55 @@ func (finder MessageFinder) Find(database DatabaseInterface, messageID string) (
 
 	return Message{Status: message.Status}, nil
 }
+
+func (finder MessageFinder) Timeline(database DatabaseInterface, messageID string) (Message, error) {
+	connection := database.Connection()
+
+	message, err := finder.repo.FindByID(connection, messageID)
+	if err != nil {
+		return Message{}, err
+	}
+
+	events, err := finder.eventsRepo.ListByMessageID(connection, messageID)
+	if err != nil {
+		return Message{}, err
+	}
+
+	timeline := Message{
+		Status: message.Status,
+		Events: []MessageEvent{},
+	}
+	for _, event := range events {
+		timeline.Events = append(timeline.Events, MessageEvent{
+			Event:     event.Event,
+			Detail:    event.Detail,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	return timeline, nil
+}
+
+func (finder MessageFinder) List(database DatabaseInterface, filter models.MessagesFilter) ([]Message, int, error) {
+	messages, total, err := finder.repo.List(database.Connection(), filter)
+	if err != nil {
+		return []Message{}, 0, err
+	}
+
+	results := []Message{}
+	for _, message := range messages {
+		results = append(results, Message{
+			ID:            message.ID,
+			Status:        message.Status,
+			ClientID:      message.ClientID,
+			KindID:        message.KindID,
+			UserGUID:      message.UserGUID,
+			Email:         message.Email,
+			VCAPRequestID: message.VCAPRequestID,
+			CreatedAt:     message.CreatedAt,
+			UpdatedAt:     message.UpdatedAt,
+		})
+	}
+
+	return results, total, nil
+}
@@ -2,6 +2,7 @@ package services_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
@@ -12,12 +13,13 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-var _ = Describe("MessageFinder.Find", func() {
+var _ = Describe("MessageFinder", func() {
 	var (
-		finder       services.MessageFinder
-		messagesRepo *mocks.MessagesRepo
-		database     *mocks.Database
-		conn         *mocks.Connection
+		finder            services.MessageFinder
+		messagesRepo      *mocks.MessagesRepo
+		messageEventsRepo *mocks.MessageEventsRepo
+		database          *mocks.Database
+		conn              *mocks.Connection
 	)
 
 	BeforeEach(func() {
@@ -26,28 +28,132 @@ var _ = Describe("MessageFinder.Find", func() {
 		database = mocks.NewDatabase()
 		database.ConnectionCall.Returns.Connection = conn
 
-		finder = services.NewMessageFinder(messagesRepo)
+		messageEventsRepo = mocks.NewMessageEventsRepo()
+
+		finder = services.NewMessageFinder(messagesRepo, messageEventsRepo)
 	})
 
-	Context("when a message exists with the given id", func() {
-		It("returns the right Message struct", func() {
-			messagesRepo.FindByIDCall.Returns.Message = models.Message{Status: common.StatusDelivered}
+	Describe("Find", func() {
+		Context("when a message exists with the given id", func() {
+			It("returns the right Message struct", func() {
+				messagesRepo.FindByIDCall.Returns.Message = models.Message{Status: common.StatusDelivered}
+
+				message, err := finder.Find(database, "a-message-id")
 
-			message, err := finder.Find(database, "a-message-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(message.Status).To(Equal(common.StatusDelivered))
+
+				Expect(messagesRepo.FindByIDCall.Receives.Connection).To(Equal(conn))
+				Expect(messagesRepo.FindByIDCall.Receives.MessageID).To(Equal("a-message-id"))
+			})
+		})
 
+		Context("when the underlying repo returns an error", func() {
+			It("bubbles up the error", func() {
+				messagesRepo.FindByIDCall.Returns.Error = errors.New("some error")
+
+				_, err := finder.Find(database, "a-message-id")
+				Expect(err).To(MatchError(errors.New("some error")))
+			})
+		})
+	})
+
+	Describe("Timeline", func() {
+		It("returns the status of the message along with its events", func() {
+			createdAt := time.Now().Truncate(time.Second).UTC()
+			messagesRepo.FindByIDCall.Returns.Message = models.Message{Status: common.StatusFailed}
+			messageEventsRepo.ListByMessageIDCall.Returns.Events = []models.MessageEvent{
+				{MessageID: "a-message-id", Event: models.MessageEventQueued, CreatedAt: createdAt},
+				{MessageID: "a-message-id", Event: models.MessageEventFailed, Detail: "550 mailbox unavailable", CreatedAt: createdAt},
+			}
+
+			message, err := finder.Timeline(database, "a-message-id")
 			Expect(err).NotTo(HaveOccurred())
-			Expect(message.Status).To(Equal(common.StatusDelivered))
 
-			Expect(messagesRepo.FindByIDCall.Receives.Connection).To(Equal(conn))
+			Expect(message).To(Equal(services.Message{
+				Status: common.StatusFailed,
+				Events: []services.MessageEvent{
+					{Event: models.MessageEventQueued, CreatedAt: createdAt},
+					{Event: models.MessageEventFailed, Detail: "550 mailbox unavailable", CreatedAt: createdAt},
+				},
+			}))
+
 			Expect(messagesRepo.FindByIDCall.Receives.MessageID).To(Equal("a-message-id"))
+			Expect(messageEventsRepo.ListByMessageIDCall.Receives.Connection).To(Equal(conn))
+			Expect(messageEventsRepo.ListByMessageIDCall.Receives.MessageID).To(Equal("a-message-id"))
+		})
+
+		It("returns an empty list of events when none have been recorded", func() {
+			message, err := finder.Timeline(database, "a-message-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message.Events).To(Equal([]services.MessageEvent{}))
+		})
+
+		Context("when the message cannot be found", func() {
+			It("returns the error without listing events", func() {
+				messagesRepo.FindByIDCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+				_, err := finder.Timeline(database, "a-message-id")
+				Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+				Expect(messageEventsRepo.ListByMessageIDCall.Receives.MessageID).To(BeEmpty())
+			})
+		})
+
+		Context("when the events cannot be listed", func() {
+			It("bubbles up the error", func() {
+				messageEventsRepo.ListByMessageIDCall.Returns.Error = errors.New("some error")
+
+				_, err := finder.Timeline(database, "a-message-id")
+				Expect(err).To(MatchError(errors.New("some error")))
+			})
 		})
 	})
 
-	Context("when the underlying repo returns an error", func() {
-		It("bubbles up the error", func() {
-			messagesRepo.FindByIDCall.Returns.Error = errors.New("some error")
+	Describe("List", func() {
+		It("returns the filtered messages with their metadata and the total", func() {
+			createdAt := time.Now().Truncate(time.Second).UTC()
+			messagesRepo.ListCall.Returns.Messages = []models.Message{
+				{
+					ID:            "message-1",
+					Status:        common.StatusDelivered,
+					ClientID:      "some-client",
+					KindID:        "some-kind",
+					UserGUID:      "some-user",
+					Email:         "user@example.com",
+					VCAPRequestID: "some-request-id",
+					CreatedAt:     createdAt,
+					UpdatedAt:     createdAt,
+				},
+			}
+			messagesRepo.ListCall.Returns.Total = 12
+
+			filter := models.MessagesFilter{ClientID: "some-client", Limit: 1}
+			messages, total, err := finder.List(database, filter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(total).To(Equal(12))
+			Expect(messages).To(Equal([]services.Message{
+				{
+					ID:            "message-1",
+					Status:        common.StatusDelivered,
+					ClientID:      "some-client",
+					KindID:        "some-kind",
+					UserGUID:      "some-user",
+					Email:         "user@example.com",
+					VCAPRequestID: "some-request-id",
+					CreatedAt:     createdAt,
+					UpdatedAt:     createdAt,
+				},
+			}))
+
+			Expect(messagesRepo.ListCall.Receives.Connection).To(Equal(conn))
+			Expect(messagesRepo.ListCall.Receives.Filter).To(Equal(filter))
+		})
+
+		It("bubbles up repo errors", func() {
+			messagesRepo.ListCall.Returns.Error = errors.New("some error")
 
-			_, err := finder.Find(database, "a-message-id")
+			_, _, err := finder.List(database, models.MessagesFilter{})
 			Expect(err).To(MatchError(errors.New("some error")))
 		})
 	})
@@ -19,10 +19,10 @@ type OrganizationStrategy struct {
 	tokenLoader        loadsTokens
 	organizationLoader loadsOrganizations
 	findsUserIDs       orgUserIDFinder
-	enqueuer           enqueuer
+	enqueuer           campaignEnqueuer
 }
 
-func NewOrganizationStrategy(tokenLoader loadsTokens, organizationLoader loadsOrganizations, findsUserIDs orgUserIDFinder, queue enqueuer) OrganizationStrategy {
+func NewOrganizationStrategy(tokenLoader loadsTokens, organizationLoader loadsOrganizations, findsUserIDs orgUserIDFinder, queue campaignEnqueuer) OrganizationStrategy {
 	return OrganizationStrategy{
 		tokenLoader:        tokenLoader,
 		organizationLoader: organizationLoader,
@@ -39,10 +39,13 @@ func (strategy OrganizationStrategy) Dispatch(dispatch Dispatch) ([]Response, er
 		Subject:           dispatch.Message.Subject,
 		KindID:            dispatch.Kind.ID,
 		KindDescription:   dispatch.Kind.Description,
+		Critical:          dispatch.Kind.Critical,
 		SourceDescription: dispatch.Client.Description,
 		Endorsement:       OrganizationEndorsement,
 		Text:              dispatch.Message.Text,
 		TemplateID:        dispatch.TemplateID,
+		SendAt:            dispatch.SendAt,
+		Locale:            dispatch.Message.Locale,
 		Role:              dispatch.Role,
 		HTML: HTML{
 			BodyContent:    dispatch.Message.HTML.BodyContent,
@@ -76,7 +79,7 @@ func (strategy OrganizationStrategy) Dispatch(dispatch Dispatch) ([]Response, er
 		users = append(users, User{GUID: guid})
 	}
 
-	return strategy.enqueuer.Enqueue(
+	return strategy.enqueuer.EnqueueCampaign(
 		dispatch.Connection,
 		users,
 		options,
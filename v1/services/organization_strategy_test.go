@@ -58,7 +58,7 @@ var _ = Describe("Organization Strategy", func() {
 	Describe("Dispatch", func() {
 		Context("when the dispatch JobType is unspecified", func() {
 			Context("when the request is valid", func() {
-				It("call enqueuer.Enqueue with the correct arguments for an organization", func() {
+				It("call enqueuer.EnqueueCampaign with the correct arguments for an organization", func() {
 					_, err := strategy.Dispatch(services.Dispatch{
 						GUID:       "org-001",
 						Connection: conn,
@@ -79,6 +79,7 @@ var _ = Describe("Organization Strategy", func() {
 							Description: "Password reminder",
 						},
 						TemplateID: "some-template-id",
+						SendAt:     time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
 						Client: services.DispatchClient{
 							ID:          "mister-client",
 							Description: "Login system",
@@ -99,9 +100,9 @@ var _ = Describe("Organization Strategy", func() {
 					Expect(organizationLoader.LoadCall.Receives.OrganizationGUID).To(Equal("org-001"))
 					Expect(organizationLoader.LoadCall.Receives.Token).To(Equal(tokenLoader.LoadCall.Returns.Token))
 
-					Expect(enqueuer.EnqueueCall.Receives.Connection).To(Equal(conn))
-					Expect(enqueuer.EnqueueCall.Receives.Users).To(Equal(users))
-					Expect(enqueuer.EnqueueCall.Receives.Options).To(Equal(services.Options{
+					Expect(enqueuer.EnqueueCampaignCall.Receives.Connection).To(Equal(conn))
+					Expect(enqueuer.EnqueueCampaignCall.Receives.Users).To(Equal(users))
+					Expect(enqueuer.EnqueueCampaignCall.Receives.Options).To(Equal(services.Options{
 						ReplyTo:           "reply-to@example.com",
 						Subject:           "this is the subject",
 						To:                "dr@strangelove.com",
@@ -110,6 +111,7 @@ var _ = Describe("Organization Strategy", func() {
 						SourceDescription: "Login system",
 						Text:              "Please reset your password by clicking on this link...",
 						TemplateID:        "some-template-id",
+						SendAt:            time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
 						HTML: services.HTML{
 							BodyContent:    "<p>Welcome to the system, now get off my lawn.</p>",
 							BodyAttributes: "some-html-body-attributes",
@@ -118,16 +120,16 @@ var _ = Describe("Organization Strategy", func() {
 						},
 						Endorsement: services.OrganizationEndorsement,
 					}))
-					Expect(enqueuer.EnqueueCall.Receives.Space).To(Equal(cf.CloudControllerSpace{}))
-					Expect(enqueuer.EnqueueCall.Receives.Org).To(Equal(cf.CloudControllerOrganization{
+					Expect(enqueuer.EnqueueCampaignCall.Receives.Space).To(Equal(cf.CloudControllerSpace{}))
+					Expect(enqueuer.EnqueueCampaignCall.Receives.Org).To(Equal(cf.CloudControllerOrganization{
 						Name: "my-org",
 						GUID: "org-001",
 					}))
-					Expect(enqueuer.EnqueueCall.Receives.Client).To(Equal("mister-client"))
-					Expect(enqueuer.EnqueueCall.Receives.Scope).To(Equal(""))
-					Expect(enqueuer.EnqueueCall.Receives.VCAPRequestID).To(Equal("some-vcap-request-id"))
-					Expect(enqueuer.EnqueueCall.Receives.RequestReceived).To(Equal(requestReceived))
-					Expect(enqueuer.EnqueueCall.Receives.UAAHost).To(Equal("testzone1"))
+					Expect(enqueuer.EnqueueCampaignCall.Receives.Client).To(Equal("mister-client"))
+					Expect(enqueuer.EnqueueCampaignCall.Receives.Scope).To(Equal(""))
+					Expect(enqueuer.EnqueueCampaignCall.Receives.VCAPRequestID).To(Equal("some-vcap-request-id"))
+					Expect(enqueuer.EnqueueCampaignCall.Receives.RequestReceived).To(Equal(requestReceived))
+					Expect(enqueuer.EnqueueCampaignCall.Receives.UAAHost).To(Equal("testzone1"))
 
 					Expect(tokenLoader.LoadCall.Receives.UAAHost).To(Equal("testzone1"))
 
@@ -137,7 +139,7 @@ var _ = Describe("Organization Strategy", func() {
 				})
 
 				Context("when the org role field is set", func() {
-					It("calls enqueuer.Enqueue with the correct arguments", func() {
+					It("calls enqueuer.EnqueueCampaign with the correct arguments", func() {
 						_, err := strategy.Dispatch(services.Dispatch{
 							GUID:       "org-001",
 							Role:       "OrgManager",
@@ -169,7 +171,7 @@ var _ = Describe("Organization Strategy", func() {
 						})
 						Expect(err).NotTo(HaveOccurred())
 
-						Expect(enqueuer.EnqueueCall.Receives.Options).To(Equal(services.Options{
+						Expect(enqueuer.EnqueueCampaignCall.Receives.Options).To(Equal(services.Options{
 							ReplyTo:           "reply-to@example.com",
 							Subject:           "this is the subject",
 							To:                "dr@strangelove.com",
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type recipientsEnqueuer interface {
+	enqueuer
+	campaignEnqueuer
+}
+
+type recipientLimiter interface {
+	AllowRecipients(conn models.ConnectionInterface, clientID string, count int) error
+}
+
+// RateLimitedEnqueuer charges every recipient of a dispatch against the
+// client's recipients-per-hour limit before enqueueing it.
+type RateLimitedEnqueuer struct {
+	enqueuer recipientsEnqueuer
+	limiter  recipientLimiter
+}
+
+func NewRateLimitedEnqueuer(enqueuer recipientsEnqueuer, limiter recipientLimiter) RateLimitedEnqueuer {
+	return RateLimitedEnqueuer{
+		enqueuer: enqueuer,
+		limiter:  limiter,
+	}
+}
+
+func (e RateLimitedEnqueuer) Enqueue(conn ConnectionInterface, users []User, options Options, space cf.CloudControllerSpace,
+	organization cf.CloudControllerOrganization, clientID, uaaHost, scope, vcapRequestID string, reqReceived time.Time) ([]Response, error) {
+
+	if err := e.limiter.AllowRecipients(conn, clientID, len(users)); err != nil {
+		return []Response{}, err
+	}
+
+	return e.enqueuer.Enqueue(conn, users, options, space, organization, clientID, uaaHost, scope, vcapRequestID, reqReceived)
+}
+
+func (e RateLimitedEnqueuer) EnqueueCampaign(conn ConnectionInterface, users []User, options Options, space cf.CloudControllerSpace,
+	organization cf.CloudControllerOrganization, clientID, uaaHost, scope, vcapRequestID string, reqReceived time.Time) ([]Response, error) {
+
+	if err := e.limiter.AllowRecipients(conn, clientID, len(users)); err != nil {
+		return []Response{}, err
+	}
+
+	return e.enqueuer.EnqueueCampaign(conn, users, options, space, organization, clientID, uaaHost, scope, vcapRequestID, reqReceived)
+}
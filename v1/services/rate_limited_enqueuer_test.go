@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimitedEnqueuer", func() {
+	var (
+		enqueuer    services.RateLimitedEnqueuer
+		inner       *mocks.Enqueuer
+		limiter     *mocks.RateLimiter
+		conn        *mocks.Connection
+		users       []services.User
+		reqReceived time.Time
+	)
+
+	BeforeEach(func() {
+		inner = mocks.NewEnqueuer()
+		inner.EnqueueCall.Returns.Responses = []services.Response{{Status: "queued"}}
+		inner.EnqueueCampaignCall.Returns.Responses = []services.Response{{Status: "queued"}}
+		limiter = mocks.NewRateLimiter()
+		conn = mocks.NewConnection()
+		users = []services.User{{GUID: "user-1"}, {GUID: "user-2"}}
+		reqReceived = time.Now()
+
+		enqueuer = services.NewRateLimitedEnqueuer(inner, limiter)
+	})
+
+	Describe("Enqueue", func() {
+		It("charges the recipients against the client's limit before enqueueing", func() {
+			responses, err := enqueuer.Enqueue(conn, users, services.Options{}, cf.CloudControllerSpace{}, cf.CloudControllerOrganization{},
+				"some-client", "uaa-host", "", "request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responses).To(Equal([]services.Response{{Status: "queued"}}))
+
+			Expect(limiter.AllowRecipientsCall.Receives.Connection).To(Equal(conn))
+			Expect(limiter.AllowRecipientsCall.Receives.ClientID).To(Equal("some-client"))
+			Expect(limiter.AllowRecipientsCall.Receives.Count).To(Equal(2))
+			Expect(inner.EnqueueCall.Receives.Users).To(Equal(users))
+		})
+
+		It("does not enqueue when the limit is exceeded", func() {
+			limiter.AllowRecipientsCall.Returns.Error = services.RateLimitExceededError{ClientID: "some-client"}
+
+			_, err := enqueuer.Enqueue(conn, users, services.Options{}, cf.CloudControllerSpace{}, cf.CloudControllerOrganization{},
+				"some-client", "uaa-host", "", "request-id", reqReceived)
+			Expect(err).To(MatchError(services.RateLimitExceededError{ClientID: "some-client"}))
+			Expect(inner.EnqueueCall.WasCalled).To(BeFalse())
+		})
+	})
+
+	Describe("EnqueueCampaign", func() {
+		It("charges the recipients against the client's limit before enqueueing", func() {
+			responses, err := enqueuer.EnqueueCampaign(conn, users, services.Options{}, cf.CloudControllerSpace{}, cf.CloudControllerOrganization{},
+				"some-client", "uaa-host", "", "request-id", reqReceived)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(responses).To(Equal([]services.Response{{Status: "queued"}}))
+
+			Expect(limiter.AllowRecipientsCall.Receives.Count).To(Equal(2))
+			Expect(inner.EnqueueCampaignCall.Receives.Users).To(Equal(users))
+		})
+
+		It("does not enqueue when the limit is exceeded", func() {
+			limiter.AllowRecipientsCall.Returns.Error = services.RateLimitExceededError{ClientID: "some-client"}
+
+			_, err := enqueuer.EnqueueCampaign(conn, users, services.Options{}, cf.CloudControllerSpace{}, cf.CloudControllerOrganization{},
+				"some-client", "uaa-host", "", "request-id", reqReceived)
+			Expect(err).To(MatchError(services.RateLimitExceededError{ClientID: "some-client"}))
+			Expect(inner.EnqueueCampaignCall.WasCalled).To(BeFalse())
+		})
+	})
+})
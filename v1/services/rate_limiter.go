@@ -0,0 +1,154 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type RateLimits struct {
+	RequestsPerMinute int
+	RecipientsPerHour int
+}
+
+type rateLimitsFinder interface {
+	Find(conn models.ConnectionInterface, clientID string) (models.RateLimit, error)
+}
+
+// RateLimiter keeps an in-memory token bucket per client for notify
+// requests and for recipients. Buckets are local to each API instance.
+type RateLimiter struct {
+	repo     rateLimitsFinder
+	clock    clock
+	defaults RateLimits
+
+	mutex   *sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func NewRateLimiter(repo rateLimitsFinder, clock clock, defaults RateLimits) RateLimiter {
+	return RateLimiter{
+		repo:     repo,
+		clock:    clock,
+		defaults: defaults,
+		mutex:    &sync.Mutex{},
+		buckets:  map[string]*tokenBucket{},
+	}
+}
+
+// Limits returns the limits that apply to the client, falling back to the
+// defaults when the client has no override.
+func (l RateLimiter) Limits(conn models.ConnectionInterface, clientID string) (RateLimits, error) {
+	rateLimit, err := l.repo.Find(conn, clientID)
+	if err != nil {
+		if _, ok := err.(models.NotFoundError); ok {
+			return l.defaults, nil
+		}
+		return RateLimits{}, err
+	}
+
+	return RateLimits{
+		RequestsPerMinute: rateLimit.RequestsPerMinute,
+		RecipientsPerHour: rateLimit.RecipientsPerHour,
+	}, nil
+}
+
+func (l RateLimiter) AllowRequest(conn models.ConnectionInterface, clientID string) error {
+	limits, err := l.Limits(conn, clientID)
+	if err != nil {
+		return err
+	}
+
+	return l.take(clientID, "requests", limits.RequestsPerMinute, time.Minute, 1)
+}
+
+func (l RateLimiter) AllowRecipients(conn models.ConnectionInterface, clientID string, count int) error {
+	limits, err := l.Limits(conn, clientID)
+	if err != nil {
+		return err
+	}
+
+	if limits.RecipientsPerHour > 0 && count > limits.RecipientsPerHour {
+		return RecipientsExceedRateLimitError{
+			ClientID:          clientID,
+			Recipients:        count,
+			RecipientsPerHour: limits.RecipientsPerHour,
+		}
+	}
+
+	return l.take(clientID, "recipients", limits.RecipientsPerHour, time.Hour, count)
+}
+
+func (l RateLimiter) take(clientID, unit string, limit int, window time.Duration, count int) error {
+	if limit <= 0 || count == 0 {
+		return nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	key := clientID + "|" + unit
+	bucket, ok := l.buckets[key]
+	if !ok || bucket.capacity != float64(limit) {
+		bucket = newTokenBucket(limit, l.clock.Now())
+		l.buckets[key] = bucket
+	}
+
+	retryAfter, ok := bucket.take(l.clock.Now(), window, count)
+	if !ok {
+		return RateLimitExceededError{
+			ClientID:   clientID,
+			Limit:      fmt.Sprintf("%d %s per %s", limit, unit, windowName(window)),
+			RetryAfter: retryAfter,
+		}
+	}
+
+	return nil
+}
+
+func windowName(window time.Duration) string {
+	if window == time.Hour {
+		return "hour"
+	}
+
+	return "minute"
+}
+
+type tokenBucket struct {
+	capacity  float64
+	tokens    float64
+	updatedAt time.Time
+}
+
+func newTokenBucket(capacity int, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		capacity:  float64(capacity),
+		tokens:    float64(capacity),
+		updatedAt: now,
+	}
+}
+
+// take refills the bucket for the time elapsed since it was last used and
+// removes count tokens. When there are not enough tokens it reports how long
+// until there will be, so count must not exceed the capacity.
+func (b *tokenBucket) take(now time.Time, window time.Duration, count int) (time.Duration, bool) {
+	rate := b.capacity / window.Seconds()
+
+	if elapsed := now.Sub(b.updatedAt).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+	}
+	b.updatedAt = now
+
+	needed := float64(count)
+	if needed <= b.tokens {
+		b.tokens -= needed
+		return 0, true
+	}
+
+	return time.Duration((needed - b.tokens) / rate * float64(time.Second)), false
+}
@@ -0,0 +1,162 @@
+package services_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var (
+		limiter        services.RateLimiter
+		rateLimitsRepo *mocks.RateLimitsRepo
+		clock          *mocks.Clock
+		conn           *mocks.Connection
+		now            time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2015, 1, 1, 12, 0, 0, 0, time.UTC)
+		clock = mocks.NewClock()
+		clock.NowCall.Returns.Time = now
+
+		rateLimitsRepo = mocks.NewRateLimitsRepo()
+		rateLimitsRepo.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		conn = mocks.NewConnection()
+
+		limiter = services.NewRateLimiter(rateLimitsRepo, clock, services.RateLimits{
+			RequestsPerMinute: 2,
+			RecipientsPerHour: 10,
+		})
+	})
+
+	Describe("Limits", func() {
+		It("returns the defaults when the client has no override", func() {
+			limits, err := limiter.Limits(conn, "some-client")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(limits).To(Equal(services.RateLimits{RequestsPerMinute: 2, RecipientsPerHour: 10}))
+
+			Expect(rateLimitsRepo.FindCall.Receives.Connection).To(Equal(conn))
+			Expect(rateLimitsRepo.FindCall.Receives.ClientID).To(Equal("some-client"))
+		})
+
+		It("returns the client's override", func() {
+			rateLimitsRepo.FindCall.Returns.Error = nil
+			rateLimitsRepo.FindCall.Returns.RateLimit = models.RateLimit{
+				ClientID:          "some-client",
+				RequestsPerMinute: 60,
+			}
+
+			limits, err := limiter.Limits(conn, "some-client")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(limits).To(Equal(services.RateLimits{RequestsPerMinute: 60}))
+		})
+
+		It("returns other errors from the repo", func() {
+			rateLimitsRepo.FindCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := limiter.Limits(conn, "some-client")
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+
+	Describe("AllowRequest", func() {
+		It("allows requests until the bucket is empty", func() {
+			Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+			Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+
+			err := limiter.AllowRequest(conn, "some-client")
+			Expect(err).To(MatchError(services.RateLimitExceededError{
+				ClientID:   "some-client",
+				Limit:      "2 requests per minute",
+				RetryAfter: 30 * time.Second,
+			}))
+		})
+
+		It("refills the bucket over time", func() {
+			Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+			Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+
+			clock.NowCall.Returns.Time = now.Add(30 * time.Second)
+			Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+			Expect(limiter.AllowRequest(conn, "some-client")).NotTo(Succeed())
+		})
+
+		It("keeps a separate bucket per client", func() {
+			Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+			Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+
+			Expect(limiter.AllowRequest(conn, "other-client")).To(Succeed())
+		})
+
+		It("does not limit clients with a limit of zero", func() {
+			rateLimitsRepo.FindCall.Returns.Error = nil
+			rateLimitsRepo.FindCall.Returns.RateLimit = models.RateLimit{ClientID: "some-client"}
+
+			for i := 0; i < 100; i++ {
+				Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+			}
+		})
+
+		It("starts a new bucket when the client's limit changes", func() {
+			Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+			Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+
+			rateLimitsRepo.FindCall.Returns.Error = nil
+			rateLimitsRepo.FindCall.Returns.RateLimit = models.RateLimit{ClientID: "some-client", RequestsPerMinute: 5}
+
+			Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+		})
+
+		It("returns errors from looking up the limits", func() {
+			rateLimitsRepo.FindCall.Returns.Error = errors.New("BOOM!")
+
+			Expect(limiter.AllowRequest(conn, "some-client")).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+
+	Describe("AllowRecipients", func() {
+		It("charges one token per recipient", func() {
+			Expect(limiter.AllowRecipients(conn, "some-client", 8)).To(Succeed())
+
+			err := limiter.AllowRecipients(conn, "some-client", 4)
+			Expect(err).To(MatchError(services.RateLimitExceededError{
+				ClientID:   "some-client",
+				Limit:      "10 recipients per hour",
+				RetryAfter: 12 * time.Minute,
+			}))
+
+			Expect(limiter.AllowRecipients(conn, "some-client", 2)).To(Succeed())
+		})
+
+		It("rejects a dispatch that can never fit without charging for it", func() {
+			err := limiter.AllowRecipients(conn, "some-client", 11)
+			Expect(err).To(MatchError(services.RecipientsExceedRateLimitError{
+				ClientID:          "some-client",
+				Recipients:        11,
+				RecipientsPerHour: 10,
+			}))
+
+			Expect(limiter.AllowRecipients(conn, "some-client", 10)).To(Succeed())
+		})
+
+		It("does not share tokens with the request bucket", func() {
+			Expect(limiter.AllowRecipients(conn, "some-client", 10)).To(Succeed())
+			Expect(limiter.AllowRequest(conn, "some-client")).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("RateLimitExceededError", func() {
+	It("rounds the retry delay up to whole seconds", func() {
+		Expect(services.RateLimitExceededError{RetryAfter: 1500 * time.Millisecond}.RetryAfterSeconds()).To(Equal(2))
+		Expect(services.RateLimitExceededError{}.RetryAfterSeconds()).To(Equal(1))
+	})
+})
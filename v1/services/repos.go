@@ -27,7 +27,10 @@ type TemplatesRepo interface {
 	Create(connection models.ConnectionInterface, template models.Template) (models.Template, error)
 	Destroy(connection models.ConnectionInterface, templateID string) error
 	FindByID(connection models.ConnectionInterface, templateID string) (models.Template, error)
+	FindVersion(connection models.ConnectionInterface, templateID string, version int) (models.TemplateVersion, error)
 	ListIDsAndNames(connection models.ConnectionInterface) ([]models.Template, error)
+	ListVersions(connection models.ConnectionInterface, templateID string) ([]models.TemplateVersion, error)
+	Restore(connection models.ConnectionInterface, templateID string, templateVersion models.TemplateVersion) (models.Template, error)
 	Update(connection models.ConnectionInterface, templateID string, template models.Template) (models.Template, error)
 }
 
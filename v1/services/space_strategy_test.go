@@ -85,6 +85,7 @@ var _ = Describe("Space Strategy", func() {
 							},
 						},
 						TemplateID: "some-template-id",
+						SendAt:     time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
 						Kind: services.DispatchKind{
 							ID:          "forgot_password",
 							Description: "Password reminder",
@@ -120,6 +121,7 @@ var _ = Describe("Space Strategy", func() {
 						SourceDescription: "Login system",
 						Text:              "Please reset your password by clicking on this link...",
 						TemplateID:        "some-template-id",
+						SendAt:            time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
 						HTML: services.HTML{
 							BodyContent:    "<p>Welcome to the system, now get off my lawn.</p>",
 							BodyAttributes: "some-html-body-attributes",
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type templateCompiler interface {
+	CompileSubject(context common.MessageContext) (string, error)
+	CompileParts(context common.MessageContext) ([]mail.Part, error)
+}
+
+type TemplatePreview struct {
+	Subject string
+	Text    string
+	HTML    string
+	Errors  map[string]string
+}
+
+type TemplatePreviewer struct {
+	compiler templateCompiler
+}
+
+func NewTemplatePreviewer(compiler templateCompiler) TemplatePreviewer {
+	return TemplatePreviewer{
+		compiler: compiler,
+	}
+}
+
+// Preview renders the template against the context the same way a delivery
+// would. A part that fails to render is reported in Errors rather than
+// failing the whole preview.
+func (previewer TemplatePreviewer) Preview(template models.Template, context common.MessageContext) TemplatePreview {
+	context.SubjectTemplate = template.Subject
+	context.TextTemplate = template.Text
+	context.HTMLTemplate = template.HTML
+	context.StrictTemplates = template.Strict
+
+	preview := TemplatePreview{
+		Errors: map[string]string{},
+	}
+
+	subject, err := previewer.compiler.CompileSubject(context)
+	if err != nil {
+		preview.Errors["subject"] = err.Error()
+	}
+	preview.Subject = subject
+
+	parts, err := previewer.compiler.CompileParts(context)
+	if err == nil {
+		preview.Text = findPart(parts, "text/plain")
+		preview.HTML = findPart(parts, "text/html")
+
+		return preview
+	}
+
+	// Render the parts on their own to find out which of them is broken. The
+	// other part's template is blanked rather than its value, so that a strict
+	// template can still refer to every value of the context.
+	textContext := context
+	textContext.HTMLTemplate = ""
+	parts, err = previewer.compiler.CompileParts(textContext)
+	if err != nil {
+		preview.Errors["text"] = err.Error()
+	}
+	preview.Text = findPart(parts, "text/plain")
+
+	htmlContext := context
+	htmlContext.TextTemplate = ""
+	parts, err = previewer.compiler.CompileParts(htmlContext)
+	if err != nil {
+		preview.Errors["html"] = err.Error()
+	}
+	preview.HTML = findPart(parts, "text/html")
+
+	return preview
+}
+
+func findPart(parts []mail.Part, contentType string) string {
+	for _, part := range parts {
+		if part.ContentType == contentType {
+			return part.Content
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,89 @@
+package services_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplatePreviewer", func() {
+	var (
+		previewer services.TemplatePreviewer
+		template  models.Template
+		context   common.MessageContext
+	)
+
+	BeforeEach(func() {
+		previewer = services.NewTemplatePreviewer(common.NewPackager(mocks.NewTemplatesLoader(), mocks.NewCloak()))
+
+		template = models.Template{
+			Subject: "CF Notification: {{.Subject}}",
+			Text:    "{{.Text}} from {{.SourceDescription}}",
+			HTML:    "<p>{{.Text}}</p>{{.HTML}}",
+		}
+
+		context = common.MessageContext{
+			Subject:           "Instance down",
+			Text:              "Your <instance> is down",
+			HTML:              "<b>down</b>",
+			HTMLComponents:    common.HTML{BodyContent: "<b>down</b>"},
+			SourceDescription: "Health Monitor",
+		}
+	})
+
+	It("renders the subject, text and html of the template", func() {
+		preview := previewer.Preview(template, context)
+
+		Expect(preview.Errors).To(BeEmpty())
+		Expect(preview.Subject).To(Equal("CF Notification: Instance down"))
+		Expect(preview.Text).To(Equal("Your <instance> is down from Health Monitor"))
+		Expect(preview.HTML).To(ContainSubstring("<p>Your &lt;instance&gt; is down</p><b>down</b>"))
+	})
+
+	It("only renders the parts the context has content for", func() {
+		context.HTML = ""
+
+		preview := previewer.Preview(template, context)
+
+		Expect(preview.Errors).To(BeEmpty())
+		Expect(preview.Text).To(Equal("Your <instance> is down from Health Monitor"))
+		Expect(preview.HTML).To(BeEmpty())
+	})
+
+	It("reports the parts that fail to render and still renders the others", func() {
+		template.Subject = "{{.Subject"
+		template.HTML = "<p>{{if .Text}}</p>"
+
+		preview := previewer.Preview(template, context)
+
+		Expect(preview.Errors).To(HaveKey("subject"))
+		Expect(preview.Errors).To(HaveKey("html"))
+		Expect(preview.Errors).NotTo(HaveKey("text"))
+		Expect(preview.Text).To(Equal("Your <instance> is down from Health Monitor"))
+	})
+
+	It("reports values missing from the context when the template is strict", func() {
+		template.Strict = true
+		template.Text = "{{.Text}} in {{.Space}}"
+
+		preview := previewer.Preview(template, context)
+
+		Expect(preview.Errors).To(HaveKeyWithValue("text", ContainSubstring(`map has no entry for key "Space"`)))
+		Expect(preview.Errors).NotTo(HaveKey("subject"))
+		Expect(preview.Errors).NotTo(HaveKey("html"))
+		Expect(preview.Text).To(BeEmpty())
+	})
+
+	It("renders values missing from the context as empty when the template is not strict", func() {
+		template.Text = "{{.Text}} in {{.Space}}"
+
+		preview := previewer.Preview(template, context)
+
+		Expect(preview.Errors).To(BeEmpty())
+		Expect(preview.Text).To(Equal("Your <instance> is down in "))
+	})
+})
@@ -0,0 +1,30 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type TemplateVersioner struct {
+	templatesRepo TemplatesRepo
+}
+
+func NewTemplateVersioner(templatesRepo TemplatesRepo) TemplateVersioner {
+	return TemplateVersioner{
+		templatesRepo: templatesRepo,
+	}
+}
+
+func (versioner TemplateVersioner) ListVersions(database DatabaseInterface, templateID string) ([]models.TemplateVersion, error) {
+	return versioner.templatesRepo.ListVersions(database.Connection(), templateID)
+}
+
+// Rollback restores the content of a prior version as a new version so the
+// history itself is never rewritten.
+func (versioner TemplateVersioner) Rollback(database DatabaseInterface, templateID string, version int) (models.Template, error) {
+	connection := database.Connection()
+
+	templateVersion, err := versioner.templatesRepo.FindVersion(connection, templateID, version)
+	if err != nil {
+		return models.Template{}, err
+	}
+
+	return versioner.templatesRepo.Restore(connection, templateID, templateVersion)
+}
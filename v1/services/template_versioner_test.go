@@ -0,0 +1,110 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplateVersioner", func() {
+	var (
+		conn          *mocks.Connection
+		database      *mocks.Database
+		templatesRepo *mocks.TemplatesRepo
+		versioner     services.TemplateVersioner
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		templatesRepo = mocks.NewTemplatesRepo()
+
+		versioner = services.NewTemplateVersioner(templatesRepo)
+	})
+
+	Describe("ListVersions", func() {
+		It("returns the versions of the template", func() {
+			templatesRepo.ListVersionsCall.Returns.Versions = []models.TemplateVersion{
+				{TemplateID: "some-template-id", Version: 2},
+				{TemplateID: "some-template-id", Version: 1},
+			}
+
+			versions, err := versioner.ListVersions(database, "some-template-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(Equal(templatesRepo.ListVersionsCall.Returns.Versions))
+
+			Expect(templatesRepo.ListVersionsCall.Receives.Connection).To(Equal(conn))
+			Expect(templatesRepo.ListVersionsCall.Receives.TemplateID).To(Equal("some-template-id"))
+		})
+
+		It("propagates errors from the repo", func() {
+			templatesRepo.ListVersionsCall.Returns.Error = errors.New("Boom!")
+
+			_, err := versioner.ListVersions(database, "some-template-id")
+			Expect(err).To(MatchError(errors.New("Boom!")))
+		})
+	})
+
+	Describe("Rollback", func() {
+		BeforeEach(func() {
+			templatesRepo.FindVersionCall.Returns.Version = models.TemplateVersion{
+				TemplateID: "some-template-id",
+				Version:    2,
+				Name:       "old name",
+				Subject:    "old subject",
+				Text:       "old text",
+				HTML:       "<p>old html</p>",
+				Metadata:   "{}",
+				Strict:     true,
+				Overridden: false,
+			}
+			templatesRepo.RestoreCall.Returns.Template = models.Template{
+				ID:      "some-template-id",
+				Version: 5,
+			}
+		})
+
+		It("restores the content of the version as a new version", func() {
+			template, err := versioner.Rollback(database, "some-template-id", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(template).To(Equal(models.Template{
+				ID:      "some-template-id",
+				Version: 5,
+			}))
+
+			Expect(templatesRepo.FindVersionCall.Receives.Connection).To(Equal(conn))
+			Expect(templatesRepo.FindVersionCall.Receives.TemplateID).To(Equal("some-template-id"))
+			Expect(templatesRepo.FindVersionCall.Receives.Version).To(Equal(2))
+
+			Expect(templatesRepo.RestoreCall.Receives.Connection).To(Equal(conn))
+			Expect(templatesRepo.RestoreCall.Receives.TemplateID).To(Equal("some-template-id"))
+			Expect(templatesRepo.RestoreCall.Receives.TemplateVersion).To(Equal(templatesRepo.FindVersionCall.Returns.Version))
+			Expect(templatesRepo.UpdateCall.Receives.TemplateID).To(BeEmpty())
+		})
+
+		Context("when the version cannot be found", func() {
+			It("returns the error without updating the template", func() {
+				templatesRepo.FindVersionCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+				_, err := versioner.Rollback(database, "some-template-id", 2)
+				Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+				Expect(templatesRepo.RestoreCall.Receives.TemplateID).To(BeEmpty())
+			})
+		})
+
+		Context("when the restore fails", func() {
+			It("returns the error", func() {
+				templatesRepo.RestoreCall.Returns.Error = errors.New("Boom!")
+
+				_, err := versioner.Rollback(database, "some-template-id", 2)
+				Expect(err).To(MatchError(errors.New("Boom!")))
+			})
+		})
+	})
+})
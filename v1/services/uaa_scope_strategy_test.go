@@ -82,6 +82,7 @@ var _ = Describe("UAA Scope Strategy", func() {
 							},
 						},
 						TemplateID: "some-template-id",
+						SendAt:     time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
 						Kind: services.DispatchKind{
 							ID:          "forgot_waterbottle",
 							Description: "Water Bottle Reminder",
@@ -111,6 +112,7 @@ var _ = Describe("UAA Scope Strategy", func() {
 						SourceDescription: "The Water Bottle System",
 						Text:              "Please make sure to leave your bottle in a place that is safe and dry",
 						TemplateID:        "some-template-id",
+						SendAt:            time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
 						HTML: services.HTML{
 							BodyContent:    "<p>The water bottle needs to be safe and dry</p>",
 							BodyAttributes: "some-html-body-attributes",
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/pivotal-golang/conceal"
+)
+
+type UnsubscribeLink struct {
+	UserGUID        string
+	ClientID        string
+	KindID          string
+	KindDescription string
+	Critical        bool
+}
+
+type kindFinder interface {
+	Find(connection models.ConnectionInterface, kindID string, clientID string) (models.Kind, error)
+}
+
+type Unsubscriber struct {
+	cloak                  conceal.CloakInterface
+	kindsRepo              kindFinder
+	unsubscribesRepo       UnsubscribesRepo
+	globalUnsubscribesRepo GlobalUnsubscribesRepo
+}
+
+func NewUnsubscriber(cloak conceal.CloakInterface, kindsRepo kindFinder, unsubscribesRepo UnsubscribesRepo, globalUnsubscribesRepo GlobalUnsubscribesRepo) Unsubscriber {
+	return Unsubscriber{
+		cloak:                  cloak,
+		kindsRepo:              kindsRepo,
+		unsubscribesRepo:       unsubscribesRepo,
+		globalUnsubscribesRepo: globalUnsubscribesRepo,
+	}
+}
+
+func (u Unsubscriber) Find(conn ConnectionInterface, unsubscribeID string) (UnsubscribeLink, error) {
+	plaintext, err := u.cloak.Unveil([]byte(unsubscribeID))
+	if err != nil {
+		return UnsubscribeLink{}, InvalidUnsubscribeIDError{}
+	}
+
+	parts := strings.Split(string(plaintext), "|")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return UnsubscribeLink{}, InvalidUnsubscribeIDError{}
+	}
+
+	link := UnsubscribeLink{
+		UserGUID: parts[0],
+		ClientID: parts[1],
+		KindID:   parts[2],
+	}
+
+	if link.KindID == "" {
+		return link, nil
+	}
+
+	kind, err := u.kindsRepo.Find(conn, link.KindID, link.ClientID)
+	if err != nil {
+		if _, ok := err.(models.NotFoundError); ok {
+			return UnsubscribeLink{}, MissingKindOrClientError{fmt.Errorf("The kind '%s' cannot be found for client '%s'", link.KindID, link.ClientID)}
+		}
+		return UnsubscribeLink{}, err
+	}
+
+	link.KindDescription = kind.Description
+	link.Critical = kind.Critical
+
+	return link, nil
+}
+
+func (u Unsubscriber) Unsubscribe(conn ConnectionInterface, unsubscribeID string, global bool) (UnsubscribeLink, error) {
+	link, err := u.Find(conn, unsubscribeID)
+	if err != nil {
+		return UnsubscribeLink{}, err
+	}
+
+	if global || link.KindID == "" {
+		return link, u.globalUnsubscribesRepo.Set(conn, link.UserGUID, true)
+	}
+
+	if link.Critical {
+		return UnsubscribeLink{}, CriticalKindError{fmt.Errorf("The kind '%s' for the '%s' client is critical and cannot be unsubscribed from", link.KindID, link.ClientID)}
+	}
+
+	return link, u.unsubscribesRepo.Set(conn, link.UserGUID, link.ClientID, link.KindID, true)
+}
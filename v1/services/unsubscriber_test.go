@@ -0,0 +1,145 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Unsubscriber", func() {
+	var (
+		unsubscriber           services.Unsubscriber
+		cloak                  *mocks.Cloak
+		kindsRepo              *mocks.KindsRepo
+		unsubscribesRepo       *mocks.UnsubscribesRepo
+		globalUnsubscribesRepo *mocks.GlobalUnsubscribesRepo
+		conn                   *mocks.Connection
+	)
+
+	BeforeEach(func() {
+		cloak = mocks.NewCloak()
+		cloak.UnveilCall.Returns.PlainText = []byte("some-user|some-client|some-kind")
+
+		kindsRepo = mocks.NewKindsRepo()
+		kindsRepo.FindCall.Returns.Kinds = []models.Kind{
+			{ID: "some-kind", ClientID: "some-client", Description: "Some Kind"},
+		}
+
+		unsubscribesRepo = mocks.NewUnsubscribesRepo()
+		globalUnsubscribesRepo = mocks.NewGlobalUnsubscribesRepo()
+		conn = mocks.NewConnection()
+
+		unsubscriber = services.NewUnsubscriber(cloak, kindsRepo, unsubscribesRepo, globalUnsubscribesRepo)
+	})
+
+	Describe("Find", func() {
+		It("unveils the unsubscribe ID and describes the kind", func() {
+			link, err := unsubscriber.Find(conn, "some-unsubscribe-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(link).To(Equal(services.UnsubscribeLink{
+				UserGUID:        "some-user",
+				ClientID:        "some-client",
+				KindID:          "some-kind",
+				KindDescription: "Some Kind",
+			}))
+
+			Expect(cloak.UnveilCall.Receives.CipherText).To(Equal([]byte("some-unsubscribe-id")))
+			Expect(kindsRepo.FindCall.Receives.Connection).To(Equal(conn))
+			Expect(kindsRepo.FindCall.Receives.KindID).To(Equal("some-kind"))
+			Expect(kindsRepo.FindCall.Receives.ClientID).To(Equal("some-client"))
+		})
+
+		It("returns an invalid unsubscribe ID error when the ID cannot be unveiled", func() {
+			cloak.UnveilCall.Returns.Error = errors.New("illegal base64 data")
+
+			_, err := unsubscriber.Find(conn, "garbage")
+			Expect(err).To(MatchError(services.InvalidUnsubscribeIDError{}))
+		})
+
+		It("returns an invalid unsubscribe ID error when the ID does not name a user and client", func() {
+			for _, plaintext := range []string{"garbage", "some-user|some-client", "|some-client|some-kind", "some-user||some-kind", "a|b|c|d"} {
+				cloak.UnveilCall.Returns.PlainText = []byte(plaintext)
+
+				_, err := unsubscriber.Find(conn, "some-unsubscribe-id")
+				Expect(err).To(MatchError(services.InvalidUnsubscribeIDError{}), plaintext)
+			}
+		})
+
+		It("returns a missing kind error when the kind no longer exists", func() {
+			kindsRepo.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			_, err := unsubscriber.Find(conn, "some-unsubscribe-id")
+			Expect(err).To(BeAssignableToTypeOf(services.MissingKindOrClientError{}))
+		})
+
+		It("does not look up a kind for notifications sent without one", func() {
+			cloak.UnveilCall.Returns.PlainText = []byte("some-user|some-client|")
+
+			link, err := unsubscriber.Find(conn, "some-unsubscribe-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(link.KindID).To(BeEmpty())
+			Expect(kindsRepo.FindCall.CallCount).To(Equal(0))
+		})
+	})
+
+	Describe("Unsubscribe", func() {
+		It("unsubscribes the user from the kind", func() {
+			link, err := unsubscriber.Unsubscribe(conn, "some-unsubscribe-id", false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(link.KindID).To(Equal("some-kind"))
+
+			Expect(unsubscribesRepo.SetCall.Receives.Connection).To(Equal(conn))
+			Expect(unsubscribesRepo.SetCall.Receives.UserID).To(Equal("some-user"))
+			Expect(unsubscribesRepo.SetCall.Receives.ClientID).To(Equal("some-client"))
+			Expect(unsubscribesRepo.SetCall.Receives.KindID).To(Equal("some-kind"))
+			Expect(unsubscribesRepo.SetCall.Receives.Unsubscribe).To(BeTrue())
+			Expect(globalUnsubscribesRepo.SetCall.Receives.UserID).To(BeEmpty())
+		})
+
+		It("unsubscribes the user from everything when asked to", func() {
+			_, err := unsubscriber.Unsubscribe(conn, "some-unsubscribe-id", true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(globalUnsubscribesRepo.SetCall.Receives.Connection).To(Equal(conn))
+			Expect(globalUnsubscribesRepo.SetCall.Receives.UserID).To(Equal("some-user"))
+			Expect(globalUnsubscribesRepo.SetCall.Receives.Unsubscribed).To(BeTrue())
+			Expect(unsubscribesRepo.SetCall.Receives.UserID).To(BeEmpty())
+		})
+
+		It("unsubscribes the user from everything when the notification had no kind", func() {
+			cloak.UnveilCall.Returns.PlainText = []byte("some-user|some-client|")
+
+			_, err := unsubscriber.Unsubscribe(conn, "some-unsubscribe-id", false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(globalUnsubscribesRepo.SetCall.Receives.UserID).To(Equal("some-user"))
+		})
+
+		It("refuses to unsubscribe the user from a critical kind", func() {
+			kindsRepo.FindCall.Returns.Kinds[0].Critical = true
+
+			_, err := unsubscriber.Unsubscribe(conn, "some-unsubscribe-id", false)
+			Expect(err).To(BeAssignableToTypeOf(services.CriticalKindError{}))
+			Expect(unsubscribesRepo.SetCall.Receives.UserID).To(BeEmpty())
+		})
+
+		It("returns errors from finding the link", func() {
+			cloak.UnveilCall.Returns.Error = errors.New("illegal base64 data")
+
+			_, err := unsubscriber.Unsubscribe(conn, "garbage", false)
+			Expect(err).To(MatchError(services.InvalidUnsubscribeIDError{}))
+		})
+
+		It("returns errors from the repo", func() {
+			unsubscribesRepo.SetCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := unsubscriber.Unsubscribe(conn, "some-unsubscribe-id", false)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+})
@@ -37,6 +37,7 @@ var _ = Describe("UserStrategy", func() {
 					ReplyTo: "reply-to@example.com",
 					Subject: "this is the subject",
 					Text:    "Please make sure to leave your bottle in a place that is safe and dry",
+					Locale:  "fr-CA",
 					HTML: services.HTML{
 						BodyContent:    "<p>The water bottle needs to be safe and dry</p>",
 						BodyAttributes: "some-html-body-attributes",
@@ -45,10 +46,12 @@ var _ = Describe("UserStrategy", func() {
 					},
 				},
 				TemplateID: "some-template-id",
+				SendAt:     time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
 				UAAHost:    "uaa",
 				Kind: services.DispatchKind{
 					ID:          "forgot_waterbottle",
 					Description: "Water Bottle Reminder",
+					Critical:    true,
 				},
 				Client: services.DispatchClient{
 					ID:          "mister-client",
@@ -71,9 +74,12 @@ var _ = Describe("UserStrategy", func() {
 				To:                "dr@strangelove.com",
 				KindID:            "forgot_waterbottle",
 				KindDescription:   "Water Bottle Reminder",
+				Critical:          true,
 				SourceDescription: "The Water Bottle System",
 				Text:              "Please make sure to leave your bottle in a place that is safe and dry",
 				TemplateID:        "some-template-id",
+				SendAt:            time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
+				Locale:            "fr-CA",
 				HTML: services.HTML{
 					BodyContent:    "<p>The water bottle needs to be safe and dry</p>",
 					BodyAttributes: "some-html-body-attributes",
@@ -0,0 +1,39 @@
+package bounces
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+type CreateHandler struct {
+	recorder    bounceRecorder
+	errorWriter errorWriter
+}
+
+func NewCreateHandler(recorder bounceRecorder, errWriter errorWriter) CreateHandler {
+	return CreateHandler{
+		recorder:    recorder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h CreateHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	dsn, err := mail.ParseDSN(req.Body)
+	if err != nil {
+		h.errorWriter.Write(w, webutil.ValidationError{Err: err})
+		return
+	}
+
+	connection := context.Get("database").(DatabaseInterface).Connection()
+
+	err = h.recorder.Record(connection, dsn)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,96 @@
+package bounces_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/bounces"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const dsn = "Content-Type: multipart/report; report-type=delivery-status; boundary=\"BOUNDARY\"\r\n" +
+	"\r\n" +
+	"--BOUNDARY\r\n" +
+	"Content-Type: message/delivery-status\r\n" +
+	"\r\n" +
+	"Reporting-MTA: dns; mx.example.com\r\n" +
+	"\r\n" +
+	"Final-Recipient: rfc822; gone@example.com\r\n" +
+	"Action: failed\r\n" +
+	"Status: 5.1.1\r\n" +
+	"\r\n" +
+	"--BOUNDARY\r\n" +
+	"Content-Type: text/rfc822-headers\r\n" +
+	"\r\n" +
+	"X-CF-Notification-ID: some-message-id\r\n" +
+	"--BOUNDARY--\r\n"
+
+var _ = Describe("CreateHandler", func() {
+	var (
+		handler     bounces.CreateHandler
+		recorder    *mocks.BounceRecorder
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		conn        *mocks.Connection
+		context     stack.Context
+	)
+
+	BeforeEach(func() {
+		recorder = mocks.NewBounceRecorder()
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		conn = mocks.NewConnection()
+		database := mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = bounces.NewCreateHandler(recorder, errorWriter)
+	})
+
+	It("records the delivery status notification", func() {
+		request, err := http.NewRequest("POST", "/bounces", strings.NewReader(dsn))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+		Expect(recorder.RecordCall.Receives.Connection).To(Equal(conn))
+		Expect(recorder.RecordCall.Receives.DSN).To(Equal(mail.DSN{
+			MessageID: "some-message-id",
+			Recipients: []mail.DSNRecipient{
+				{Recipient: "gone@example.com", Action: "failed", Status: "5.1.1"},
+			},
+		}))
+	})
+
+	It("writes a validation error when the body is not a delivery status notification", func() {
+		request, err := http.NewRequest("POST", "/bounces", strings.NewReader("Content-Type: text/plain\r\n\r\nhello"))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(webutil.ValidationError{}))
+		Expect(recorder.RecordCall.WasCalled).To(BeFalse())
+	})
+
+	It("delegates errors from recording the bounce to the error writer", func() {
+		recorder.RecordCall.Returns.Error = errors.New("BOOM!")
+
+		request, err := http.NewRequest("POST", "/bounces", strings.NewReader(dsn))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
@@ -0,0 +1,35 @@
+package bounces
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type muxer interface {
+	Handle(method, path string, handler stack.Handler, middleware ...stack.Middleware)
+}
+
+type errorWriter interface {
+	Write(writer http.ResponseWriter, err error)
+}
+
+type bounceRecorder interface {
+	Record(conn services.ConnectionInterface, dsn mail.DSN) error
+}
+
+type Routes struct {
+	RequestCounter                   stack.Middleware
+	RequestLogging                   stack.Middleware
+	DatabaseAllocator                stack.Middleware
+	NotificationsManageAuthenticator stack.Middleware
+
+	ErrorWriter    errorWriter
+	BounceRecorder bounceRecorder
+}
+
+func (r Routes) Register(m muxer) {
+	m.Handle("POST", "/bounces", NewCreateHandler(r.BounceRecorder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator, r.DatabaseAllocator)
+}
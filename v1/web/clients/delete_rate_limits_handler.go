@@ -0,0 +1,32 @@
+package clients
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type DeleteRateLimitsHandler struct {
+	repo        rateLimitsRepo
+	errorWriter errorWriter
+}
+
+func NewDeleteRateLimitsHandler(repo rateLimitsRepo, errWriter errorWriter) DeleteRateLimitsHandler {
+	return DeleteRateLimitsHandler{
+		repo:        repo,
+		errorWriter: errWriter,
+	}
+}
+
+func (h DeleteRateLimitsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	clientID := parseRateLimitsClientID(req.URL.Path)
+	database := context.Get("database").(DatabaseInterface)
+
+	err := h.repo.Delete(database.Connection(), clientID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,60 @@
+package clients_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/clients"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteRateLimitsHandler", func() {
+	var (
+		handler        clients.DeleteRateLimitsHandler
+		rateLimitsRepo *mocks.RateLimitsRepo
+		errorWriter    *mocks.ErrorWriter
+		context        stack.Context
+		connection     *mocks.Connection
+		writer         *httptest.ResponseRecorder
+		request        *http.Request
+	)
+
+	BeforeEach(func() {
+		rateLimitsRepo = mocks.NewRateLimitsRepo()
+		errorWriter = mocks.NewErrorWriter()
+		connection = mocks.NewConnection()
+		database := mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = connection
+		context = stack.NewContext()
+		context.Set("database", database)
+		writer = httptest.NewRecorder()
+
+		var err error
+		request, err = http.NewRequest("DELETE", "/clients/my-client/rate_limits", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = clients.NewDeleteRateLimitsHandler(rateLimitsRepo, errorWriter)
+	})
+
+	It("removes the client's override", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+		Expect(rateLimitsRepo.DeleteCall.Receives.Connection).To(Equal(connection))
+		Expect(rateLimitsRepo.DeleteCall.Receives.ClientID).To(Equal("my-client"))
+	})
+
+	It("delegates errors to the error writer", func() {
+		rateLimitsRepo.DeleteCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(models.NotFoundError{Err: errors.New("not found")}))
+	})
+})
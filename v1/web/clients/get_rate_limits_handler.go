@@ -0,0 +1,36 @@
+package clients
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type GetRateLimitsHandler struct {
+	finder      rateLimitsFinder
+	errorWriter errorWriter
+}
+
+func NewGetRateLimitsHandler(finder rateLimitsFinder, errWriter errorWriter) GetRateLimitsHandler {
+	return GetRateLimitsHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h GetRateLimitsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	clientID := parseRateLimitsClientID(req.URL.Path)
+	database := context.Get("database").(DatabaseInterface)
+
+	limits, err := h.finder.Limits(database.Connection(), clientID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeRateLimits(w, RateLimitsDocument{
+		ClientID:          clientID,
+		RequestsPerMinute: limits.RequestsPerMinute,
+		RecipientsPerHour: limits.RecipientsPerHour,
+	})
+}
@@ -0,0 +1,70 @@
+package clients_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/clients"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetRateLimitsHandler", func() {
+	var (
+		handler     clients.GetRateLimitsHandler
+		limiter     *mocks.RateLimiter
+		errorWriter *mocks.ErrorWriter
+		context     stack.Context
+		connection  *mocks.Connection
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+	)
+
+	BeforeEach(func() {
+		limiter = mocks.NewRateLimiter()
+		errorWriter = mocks.NewErrorWriter()
+		connection = mocks.NewConnection()
+		database := mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = connection
+		context = stack.NewContext()
+		context.Set("database", database)
+		writer = httptest.NewRecorder()
+
+		var err error
+		request, err = http.NewRequest("GET", "/clients/my-client/rate_limits", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = clients.NewGetRateLimitsHandler(limiter, errorWriter)
+	})
+
+	It("writes out the limits that apply to the client", func() {
+		limiter.LimitsCall.Returns.Limits = services.RateLimits{
+			RequestsPerMinute: 60,
+			RecipientsPerHour: 1000,
+		}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{
+			"client_id": "my-client",
+			"requests_per_minute": 60,
+			"recipients_per_hour": 1000
+		}`))
+		Expect(limiter.LimitsCall.Receives.Connection).To(Equal(connection))
+		Expect(limiter.LimitsCall.Receives.ClientID).To(Equal("my-client"))
+	})
+
+	It("delegates errors to the error writer", func() {
+		limiter.LimitsCall.Returns.Error = errors.New("BOOM!")
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
@@ -0,0 +1,41 @@
+package clients
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+var rateLimitsRouteRegex = regexp.MustCompile("/clients/(.*)/rate_limits")
+
+type rateLimitsFinder interface {
+	Limits(conn models.ConnectionInterface, clientID string) (services.RateLimits, error)
+}
+
+type rateLimitsRepo interface {
+	Upsert(conn models.ConnectionInterface, rateLimit models.RateLimit) (models.RateLimit, error)
+	Delete(conn models.ConnectionInterface, clientID string) error
+}
+
+type RateLimitsDocument struct {
+	ClientID          string `json:"client_id"`
+	RequestsPerMinute int    `json:"requests_per_minute"`
+	RecipientsPerHour int    `json:"recipients_per_hour"`
+}
+
+func parseRateLimitsClientID(path string) string {
+	return rateLimitsRouteRegex.FindStringSubmatch(path)[1]
+}
+
+func writeRateLimits(w http.ResponseWriter, document RateLimitsDocument) {
+	output, err := json.Marshal(document)
+	if err != nil {
+		panic(err) // No JSON we write into a response should ever panic
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(output)
+}
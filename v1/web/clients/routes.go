@@ -14,8 +14,13 @@ type Routes struct {
 
 	ErrorWriter      errorWriter
 	TemplateAssigner assignsTemplates
+	RateLimitsFinder rateLimitsFinder
+	RateLimitsRepo   rateLimitsRepo
 }
 
 func (r Routes) Register(m muxer) {
 	m.Handle("PUT", "/clients/{client_id}/template", NewAssignTemplateHandler(r.TemplateAssigner, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator, r.DatabaseAllocator)
+	m.Handle("GET", "/clients/{client_id}/rate_limits", NewGetRateLimitsHandler(r.RateLimitsFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator, r.DatabaseAllocator)
+	m.Handle("PUT", "/clients/{client_id}/rate_limits", NewUpdateRateLimitsHandler(r.RateLimitsRepo, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator, r.DatabaseAllocator)
+	m.Handle("DELETE", "/clients/{client_id}/rate_limits", NewDeleteRateLimitsHandler(r.RateLimitsRepo, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator, r.DatabaseAllocator)
 }
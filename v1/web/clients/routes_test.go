@@ -27,6 +27,8 @@ var _ = Describe("Routes", func() {
 
 			ErrorWriter:      mocks.NewErrorWriter(),
 			TemplateAssigner: mocks.NewTemplateAssigner(),
+			RateLimitsFinder: mocks.NewRateLimiter(),
+			RateLimitsRepo:   mocks.NewRateLimitsRepo(),
 		}.Register(muxer)
 	})
 
@@ -41,4 +43,40 @@ var _ = Describe("Routes", func() {
 		authenticator := s.Middleware[2].(middleware.Authenticator)
 		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
 	})
+
+	It("routes GET /clients/{client_id}/rate_limits", func() {
+		request, err := http.NewRequest("GET", "/clients/some-client-id/rate_limits", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		s := muxer.Match(request).(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(clients.GetRateLimitsHandler{}))
+		ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
+
+	It("routes PUT /clients/{client_id}/rate_limits", func() {
+		request, err := http.NewRequest("PUT", "/clients/some-client-id/rate_limits", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		s := muxer.Match(request).(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(clients.UpdateRateLimitsHandler{}))
+		ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
+
+	It("routes DELETE /clients/{client_id}/rate_limits", func() {
+		request, err := http.NewRequest("DELETE", "/clients/some-client-id/rate_limits", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		s := muxer.Match(request).(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(clients.DeleteRateLimitsHandler{}))
+		ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
 })
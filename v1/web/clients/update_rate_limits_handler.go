@@ -0,0 +1,64 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+type UpdateRateLimitsHandler struct {
+	repo        rateLimitsRepo
+	errorWriter errorWriter
+}
+
+func NewUpdateRateLimitsHandler(repo rateLimitsRepo, errWriter errorWriter) UpdateRateLimitsHandler {
+	return UpdateRateLimitsHandler{
+		repo:        repo,
+		errorWriter: errWriter,
+	}
+}
+
+func (h UpdateRateLimitsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	clientID := parseRateLimitsClientID(req.URL.Path)
+
+	var params struct {
+		RequestsPerMinute *int `json:"requests_per_minute"`
+		RecipientsPerHour *int `json:"recipients_per_hour"`
+	}
+	err := json.NewDecoder(req.Body).Decode(&params)
+	if err != nil {
+		h.errorWriter.Write(w, webutil.ParseError{})
+		return
+	}
+
+	if params.RequestsPerMinute == nil || params.RecipientsPerHour == nil {
+		h.errorWriter.Write(w, webutil.ValidationError{Err: errors.New(`"requests_per_minute" and "recipients_per_hour" are required`)})
+		return
+	}
+
+	if *params.RequestsPerMinute < 0 || *params.RecipientsPerHour < 0 {
+		h.errorWriter.Write(w, webutil.ValidationError{Err: errors.New(`"requests_per_minute" and "recipients_per_hour" cannot be negative`)})
+		return
+	}
+
+	database := context.Get("database").(DatabaseInterface)
+	rateLimit, err := h.repo.Upsert(database.Connection(), models.RateLimit{
+		ClientID:          clientID,
+		RequestsPerMinute: *params.RequestsPerMinute,
+		RecipientsPerHour: *params.RecipientsPerHour,
+	})
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeRateLimits(w, RateLimitsDocument{
+		ClientID:          clientID,
+		RequestsPerMinute: rateLimit.RequestsPerMinute,
+		RecipientsPerHour: rateLimit.RecipientsPerHour,
+	})
+}
@@ -0,0 +1,97 @@
+package clients_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/clients"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpdateRateLimitsHandler", func() {
+	var (
+		handler        clients.UpdateRateLimitsHandler
+		rateLimitsRepo *mocks.RateLimitsRepo
+		errorWriter    *mocks.ErrorWriter
+		context        stack.Context
+		connection     *mocks.Connection
+		writer         *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		rateLimitsRepo = mocks.NewRateLimitsRepo()
+		errorWriter = mocks.NewErrorWriter()
+		connection = mocks.NewConnection()
+		database := mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = connection
+		context = stack.NewContext()
+		context.Set("database", database)
+		writer = httptest.NewRecorder()
+
+		handler = clients.NewUpdateRateLimitsHandler(rateLimitsRepo, errorWriter)
+	})
+
+	newRequest := func(body string) *http.Request {
+		request, err := http.NewRequest("PUT", "/clients/my-client/rate_limits", strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		return request
+	}
+
+	It("stores the client's rate limits", func() {
+		rateLimitsRepo.UpsertCall.Returns.RateLimit = models.RateLimit{
+			ClientID:          "my-client",
+			RequestsPerMinute: 60,
+			RecipientsPerHour: 0,
+		}
+
+		handler.ServeHTTP(writer, newRequest(`{"requests_per_minute": 60, "recipients_per_hour": 0}`), context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{
+			"client_id": "my-client",
+			"requests_per_minute": 60,
+			"recipients_per_hour": 0
+		}`))
+		Expect(rateLimitsRepo.UpsertCall.Receives.Connection).To(Equal(connection))
+		Expect(rateLimitsRepo.UpsertCall.Receives.RateLimit).To(Equal(models.RateLimit{
+			ClientID:          "my-client",
+			RequestsPerMinute: 60,
+		}))
+	})
+
+	It("writes a parse error when the body is not JSON", func() {
+		handler.ServeHTTP(writer, newRequest(`banana`), context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(webutil.ParseError{}))
+	})
+
+	It("requires both limits", func() {
+		handler.ServeHTTP(writer, newRequest(`{"requests_per_minute": 60}`), context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(webutil.ValidationError{}))
+		Expect(rateLimitsRepo.UpsertCall.Receives.RateLimit).To(Equal(models.RateLimit{}))
+	})
+
+	It("rejects negative limits", func() {
+		handler.ServeHTTP(writer, newRequest(`{"requests_per_minute": -1, "recipients_per_hour": 10}`), context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(webutil.ValidationError{}))
+		Expect(rateLimitsRepo.UpsertCall.Receives.RateLimit).To(Equal(models.RateLimit{}))
+	})
+
+	It("delegates errors from the repo to the error writer", func() {
+		rateLimitsRepo.UpsertCall.Returns.Error = errors.New("BOOM!")
+
+		handler.ServeHTTP(writer, newRequest(`{"requests_per_minute": 60, "recipients_per_hour": 10}`), context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
@@ -0,0 +1,71 @@
+package deadletters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type errorWriter interface {
+	Write(writer http.ResponseWriter, err error)
+}
+
+type deadLetterCollection interface {
+	List() ([]gobble.DeadLetter, error)
+	Find(id int) (gobble.DeadLetter, error)
+	Replay(id int) (*gobble.Job, error)
+	Purge(id int) error
+	PurgeAll() (int, error)
+}
+
+type DeadLetterOutput struct {
+	ID           int                   `json:"id"`
+	JobID        int                   `json:"job_id"`
+	WorkerID     string                `json:"worker_id"`
+	Payload      string                `json:"payload"`
+	LastError    string                `json:"last_error"`
+	RetryCount   int                   `json:"retry_count"`
+	RetryHistory []gobble.RetryAttempt `json:"retry_history"`
+	CreatedAt    time.Time             `json:"created_at"`
+}
+
+func NewDeadLetterOutput(letter gobble.DeadLetter) DeadLetterOutput {
+	return DeadLetterOutput{
+		ID:           letter.ID,
+		JobID:        letter.JobID,
+		WorkerID:     letter.WorkerID,
+		Payload:      letter.Payload,
+		LastError:    letter.LastError,
+		RetryCount:   letter.RetryCount,
+		RetryHistory: letter.History(),
+		CreatedAt:    letter.CreatedAt,
+	}
+}
+
+func parseDeadLetterID(path string) (int, error) {
+	rawID := strings.Split(path, "/dead_letters/")[1]
+	rawID = strings.TrimSuffix(rawID, "/replay")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		return 0, models.NotFoundError{Err: fmt.Errorf("Dead letter with ID %q could not be found", rawID)}
+	}
+
+	return id, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, object interface{}) {
+	output, err := json.Marshal(object)
+	if err != nil {
+		panic(err) // No JSON we write into a response should ever panic
+	}
+
+	w.WriteHeader(status)
+	w.Write(output)
+}
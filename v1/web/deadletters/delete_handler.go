@@ -0,0 +1,35 @@
+package deadletters
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type DeleteHandler struct {
+	deadLetters deadLetterCollection
+	errorWriter errorWriter
+}
+
+func NewDeleteHandler(deadLetters deadLetterCollection, errWriter errorWriter) DeleteHandler {
+	return DeleteHandler{
+		deadLetters: deadLetters,
+		errorWriter: errWriter,
+	}
+}
+
+func (h DeleteHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	id, err := parseDeadLetterID(req.URL.Path)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	err = h.deadLetters.Purge(id)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,53 @@
+package deadletters_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadletters"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteHandler", func() {
+	var (
+		handler     deadletters.DeleteHandler
+		deadLetters *mocks.DeadLetters
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+	)
+
+	BeforeEach(func() {
+		deadLetters = mocks.NewDeadLetters()
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		var err error
+		request, err = http.NewRequest("DELETE", "/dead_letters/7", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = deadletters.NewDeleteHandler(deadLetters, errorWriter)
+	})
+
+	It("purges the dead letter", func() {
+		handler.ServeHTTP(writer, request, stack.NewContext())
+
+		Expect(deadLetters.PurgeCall.Receives.ID).To(Equal(7))
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+	})
+
+	Context("when the dead letter cannot be found", func() {
+		It("delegates to the error writer", func() {
+			deadLetters.PurgeCall.Returns.Error = gobble.DeadLetterNotFoundError{ID: 7}
+
+			handler.ServeHTTP(writer, request, stack.NewContext())
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(Equal(gobble.DeadLetterNotFoundError{ID: 7}))
+		})
+	})
+})
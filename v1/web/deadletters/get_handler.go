@@ -0,0 +1,35 @@
+package deadletters
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type GetHandler struct {
+	deadLetters deadLetterCollection
+	errorWriter errorWriter
+}
+
+func NewGetHandler(deadLetters deadLetterCollection, errWriter errorWriter) GetHandler {
+	return GetHandler{
+		deadLetters: deadLetters,
+		errorWriter: errWriter,
+	}
+}
+
+func (h GetHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	id, err := parseDeadLetterID(req.URL.Path)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	letter, err := h.deadLetters.Find(id)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NewDeadLetterOutput(letter))
+}
@@ -0,0 +1,100 @@
+package deadletters_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadletters"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetHandler", func() {
+	var (
+		handler     deadletters.GetHandler
+		deadLetters *mocks.DeadLetters
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		deadLetters = mocks.NewDeadLetters()
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		handler = deadletters.NewGetHandler(deadLetters, errorWriter)
+	})
+
+	It("writes out the dead letter", func() {
+		deadLetters.FindCall.Returns.DeadLetter = gobble.DeadLetter{
+			ID:         7,
+			JobID:      42,
+			Payload:    "{}",
+			LastError:  "boom",
+			RetryCount: 10,
+			CreatedAt:  time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+
+		request, err := http.NewRequest("GET", "/dead_letters/7", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, stack.NewContext())
+
+		Expect(deadLetters.FindCall.Receives.ID).To(Equal(7))
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{
+			"id": 7,
+			"job_id": 42,
+			"worker_id": "",
+			"payload": "{}",
+			"last_error": "boom",
+			"retry_count": 10,
+			"retry_history": [],
+			"created_at": "2015-01-01T00:00:00Z"
+		}`))
+	})
+
+	Context("when the id is not a number", func() {
+		It("writes a not found error", func() {
+			request, err := http.NewRequest("GET", "/dead_letters/banana", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler.ServeHTTP(writer, request, stack.NewContext())
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+
+	Context("when the dead letter cannot be found", func() {
+		It("delegates to the error writer", func() {
+			deadLetters.FindCall.Returns.Error = gobble.DeadLetterNotFoundError{ID: 7}
+
+			request, err := http.NewRequest("GET", "/dead_letters/7", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler.ServeHTTP(writer, request, stack.NewContext())
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(Equal(gobble.DeadLetterNotFoundError{ID: 7}))
+		})
+	})
+
+	Context("when finding fails for another reason", func() {
+		It("delegates to the error writer", func() {
+			deadLetters.FindCall.Returns.Error = errors.New("database is down")
+
+			request, err := http.NewRequest("GET", "/dead_letters/7", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler.ServeHTTP(writer, request, stack.NewContext())
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("database is down")))
+		})
+	})
+})
@@ -0,0 +1,38 @@
+package deadletters
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type ListHandler struct {
+	deadLetters deadLetterCollection
+	errorWriter errorWriter
+}
+
+func NewListHandler(deadLetters deadLetterCollection, errWriter errorWriter) ListHandler {
+	return ListHandler{
+		deadLetters: deadLetters,
+		errorWriter: errWriter,
+	}
+}
+
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	letters, err := h.deadLetters.List()
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	var document struct {
+		DeadLetters []DeadLetterOutput `json:"dead_letters"`
+	}
+	document.DeadLetters = []DeadLetterOutput{}
+
+	for _, letter := range letters {
+		document.DeadLetters = append(document.DeadLetters, NewDeadLetterOutput(letter))
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
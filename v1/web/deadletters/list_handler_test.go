@@ -0,0 +1,92 @@
+package deadletters_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadletters"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListHandler", func() {
+	var (
+		handler     deadletters.ListHandler
+		deadLetters *mocks.DeadLetters
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+	)
+
+	BeforeEach(func() {
+		deadLetters = mocks.NewDeadLetters()
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		var err error
+		request, err = http.NewRequest("GET", "/dead_letters", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = deadletters.NewListHandler(deadLetters, errorWriter)
+	})
+
+	It("writes out the list of dead letters", func() {
+		deadLetters.ListCall.Returns.DeadLetters = []gobble.DeadLetter{
+			{
+				ID:           1,
+				JobID:        42,
+				WorkerID:     "worker-1",
+				Payload:      `{"some":"payload"}`,
+				LastError:    "second failure",
+				RetryCount:   2,
+				RetryHistory: `[{"retry_count":0,"error":"first failure","failed_at":"2015-01-01T00:00:00Z"},{"retry_count":1,"error":"second failure","failed_at":"2015-01-01T00:01:00Z"}]`,
+				CreatedAt:    time.Date(2015, 1, 1, 0, 2, 0, 0, time.UTC),
+			},
+		}
+
+		handler.ServeHTTP(writer, request, stack.NewContext())
+
+		Expect(deadLetters.ListCall.WasCalled).To(BeTrue())
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{
+			"dead_letters": [
+				{
+					"id": 1,
+					"job_id": 42,
+					"worker_id": "worker-1",
+					"payload": "{\"some\":\"payload\"}",
+					"last_error": "second failure",
+					"retry_count": 2,
+					"retry_history": [
+						{"retry_count": 0, "error": "first failure", "failed_at": "2015-01-01T00:00:00Z"},
+						{"retry_count": 1, "error": "second failure", "failed_at": "2015-01-01T00:01:00Z"}
+					],
+					"created_at": "2015-01-01T00:02:00Z"
+				}
+			]
+		}`))
+	})
+
+	It("writes out an empty list when there are no dead letters", func() {
+		handler.ServeHTTP(writer, request, stack.NewContext())
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{"dead_letters": []}`))
+	})
+
+	Context("when listing the dead letters fails", func() {
+		It("delegates to the error writer", func() {
+			deadLetters.ListCall.Returns.Error = errors.New("database is down")
+
+			handler.ServeHTTP(writer, request, stack.NewContext())
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("database is down")))
+		})
+	})
+})
@@ -0,0 +1,29 @@
+package deadletters
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type PurgeHandler struct {
+	deadLetters deadLetterCollection
+	errorWriter errorWriter
+}
+
+func NewPurgeHandler(deadLetters deadLetterCollection, errWriter errorWriter) PurgeHandler {
+	return PurgeHandler{
+		deadLetters: deadLetters,
+		errorWriter: errWriter,
+	}
+}
+
+func (h PurgeHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	_, err := h.deadLetters.PurgeAll()
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,53 @@
+package deadletters_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadletters"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PurgeHandler", func() {
+	var (
+		handler     deadletters.PurgeHandler
+		deadLetters *mocks.DeadLetters
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+	)
+
+	BeforeEach(func() {
+		deadLetters = mocks.NewDeadLetters()
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		var err error
+		request, err = http.NewRequest("DELETE", "/dead_letters", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = deadletters.NewPurgeHandler(deadLetters, errorWriter)
+	})
+
+	It("purges all of the dead letters", func() {
+		handler.ServeHTTP(writer, request, stack.NewContext())
+
+		Expect(deadLetters.PurgeAllCall.WasCalled).To(BeTrue())
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+	})
+
+	Context("when the purge fails", func() {
+		It("delegates to the error writer", func() {
+			deadLetters.PurgeAllCall.Returns.Error = errors.New("database is down")
+
+			handler.ServeHTTP(writer, request, stack.NewContext())
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("database is down")))
+		})
+	})
+})
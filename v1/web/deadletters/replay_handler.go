@@ -0,0 +1,40 @@
+package deadletters
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type ReplayHandler struct {
+	deadLetters deadLetterCollection
+	errorWriter errorWriter
+}
+
+func NewReplayHandler(deadLetters deadLetterCollection, errWriter errorWriter) ReplayHandler {
+	return ReplayHandler{
+		deadLetters: deadLetters,
+		errorWriter: errWriter,
+	}
+}
+
+func (h ReplayHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	id, err := parseDeadLetterID(req.URL.Path)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	job, err := h.deadLetters.Replay(id)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	var document struct {
+		JobID int `json:"job_id"`
+	}
+	document.JobID = job.ID
+
+	writeJSON(w, http.StatusOK, document)
+}
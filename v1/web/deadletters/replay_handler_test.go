@@ -0,0 +1,70 @@
+package deadletters_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadletters"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReplayHandler", func() {
+	var (
+		handler     deadletters.ReplayHandler
+		deadLetters *mocks.DeadLetters
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		deadLetters = mocks.NewDeadLetters()
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		handler = deadletters.NewReplayHandler(deadLetters, errorWriter)
+	})
+
+	It("replays the dead letter and writes out the new job id", func() {
+		deadLetters.ReplayCall.Returns.Job = &gobble.Job{ID: 99}
+
+		request, err := http.NewRequest("POST", "/dead_letters/7/replay", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, stack.NewContext())
+
+		Expect(deadLetters.ReplayCall.Receives.ID).To(Equal(7))
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{"job_id": 99}`))
+	})
+
+	Context("when the id is not a number", func() {
+		It("writes a not found error", func() {
+			request, err := http.NewRequest("POST", "/dead_letters/banana/replay", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler.ServeHTTP(writer, request, stack.NewContext())
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+
+	Context("when the replay fails", func() {
+		It("delegates to the error writer", func() {
+			deadLetters.ReplayCall.Returns.Error = errors.New("queue is full")
+
+			request, err := http.NewRequest("POST", "/dead_letters/7/replay", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler.ServeHTTP(writer, request, stack.NewContext())
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("queue is full")))
+		})
+	})
+})
@@ -0,0 +1,24 @@
+package deadletters
+
+import "github.com/ryanmoran/stack"
+
+type muxer interface {
+	Handle(method, path string, handler stack.Handler, middleware ...stack.Middleware)
+}
+
+type Routes struct {
+	RequestCounter                   stack.Middleware
+	RequestLogging                   stack.Middleware
+	NotificationsManageAuthenticator stack.Middleware
+
+	ErrorWriter errorWriter
+	DeadLetters deadLetterCollection
+}
+
+func (r Routes) Register(m muxer) {
+	m.Handle("GET", "/dead_letters", NewListHandler(r.DeadLetters, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator)
+	m.Handle("DELETE", "/dead_letters", NewPurgeHandler(r.DeadLetters, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator)
+	m.Handle("GET", "/dead_letters/{dead_letter_id}", NewGetHandler(r.DeadLetters, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator)
+	m.Handle("DELETE", "/dead_letters/{dead_letter_id}", NewDeleteHandler(r.DeadLetters, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator)
+	m.Handle("POST", "/dead_letters/{dead_letter_id}/replay", NewReplayHandler(r.DeadLetters, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator)
+}
@@ -0,0 +1,38 @@
+package messages
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type messageCanceller interface {
+	Cancel(database services.DatabaseInterface, messageID, clientID string) error
+}
+
+type CancelHandler struct {
+	canceller   messageCanceller
+	errorWriter errorWriter
+}
+
+func NewCancelHandler(canceller messageCanceller, errWriter errorWriter) CancelHandler {
+	return CancelHandler{
+		canceller:   canceller,
+		errorWriter: errWriter,
+	}
+}
+
+func (h CancelHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	messageID := strings.Split(req.URL.Path, "/messages/")[1]
+	clientID, _ := context.Get("client_id").(string)
+
+	err := h.canceller.Cancel(context.Get("database").(DatabaseInterface), messageID, clientID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,59 @@
+package messages_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/messages"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CancelHandler", func() {
+	var (
+		handler     messages.CancelHandler
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+		canceller   *mocks.MessageCanceller
+		database    *mocks.Database
+		context     stack.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		errorWriter = mocks.NewErrorWriter()
+		canceller = mocks.NewMessageCanceller()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+		context.Set("client_id", "some-client")
+
+		request, err = http.NewRequest("DELETE", "/messages/message-123", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = messages.NewCancelHandler(canceller, errorWriter)
+	})
+
+	It("cancels the message on behalf of the requesting client", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+		Expect(canceller.CancelCall.Receives.Database).To(Equal(database))
+		Expect(canceller.CancelCall.Receives.MessageID).To(Equal("message-123"))
+		Expect(canceller.CancelCall.Receives.ClientID).To(Equal("some-client"))
+	})
+
+	It("delegates errors to the error writer", func() {
+		canceller.CancelCall.Returns.Error = errors.New("BOOM!")
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(errors.New("BOOM!")))
+	})
+})
@@ -0,0 +1,61 @@
+package messages
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type messageTimelineFinder interface {
+	Timeline(services.DatabaseInterface, string) (services.Message, error)
+}
+
+type EventsHandler struct {
+	finder      messageTimelineFinder
+	errorWriter errorWriter
+}
+
+func NewEventsHandler(finder messageTimelineFinder, errWriter errorWriter) EventsHandler {
+	return EventsHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h EventsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	messageID := strings.TrimSuffix(strings.Split(req.URL.Path, "/messages/")[1], "/events")
+
+	message, err := h.finder.Timeline(context.Get("database").(DatabaseInterface), messageID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	type event struct {
+		Event     string `json:"event"`
+		Detail    string `json:"detail"`
+		CreatedAt string `json:"created_at"`
+	}
+
+	var document struct {
+		MessageID string  `json:"message_id"`
+		Status    string  `json:"status"`
+		Events    []event `json:"events"`
+	}
+	document.MessageID = messageID
+	document.Status = message.Status
+	document.Events = []event{}
+
+	for _, e := range message.Events {
+		document.Events = append(document.Events, event{
+			Event:     e.Event,
+			Detail:    e.Detail,
+			CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
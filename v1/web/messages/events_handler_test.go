@@ -0,0 +1,99 @@
+package messages_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/messages"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EventsHandler", func() {
+	var (
+		handler       messages.EventsHandler
+		errorWriter   *mocks.ErrorWriter
+		writer        *httptest.ResponseRecorder
+		request       *http.Request
+		messageFinder *mocks.MessageFinder
+		database      *mocks.Database
+		context       stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		messageFinder = mocks.NewMessageFinder()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		var err error
+		request, err = http.NewRequest("GET", "/messages/message-123/events", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = messages.NewEventsHandler(messageFinder, errorWriter)
+	})
+
+	It("returns the timeline of the message", func() {
+		createdAt := time.Date(2015, time.June, 8, 14, 40, 12, 0, time.UTC)
+		messageFinder.TimelineCall.Returns.Message = services.Message{
+			Status: "failed",
+			Events: []services.MessageEvent{
+				{Event: "queued", CreatedAt: createdAt},
+				{Event: "failed", Detail: "550 mailbox unavailable", CreatedAt: createdAt.Add(time.Minute)},
+			},
+		}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.Bytes()).To(MatchJSON(`{
+			"message_id": "message-123",
+			"status": "failed",
+			"events": [
+				{
+					"event": "queued",
+					"detail": "",
+					"created_at": "2015-06-08T14:40:12Z"
+				},
+				{
+					"event": "failed",
+					"detail": "550 mailbox unavailable",
+					"created_at": "2015-06-08T14:41:12Z"
+				}
+			]
+		}`))
+
+		Expect(messageFinder.TimelineCall.Receives.Database).To(Equal(database))
+		Expect(messageFinder.TimelineCall.Receives.MessageID).To(Equal("message-123"))
+	})
+
+	It("returns an empty list when no events have been recorded", func() {
+		messageFinder.TimelineCall.Returns.Message = services.Message{Status: "queued"}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.Bytes()).To(MatchJSON(`{
+			"message_id": "message-123",
+			"status": "queued",
+			"events": []
+		}`))
+	})
+
+	Context("when the finder errors", func() {
+		It("delegates to the error writer", func() {
+			messageFinder.TimelineCall.Returns.Error = errors.New("not found")
+
+			handler.ServeHTTP(writer, request, context)
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("not found")))
+		})
+	})
+})
@@ -0,0 +1,130 @@
+package messages
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 500
+)
+
+type messageLister interface {
+	List(services.DatabaseInterface, models.MessagesFilter) ([]services.Message, int, error)
+}
+
+type ListHandler struct {
+	lister      messageLister
+	errorWriter errorWriter
+}
+
+func NewListHandler(lister messageLister, errWriter errorWriter) ListHandler {
+	return ListHandler{
+		lister:      lister,
+		errorWriter: errWriter,
+	}
+}
+
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	filter, err := parseMessagesFilter(req)
+	if err != nil {
+		h.errorWriter.Write(w, webutil.ValidationError{Err: err})
+		return
+	}
+
+	messages, total, err := h.lister.List(context.Get("database").(DatabaseInterface), filter)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	type message struct {
+		ID            string `json:"id"`
+		Status        string `json:"status"`
+		ClientID      string `json:"client_id"`
+		KindID        string `json:"kind_id"`
+		UserGUID      string `json:"user_guid"`
+		Email         string `json:"email"`
+		VCAPRequestID string `json:"vcap_request_id"`
+		CreatedAt     string `json:"created_at"`
+		UpdatedAt     string `json:"updated_at"`
+	}
+
+	var document struct {
+		Messages []message `json:"messages"`
+		Total    int       `json:"total"`
+		Limit    int       `json:"limit"`
+		Offset   int       `json:"offset"`
+	}
+	document.Messages = []message{}
+	document.Total = total
+	document.Limit = filter.Limit
+	document.Offset = filter.Offset
+
+	for _, m := range messages {
+		document.Messages = append(document.Messages, message{
+			ID:            m.ID,
+			Status:        m.Status,
+			ClientID:      m.ClientID,
+			KindID:        m.KindID,
+			UserGUID:      m.UserGUID,
+			Email:         m.Email,
+			VCAPRequestID: m.VCAPRequestID,
+			CreatedAt:     m.CreatedAt.UTC().Format(time.RFC3339),
+			UpdatedAt:     m.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
+
+func parseMessagesFilter(req *http.Request) (models.MessagesFilter, error) {
+	query := req.URL.Query()
+
+	filter := models.MessagesFilter{
+		ClientID: query.Get("client_id"),
+		KindID:   query.Get("kind_id"),
+		UserGUID: query.Get("user_guid"),
+		Email:    query.Get("email"),
+		Limit:    DefaultListLimit,
+	}
+
+	var err error
+	if value := query.Get("since"); value != "" {
+		filter.CreatedAfter, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("since must be an RFC 3339 timestamp")
+		}
+	}
+
+	if value := query.Get("until"); value != "" {
+		filter.CreatedBefore, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("until must be an RFC 3339 timestamp")
+		}
+	}
+
+	if value := query.Get("limit"); value != "" {
+		filter.Limit, err = strconv.Atoi(value)
+		if err != nil || filter.Limit < 1 || filter.Limit > MaxListLimit {
+			return filter, fmt.Errorf("limit must be an integer between 1 and %d", MaxListLimit)
+		}
+	}
+
+	if value := query.Get("offset"); value != "" {
+		filter.Offset, err = strconv.Atoi(value)
+		if err != nil || filter.Offset < 0 {
+			return filter, fmt.Errorf("offset must be a non-negative integer")
+		}
+	}
+
+	return filter, nil
+}
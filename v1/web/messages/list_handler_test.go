@@ -0,0 +1,148 @@
+package messages_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/messages"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListHandler", func() {
+	var (
+		handler       messages.ListHandler
+		errorWriter   *mocks.ErrorWriter
+		writer        *httptest.ResponseRecorder
+		messageFinder *mocks.MessageFinder
+		database      *mocks.Database
+		context       stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		messageFinder = mocks.NewMessageFinder()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = messages.NewListHandler(messageFinder, errorWriter)
+	})
+
+	serve := func(url string) {
+		request, err := http.NewRequest("GET", url, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+	}
+
+	It("returns the page of messages matching the filters", func() {
+		createdAt := time.Date(2015, time.June, 8, 14, 40, 12, 0, time.UTC)
+		messageFinder.ListCall.Returns.Messages = []services.Message{
+			{
+				ID:            "message-1",
+				Status:        "delivered",
+				ClientID:      "some-client",
+				KindID:        "some-kind",
+				UserGUID:      "some-user",
+				Email:         "user@example.com",
+				VCAPRequestID: "some-request-id",
+				CreatedAt:     createdAt,
+				UpdatedAt:     createdAt.Add(time.Minute),
+			},
+		}
+		messageFinder.ListCall.Returns.Total = 3
+
+		serve("/messages?client_id=some-client&kind_id=some-kind&user_guid=some-user&email=user@example.com&since=2015-06-08T00:00:00Z&until=2015-06-09T00:00:00Z&limit=1&offset=2")
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.Bytes()).To(MatchJSON(`{
+			"messages": [
+				{
+					"id": "message-1",
+					"status": "delivered",
+					"client_id": "some-client",
+					"kind_id": "some-kind",
+					"user_guid": "some-user",
+					"email": "user@example.com",
+					"vcap_request_id": "some-request-id",
+					"created_at": "2015-06-08T14:40:12Z",
+					"updated_at": "2015-06-08T14:41:12Z"
+				}
+			],
+			"total": 3,
+			"limit": 1,
+			"offset": 2
+		}`))
+
+		Expect(messageFinder.ListCall.Receives.Database).To(Equal(database))
+		Expect(messageFinder.ListCall.Receives.Filter).To(Equal(models.MessagesFilter{
+			ClientID:      "some-client",
+			KindID:        "some-kind",
+			UserGUID:      "some-user",
+			Email:         "user@example.com",
+			CreatedAfter:  time.Date(2015, time.June, 8, 0, 0, 0, 0, time.UTC),
+			CreatedBefore: time.Date(2015, time.June, 9, 0, 0, 0, 0, time.UTC),
+			Limit:         1,
+			Offset:        2,
+		}))
+	})
+
+	It("uses the default page size when no limit is given", func() {
+		serve("/messages")
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.Bytes()).To(MatchJSON(`{
+			"messages": [],
+			"total": 0,
+			"limit": 50,
+			"offset": 0
+		}`))
+		Expect(messageFinder.ListCall.Receives.Filter).To(Equal(models.MessagesFilter{Limit: messages.DefaultListLimit}))
+	})
+
+	Context("when the query parameters are invalid", func() {
+		It("rejects a malformed since timestamp", func() {
+			serve("/messages?since=yesterday")
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New("since must be an RFC 3339 timestamp")}))
+			Expect(messageFinder.ListCall.Receives.Database).To(BeNil())
+		})
+
+		It("rejects a malformed until timestamp", func() {
+			serve("/messages?until=tomorrow")
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New("until must be an RFC 3339 timestamp")}))
+		})
+
+		It("rejects a limit above the maximum", func() {
+			serve("/messages?limit=501")
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New("limit must be an integer between 1 and 500")}))
+		})
+
+		It("rejects a negative offset", func() {
+			serve("/messages?offset=-1")
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New("offset must be a non-negative integer")}))
+		})
+	})
+
+	Context("when the lister errors", func() {
+		It("delegates to the error writer", func() {
+			messageFinder.ListCall.Returns.Error = errors.New("db is down")
+
+			serve("/messages")
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("db is down")))
+		})
+	})
+})
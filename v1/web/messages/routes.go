@@ -10,12 +10,19 @@ type Routes struct {
 	RequestCounter                               stack.Middleware
 	RequestLogging                               stack.Middleware
 	NotificationsWriteOrEmailsWriteAuthenticator stack.Middleware
+	NotificationsAdminAuthenticator              stack.Middleware
 	DatabaseAllocator                            stack.Middleware
 
-	MessageFinder messageFinder
-	ErrorWriter   errorWriter
+	MessageFinder         messageFinder
+	MessageTimelineFinder messageTimelineFinder
+	MessageLister         messageLister
+	MessageCanceller      messageCanceller
+	ErrorWriter           errorWriter
 }
 
 func (r Routes) Register(m muxer) {
+	m.Handle("GET", "/messages", NewListHandler(r.MessageLister, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
 	m.Handle("GET", "/messages/{message_id}", NewGetHandler(r.MessageFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsWriteOrEmailsWriteAuthenticator, r.DatabaseAllocator)
+	m.Handle("DELETE", "/messages/{message_id}", NewCancelHandler(r.MessageCanceller, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsWriteOrEmailsWriteAuthenticator, r.DatabaseAllocator)
+	m.Handle("GET", "/messages/{message_id}/events", NewEventsHandler(r.MessageTimelineFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsWriteOrEmailsWriteAuthenticator, r.DatabaseAllocator)
 }
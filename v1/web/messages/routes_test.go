@@ -24,12 +24,28 @@ var _ = Describe("Routes", func() {
 			RequestLogging:    middleware.RequestLogging{},
 			DatabaseAllocator: middleware.DatabaseAllocator{},
 			NotificationsWriteOrEmailsWriteAuthenticator: middleware.Authenticator{Scopes: []string{"notifications.write", "emails.write"}},
+			NotificationsAdminAuthenticator:              middleware.Authenticator{Scopes: []string{"notifications.admin"}},
 
-			ErrorWriter:   mocks.NewErrorWriter(),
-			MessageFinder: mocks.NewMessageFinder(),
+			ErrorWriter:           mocks.NewErrorWriter(),
+			MessageFinder:         mocks.NewMessageFinder(),
+			MessageTimelineFinder: mocks.NewMessageFinder(),
+			MessageLister:         mocks.NewMessageFinder(),
+			MessageCanceller:      mocks.NewMessageCanceller(),
 		}.Register(muxer)
 	})
 
+	It("routes GET /messages", func() {
+		request, err := http.NewRequest("GET", "/messages", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		s := muxer.Match(request).(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(messages.ListHandler{}))
+		ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(ConsistOf([]string{"notifications.admin"}))
+	})
+
 	It("routes GET /messages/{message_id}", func() {
 		request, err := http.NewRequest("GET", "/messages/some-message-id", nil)
 		Expect(err).NotTo(HaveOccurred())
@@ -41,4 +57,28 @@ var _ = Describe("Routes", func() {
 		authenticator := s.Middleware[2].(middleware.Authenticator)
 		Expect(authenticator.Scopes).To(ConsistOf([]string{"notifications.write", "emails.write"}))
 	})
+
+	It("routes DELETE /messages/{message_id}", func() {
+		request, err := http.NewRequest("DELETE", "/messages/some-message-id", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		s := muxer.Match(request).(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(messages.CancelHandler{}))
+		ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(ConsistOf([]string{"notifications.write", "emails.write"}))
+	})
+
+	It("routes GET /messages/{message_id}/events", func() {
+		request, err := http.NewRequest("GET", "/messages/some-message-id/events", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		s := muxer.Match(request).(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(messages.EventsHandler{}))
+		ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(ConsistOf([]string{"notifications.write", "emails.write"}))
+	})
 })
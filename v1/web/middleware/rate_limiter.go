@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/rcrowley/go-metrics"
+	"github.com/ryanmoran/stack"
+)
+
+type requestLimiter interface {
+	AllowRequest(conn models.ConnectionInterface, clientID string) error
+}
+
+// RateLimiter must run after an Authenticator, which sets the client_id, and
+// after the DatabaseAllocator, which provides the client's limits.
+type RateLimiter struct {
+	Limiter requestLimiter
+}
+
+func NewRateLimiter(limiter requestLimiter) RateLimiter {
+	return RateLimiter{
+		Limiter: limiter,
+	}
+}
+
+func (ware RateLimiter) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) bool {
+	clientID, _ := context.Get("client_id").(string)
+	database := context.Get("database").(models.DatabaseInterface)
+
+	err := ware.Limiter.AllowRequest(database.Connection(), clientID)
+	switch err := err.(type) {
+	case nil:
+		return true
+	case services.RateLimitExceededError:
+		metrics.GetOrRegisterCounter("notifications.web.rate-limited", nil).Inc(1)
+		w.Header().Set("Retry-After", strconv.Itoa(err.RetryAfterSeconds()))
+		return ware.Error(w, http.StatusTooManyRequests, err.Error())
+	default:
+		return ware.Error(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func (ware RateLimiter) Error(w http.ResponseWriter, code int, message string) bool {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string][]string{
+		"errors": []string{message},
+	})
+	return false
+}
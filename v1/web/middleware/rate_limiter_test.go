@@ -0,0 +1,79 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/middleware"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var (
+		ware     middleware.RateLimiter
+		limiter  *mocks.RateLimiter
+		writer   *httptest.ResponseRecorder
+		request  *http.Request
+		conn     *mocks.Connection
+		context  stack.Context
+		database *mocks.Database
+	)
+
+	BeforeEach(func() {
+		limiter = mocks.NewRateLimiter()
+		writer = httptest.NewRecorder()
+		request = &http.Request{}
+
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		context = stack.NewContext()
+		context.Set("client_id", "some-client")
+		context.Set("database", database)
+
+		ware = middleware.NewRateLimiter(limiter)
+	})
+
+	It("allows requests that are within the client's limit", func() {
+		result := ware.ServeHTTP(writer, request, context)
+		Expect(result).To(BeTrue())
+
+		Expect(limiter.AllowRequestCall.Receives.Connection).To(Equal(conn))
+		Expect(limiter.AllowRequestCall.Receives.ClientID).To(Equal("some-client"))
+	})
+
+	It("rejects requests over the limit with a 429 and a Retry-After header", func() {
+		limiter.AllowRequestCall.Returns.Error = services.RateLimitExceededError{
+			ClientID:   "some-client",
+			Limit:      "60 requests per minute",
+			RetryAfter: 2500 * time.Millisecond,
+		}
+
+		result := ware.ServeHTTP(writer, request, context)
+		Expect(result).To(BeFalse())
+
+		Expect(writer.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(writer.Header().Get("Retry-After")).To(Equal("3"))
+		Expect(writer.Body).To(MatchJSON(`{
+			"errors": ["Client \"some-client\" has exceeded its rate limit of 60 requests per minute"]
+		}`))
+	})
+
+	It("responds with a 500 when the limits cannot be loaded", func() {
+		limiter.AllowRequestCall.Returns.Error = errors.New("BOOM!")
+
+		result := ware.ServeHTTP(writer, request, context)
+		Expect(result).To(BeFalse())
+
+		Expect(writer.Code).To(Equal(http.StatusInternalServerError))
+		Expect(writer.Body).To(MatchJSON(`{"errors": ["BOOM!"]}`))
+	})
+})
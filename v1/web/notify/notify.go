@@ -1,8 +1,11 @@
 package notify
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -24,15 +27,28 @@ type registrar interface {
 	Prune(services.ConnectionInterface, models.Client, []models.Kind) error
 }
 
+type idempotentRequests interface {
+	Begin(conn services.ConnectionInterface, clientID, key, requestHash string) ([]byte, bool, error)
+	Finish(conn services.ConnectionInterface, clientID, key string, response []byte) error
+	Abandon(conn services.ConnectionInterface, clientID, key string) error
+}
+
+const (
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
 type Notify struct {
-	finder    clientAndKindFinder
-	registrar registrar
+	finder             clientAndKindFinder
+	registrar          registrar
+	idempotentRequests idempotentRequests
 }
 
-func NewNotify(finder clientAndKindFinder, registrar registrar) Notify {
+func NewNotify(finder clientAndKindFinder, registrar registrar, idempotentRequests idempotentRequests) Notify {
 	return Notify{
-		finder:    finder,
-		registrar: registrar,
+		finder:             finder,
+		registrar:          registrar,
+		idempotentRequests: idempotentRequests,
 	}
 }
 
@@ -66,6 +82,44 @@ func (h Notify) Execute(connection ConnectionInterface, req *http.Request, conte
 	}
 	uaaHost := tokenIssuerURL.Scheme + "://" + tokenIssuerURL.Host
 
+	idempotencyKey := req.Header.Get(IdempotencyKeyHeader)
+	if idempotencyKey == "" {
+		idempotencyKey = parameters.IdempotencyKey
+	}
+
+	if idempotencyKey == "" {
+		return h.dispatch(connection, context, guid, strategy, parameters, claims, clientID, uaaHost, vcapRequestID, requestReceivedTime)
+	}
+
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		return []byte{}, webutil.ValidationError{Err: fmt.Errorf("%q must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength)}
+	}
+
+	output, replayed, err := h.idempotentRequests.Begin(connection, clientID, idempotencyKey, requestHash(req.URL.Path, parameters))
+	if err != nil {
+		return []byte{}, err
+	}
+
+	if replayed {
+		return output, nil
+	}
+
+	output, err = h.dispatch(connection, context, guid, strategy, parameters, claims, clientID, uaaHost, vcapRequestID, requestReceivedTime)
+	if err != nil {
+		h.idempotentRequests.Abandon(connection, clientID, idempotencyKey)
+		return []byte{}, err
+	}
+
+	// The notification has already been enqueued, so failing to record the
+	// response is not reported back to the client.
+	h.idempotentRequests.Finish(connection, clientID, idempotencyKey, output)
+
+	return output, nil
+}
+
+func (h Notify) dispatch(connection ConnectionInterface, context stack.Context, guid string, strategy Dispatcher, parameters NotifyParams,
+	claims jwt.MapClaims, clientID, uaaHost, vcapRequestID string, requestReceivedTime time.Time) ([]byte, error) {
+
 	client, kind, err := h.finder.ClientAndKind(context.Get("database").(DatabaseInterface), clientID, parameters.KindID)
 	if err != nil {
 		return []byte{}, err
@@ -93,8 +147,10 @@ func (h Notify) Execute(connection ConnectionInterface, req *http.Request, conte
 		Kind: services.DispatchKind{
 			ID:          parameters.KindID,
 			Description: kind.Description,
+			Critical:    kind.Critical,
 		},
 		UAAHost: uaaHost,
+		SendAt:  parameters.ParsedSendAt,
 		VCAPRequest: services.DispatchVCAPRequest{
 			ID:          vcapRequestID,
 			ReceiptTime: requestReceivedTime,
@@ -104,6 +160,7 @@ func (h Notify) Execute(connection ConnectionInterface, req *http.Request, conte
 			ReplyTo: parameters.ReplyTo,
 			Subject: parameters.Subject,
 			Text:    parameters.Text,
+			Locale:  parameters.Locale,
 			HTML: services.HTML{
 				BodyContent:    parameters.ParsedHTML.BodyContent,
 				BodyAttributes: parameters.ParsedHTML.BodyAttributes,
@@ -124,6 +181,38 @@ func (h Notify) Execute(connection ConnectionInterface, req *http.Request, conte
 	return output, nil
 }
 
+func requestHash(path string, parameters NotifyParams) string {
+	document, err := json.Marshal(struct {
+		Path    string
+		ReplyTo string
+		Subject string
+		Text    string
+		HTML    string
+		KindID  string
+		To      string
+		Role    string
+		SendAt  string
+		Locale  string
+	}{
+		Path:    path,
+		ReplyTo: parameters.ReplyTo,
+		Subject: parameters.Subject,
+		Text:    parameters.Text,
+		HTML:    parameters.RawHTML,
+		KindID:  parameters.KindID,
+		To:      parameters.To,
+		Role:    parameters.Role,
+		SendAt:  parameters.SendAt,
+		Locale:  parameters.Locale,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	sum := sha256.Sum256(document)
+	return hex.EncodeToString(sum[:])
+}
+
 func (h Notify) hasCriticalNotificationsWriteScope(elements interface{}) bool {
 	for _, elem := range elements.([]interface{}) {
 		if elem.(string) == "critical_notifications.write" {
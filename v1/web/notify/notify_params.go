@@ -6,6 +6,7 @@ import (
 	"io"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
@@ -26,8 +27,13 @@ type NotifyParams struct {
 	KindID  string `json:"kind_id"`
 	To      string `json:"to"`
 	Role    string `json:"role"`
+	SendAt  string `json:"send_at"`
+	Locale  string `json:"locale"`
+
+	IdempotencyKey string `json:"idempotency_key"`
 
 	ParsedHTML        HTML
+	ParsedSendAt      time.Time
 	KindDescription   string
 	SourceDescription string
 	Errors            []string
@@ -53,9 +59,24 @@ func NewNotifyParams(body io.ReadCloser) (NotifyParams, error) {
 		return notify, err
 	}
 
+	notify.parseSendAt()
+
 	return notify, nil
 }
 
+func (notify *NotifyParams) parseSendAt() {
+	if notify.SendAt == "" {
+		return
+	}
+
+	sendAt, err := time.Parse(time.RFC3339, notify.SendAt)
+	if err != nil {
+		return
+	}
+
+	notify.ParsedSendAt = sendAt.UTC()
+}
+
 func (notify *NotifyParams) parseRequestBody(body io.ReadCloser) error {
 	defer body.Close()
 
@@ -4,6 +4,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/v1/web/notify"
 
@@ -87,6 +88,32 @@ var _ = Describe("NotifyParams", func() {
 			})
 		})
 
+		Describe("send_at field parsing", func() {
+			It("leaves the send time empty if it is not specified", func() {
+				parameters, err := notify.NewNotifyParams(ioutil.NopCloser(strings.NewReader("{}")))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parameters.ParsedSendAt.IsZero()).To(BeTrue())
+			})
+
+			It("parses an RFC3339 timestamp into UTC", func() {
+				parameters, err := notify.NewNotifyParams(ioutil.NopCloser(strings.NewReader(`{
+                    "send_at": "2030-01-02T15:04:05-07:00"
+				}`)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parameters.SendAt).To(Equal("2030-01-02T15:04:05-07:00"))
+				Expect(parameters.ParsedSendAt).To(Equal(time.Date(2030, 1, 2, 22, 4, 5, 0, time.UTC)))
+			})
+
+			It("leaves the parsed send time empty if the timestamp is malformed", func() {
+				parameters, err := notify.NewNotifyParams(ioutil.NopCloser(strings.NewReader(`{
+                    "send_at": "tomorrow"
+				}`)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parameters.SendAt).To(Equal("tomorrow"))
+				Expect(parameters.ParsedSendAt.IsZero()).To(BeTrue())
+			})
+		})
+
 		Describe("html parsing", func() {
 			Context("when a doctype is passed in", func() {
 				It("pulls out the doctype", func() {
@@ -1,6 +1,10 @@
 package notify
 
-import "regexp"
+import (
+	"regexp"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
 
 var kindIDFormat = regexp.MustCompile(`^[0-9a-zA-Z_\-.]+$`)
 
@@ -21,6 +25,9 @@ func (validator EmailValidator) Validate(notify *NotifyParams) bool {
 		notify.Errors = append(notify.Errors, `"text" or "html" fields must be supplied`)
 	}
 
+	checkSendAtField(notify)
+	checkLocaleField(notify)
+
 	return len(notify.Errors) == 0
 }
 
@@ -39,6 +46,9 @@ func (validator GUIDValidator) Validate(notify *NotifyParams) bool {
 		notify.Errors = append(notify.Errors, `"role" must be "OrgManager", "OrgAuditor", "BillingManager" or unset`)
 	}
 
+	checkSendAtField(notify)
+	checkLocaleField(notify)
+
 	return len(notify.Errors) == 0
 }
 
@@ -46,6 +56,18 @@ func missingTextOrHTMLFields(notify *NotifyParams) bool {
 	return notify.Text == "" && notify.ParsedHTML.BodyContent == ""
 }
 
+func checkSendAtField(notify *NotifyParams) {
+	if notify.SendAt != "" && notify.ParsedSendAt.IsZero() {
+		notify.Errors = append(notify.Errors, `"send_at" must be an RFC3339 timestamp`)
+	}
+}
+
+func checkLocaleField(notify *NotifyParams) {
+	if notify.Locale != "" && !models.ValidLocale(notify.Locale) {
+		notify.Errors = append(notify.Errors, `"locale" is improperly formatted`)
+	}
+}
+
 func (validator GUIDValidator) invalidRoleField(roleName string) bool {
 	if roleName == "" {
 		return false
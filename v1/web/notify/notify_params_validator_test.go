@@ -1,6 +1,8 @@
 package notify_test
 
 import (
+	"time"
+
 	"github.com/cloudfoundry-incubator/notifications/v1/web/notify"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -56,6 +58,34 @@ var _ = Describe("Validator", func() {
 					Expect(params.Errors).To(ContainElement(`"to" is improperly formatted`))
 				})
 			})
+
+			It("validates that send_at is a parseable timestamp", func() {
+				params.SendAt = "2030-01-02T15:04:05Z"
+				params.ParsedSendAt = time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+
+				Expect(validator.Validate(params)).To(BeTrue())
+				Expect(len(params.Errors)).To(Equal(0))
+
+				params.SendAt = "tomorrow"
+				params.ParsedSendAt = time.Time{}
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(len(params.Errors)).To(Equal(1))
+				Expect(params.Errors).To(ContainElement(`"send_at" must be an RFC3339 timestamp`))
+			})
+
+			It("validates that locale is well formed", func() {
+				params.Locale = "fr_CA"
+
+				Expect(validator.Validate(params)).To(BeTrue())
+				Expect(len(params.Errors)).To(Equal(0))
+
+				params.Locale = "not a locale"
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(len(params.Errors)).To(Equal(1))
+				Expect(params.Errors).To(ContainElement(`"locale" is improperly formatted`))
+			})
 		})
 	})
 
@@ -124,6 +154,34 @@ var _ = Describe("Validator", func() {
 				Expect(len(params.Errors)).To(Equal(1))
 				Expect(params.Errors).To(ContainElement(`"role" must be "OrgManager", "OrgAuditor", "BillingManager" or unset`))
 			})
+
+			It("validates that send_at is a parseable timestamp", func() {
+				params.SendAt = "2030-01-02T15:04:05Z"
+				params.ParsedSendAt = time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+
+				Expect(validator.Validate(params)).To(BeTrue())
+				Expect(len(params.Errors)).To(Equal(0))
+
+				params.SendAt = "tomorrow"
+				params.ParsedSendAt = time.Time{}
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(len(params.Errors)).To(Equal(1))
+				Expect(params.Errors).To(ContainElement(`"send_at" must be an RFC3339 timestamp`))
+			})
+
+			It("validates that locale is well formed", func() {
+				params.Locale = "fr_CA"
+
+				Expect(validator.Validate(params)).To(BeTrue())
+				Expect(len(params.Errors)).To(Equal(0))
+
+				params.Locale = "not a locale"
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(len(params.Errors)).To(Equal(1))
+				Expect(params.Errors).To(ContainElement(`"locale" is improperly formatted`))
+			})
 		})
 	})
 })
@@ -41,6 +41,8 @@ var _ = Describe("Notify", func() {
 				vcapRequestID   string
 				database        *mocks.Database
 				reqReceivedTime time.Time
+
+				idempotentRequests *mocks.IdempotentRequests
 			)
 
 			BeforeEach(func() {
@@ -108,7 +110,9 @@ var _ = Describe("Notify", func() {
 				validator = mocks.NewValidator()
 				validator.ValidateCall.Returns.Valid = true
 
-				handler = notify.NewNotify(finder, registrar)
+				idempotentRequests = mocks.NewIdempotentRequests()
+
+				handler = notify.NewNotify(finder, registrar, idempotentRequests)
 			})
 
 			It("delegates to the strategy", func() {
@@ -126,6 +130,7 @@ var _ = Describe("Notify", func() {
 					Kind: services.DispatchKind{
 						ID:          "test_email",
 						Description: "Instance Down",
+						Critical:    true,
 					},
 					UAAHost: "http://zone-uaa-host",
 					VCAPRequest: services.DispatchVCAPRequest{
@@ -146,6 +151,42 @@ var _ = Describe("Notify", func() {
 				}))
 			})
 
+			It("passes the requested send time to the strategy", func() {
+				body, err := json.Marshal(map[string]string{
+					"kind_id": "test_email",
+					"text":    "This is the plain text body of the email",
+					"send_at": "2030-01-02T15:04:05Z",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				request, err = http.NewRequest("POST", "/spaces/space-001", bytes.NewBuffer(body))
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(strategy.DispatchCallsCount).To(Equal(1))
+				Expect(strategy.DispatchCalls[0].Receives.Dispatch.SendAt).To(Equal(time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)))
+			})
+
+			It("passes the requested locale to the strategy", func() {
+				body, err := json.Marshal(map[string]string{
+					"kind_id": "test_email",
+					"text":    "This is the plain text body of the email",
+					"locale":  "fr-CA",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				request, err = http.NewRequest("POST", "/spaces/space-001", bytes.NewBuffer(body))
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(strategy.DispatchCallsCount).To(Equal(1))
+				Expect(strategy.DispatchCalls[0].Receives.Dispatch.Message.Locale).To(Equal("fr-CA"))
+			})
+
 			It("registers the client and kind", func() {
 				_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
 				Expect(err).NotTo(HaveOccurred())
@@ -159,6 +200,119 @@ var _ = Describe("Notify", func() {
 				Expect(registrar.RegisterCall.Receives.Kinds).To(ConsistOf([]models.Kind{kind}))
 			})
 
+			Context("when an idempotency key is provided", func() {
+				newRequest := func(body map[string]string, idempotencyKey string) *http.Request {
+					content, err := json.Marshal(body)
+					Expect(err).NotTo(HaveOccurred())
+
+					request, err := http.NewRequest("POST", "/spaces/space-001", bytes.NewBuffer(content))
+					Expect(err).NotTo(HaveOccurred())
+
+					if idempotencyKey != "" {
+						request.Header.Set("Idempotency-Key", idempotencyKey)
+					}
+
+					return request
+				}
+
+				It("does not track requests without a key", func() {
+					_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(idempotentRequests.BeginCall.WasCalled).To(BeFalse())
+					Expect(idempotentRequests.FinishCall.WasCalled).To(BeFalse())
+				})
+
+				It("reserves the key for the client and stores the response", func() {
+					strategy.DispatchCalls = append(strategy.DispatchCalls, mocks.NewStrategyDispatchCall([]services.Response{
+						{Status: "queued", Recipient: "user-123", NotificationID: "some-message-id"},
+					}, nil))
+
+					request = newRequest(map[string]string{"kind_id": "test_email", "text": "some text"}, "retry-123")
+					output, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(idempotentRequests.BeginCall.Receives.Connection).To(Equal(conn))
+					Expect(idempotentRequests.BeginCall.Receives.ClientID).To(Equal("mister-client"))
+					Expect(idempotentRequests.BeginCall.Receives.Key).To(Equal("retry-123"))
+					Expect(idempotentRequests.BeginCall.Receives.RequestHash).NotTo(BeEmpty())
+
+					Expect(idempotentRequests.FinishCall.Receives.Connection).To(Equal(conn))
+					Expect(idempotentRequests.FinishCall.Receives.ClientID).To(Equal("mister-client"))
+					Expect(idempotentRequests.FinishCall.Receives.Key).To(Equal("retry-123"))
+					Expect(idempotentRequests.FinishCall.Receives.Response).To(Equal(output))
+				})
+
+				It("accepts the key as a body field", func() {
+					request = newRequest(map[string]string{"kind_id": "test_email", "text": "some text", "idempotency_key": "retry-456"}, "")
+					_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(idempotentRequests.BeginCall.Receives.Key).To(Equal("retry-456"))
+				})
+
+				It("hashes requests by their route and content", func() {
+					hashOf := func(path string, body map[string]string) string {
+						request := newRequest(body, "retry-123")
+						request.URL.Path = path
+
+						_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+						Expect(err).NotTo(HaveOccurred())
+
+						return idempotentRequests.BeginCall.Receives.RequestHash
+					}
+
+					original := hashOf("/spaces/space-001", map[string]string{"kind_id": "test_email", "text": "some text"})
+					Expect(hashOf("/spaces/space-001", map[string]string{"kind_id": "test_email", "text": "some text"})).To(Equal(original))
+					Expect(hashOf("/spaces/space-001", map[string]string{"kind_id": "test_email", "text": "other text"})).NotTo(Equal(original))
+					Expect(hashOf("/spaces/space-002", map[string]string{"kind_id": "test_email", "text": "some text"})).NotTo(Equal(original))
+				})
+
+				It("returns the original response without dispatching again", func() {
+					idempotentRequests.BeginCall.Returns.Replayed = true
+					idempotentRequests.BeginCall.Returns.Response = []byte(`[{"status":"queued"}]`)
+
+					request = newRequest(map[string]string{"kind_id": "test_email", "text": "some text"}, "retry-123")
+					output, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(output).To(MatchJSON(`[{"status":"queued"}]`))
+
+					Expect(strategy.DispatchCallsCount).To(Equal(0))
+					Expect(idempotentRequests.FinishCall.WasCalled).To(BeFalse())
+				})
+
+				It("returns errors from reserving the key without dispatching", func() {
+					idempotentRequests.BeginCall.Returns.Error = services.IdempotencyKeyConflictError{Key: "retry-123"}
+
+					request = newRequest(map[string]string{"kind_id": "test_email", "text": "some text"}, "retry-123")
+					_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).To(MatchError(services.IdempotencyKeyConflictError{Key: "retry-123"}))
+
+					Expect(strategy.DispatchCallsCount).To(Equal(0))
+				})
+
+				It("releases the key when the dispatch fails", func() {
+					strategy.DispatchCalls = append(strategy.DispatchCalls, mocks.NewStrategyDispatchCall([]services.Response{}, errors.New("BOOM!")))
+
+					request = newRequest(map[string]string{"kind_id": "test_email", "text": "some text"}, "retry-123")
+					_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).To(MatchError(errors.New("BOOM!")))
+
+					Expect(idempotentRequests.AbandonCall.Receives.Connection).To(Equal(conn))
+					Expect(idempotentRequests.AbandonCall.Receives.ClientID).To(Equal("mister-client"))
+					Expect(idempotentRequests.AbandonCall.Receives.Key).To(Equal("retry-123"))
+					Expect(idempotentRequests.FinishCall.WasCalled).To(BeFalse())
+				})
+
+				It("rejects keys longer than 255 characters", func() {
+					request = newRequest(map[string]string{"kind_id": "test_email", "text": "some text"}, strings.Repeat("k", 256))
+					_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).To(BeAssignableToTypeOf(webutil.ValidationError{}))
+
+					Expect(idempotentRequests.BeginCall.WasCalled).To(BeFalse())
+				})
+			})
+
 			Context("failure cases", func() {
 				Context("when validating params", func() {
 					It("returns a error response when params are missing", func() {
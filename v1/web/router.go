@@ -8,21 +8,27 @@ import (
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
 	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/uaa"
 	"github.com/cloudfoundry-incubator/notifications/util"
 	"github.com/cloudfoundry-incubator/notifications/v1/collections"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/bounces"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/clients"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadletters"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/info"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/messages"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/middleware"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/notifications"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/notify"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/preferences"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/suppressions"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/unsubscribe"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 	"github.com/gorilla/mux"
+	"github.com/pivotal-golang/conceal"
 	"github.com/pivotal-golang/lager"
 	"github.com/rcrowley/go-metrics"
 	"github.com/rcrowley/go-metrics/exp"
@@ -48,6 +54,11 @@ type Config struct {
 	SQLDB                *sql.DB
 	QueueWaitMaxDuration int
 	MaxQueueLength       int
+	IdempotencyKeyTTL    int
+	EncryptionKey        []byte
+
+	RateLimitRequestsPerMinute int
+	RateLimitRecipientsPerHour int
 }
 
 func NewRouter(mx muxer, config Config) http.Handler {
@@ -60,29 +71,50 @@ func NewRouter(mx muxer, config Config) http.Handler {
 	preferencesRepo := models.NewPreferencesRepo()
 	unsubscribesRepo := models.NewUnsubscribesRepo()
 	messagesRepo := models.NewMessagesRepo(guidGenerator.Generate)
+	messageEventsRepo := models.NewMessageEventsRepo()
 	templatesRepo := models.NewTemplatesRepo()
+	suppressionsRepo := models.NewSuppressionsRepo()
 
 	registrar := services.NewRegistrar(clientsRepo, kindsRepo)
 	notificationsFinder := services.NewNotificationsFinder(clientsRepo, kindsRepo)
 	preferencesFinder := services.NewPreferencesFinder(preferencesRepo, globalUnsubscribesRepo)
 	preferenceUpdater := services.NewPreferenceUpdater(globalUnsubscribesRepo, unsubscribesRepo, kindsRepo)
 	notificationsUpdater := services.NewNotificationsUpdater(kindsRepo)
-	messageFinder := services.NewMessageFinder(messagesRepo)
+	messageFinder := services.NewMessageFinder(messagesRepo, messageEventsRepo)
+
+	cloak, err := conceal.NewCloak(config.EncryptionKey)
+	if err != nil {
+		panic(err)
+	}
+
+	unsubscriber := services.NewUnsubscriber(cloak, kindsRepo, unsubscribesRepo, globalUnsubscribesRepo)
 
 	templatesCollection := collections.NewTemplatesCollection(clientsRepo, kindsRepo, templatesRepo)
 
 	templateFinder := services.NewTemplateFinder(templatesRepo)
 	templateUpdater := services.NewTemplateUpdater(templatesRepo)
 	templateLister := services.NewTemplateLister(templatesRepo)
+	templateVersioner := services.NewTemplateVersioner(templatesRepo)
+	templatePreviewer := services.NewTemplatePreviewer(common.NewPackager(nil, cloak))
+
+	idempotentRequests := services.NewIdempotentRequests(models.NewIdempotencyKeysRepo(), clock, time.Duration(config.IdempotencyKeyTTL)*time.Millisecond)
 
-	notifyObj := notify.NewNotify(notificationsFinder, registrar)
+	notifyObj := notify.NewNotify(notificationsFinder, registrar, idempotentRequests)
 
 	gobbleQueue := gobble.NewQueue(gobble.NewDatabase(config.SQLDB), clock, gobble.Config{
 		WaitMaxDuration: time.Duration(config.QueueWaitMaxDuration) * time.Millisecond,
 		MaxQueueLength:  config.MaxQueueLength,
 	})
 
-	v1enqueuer := services.NewEnqueuer(gobbleQueue, messagesRepo, gobble.Initializer{})
+	deadLetters := gobble.NewDeadLetters(gobble.NewDatabase(config.SQLDB), gobbleQueue)
+
+	rateLimitsRepo := models.NewRateLimitsRepo()
+	rateLimiter := services.NewRateLimiter(rateLimitsRepo, clock, services.RateLimits{
+		RequestsPerMinute: config.RateLimitRequestsPerMinute,
+		RecipientsPerHour: config.RateLimitRecipientsPerHour,
+	})
+
+	v1enqueuer := services.NewRateLimitedEnqueuer(services.NewEnqueuer(gobbleQueue, messagesRepo, messageEventsRepo, gobble.Initializer{}), rateLimiter)
 
 	uaaClient := uaa.NewZonedUAAClient(config.UAAClientID, config.UAAClientSecret, config.VerifySSL, config.UAATokenValidator)
 	cloudController := cf.NewCloudController(config.CCHost, !config.VerifySSL)
@@ -138,6 +170,17 @@ func NewRouter(mx muxer, config Config) http.Handler {
 
 		ErrorWriter:      errorWriter,
 		TemplateAssigner: templatesCollection,
+		RateLimitsFinder: rateLimiter,
+		RateLimitsRepo:   rateLimitsRepo,
+	}.Register(mx)
+
+	deadletters.Routes{
+		RequestCounter:                   requestCounter,
+		RequestLogging:                   requestLogging,
+		NotificationsManageAuthenticator: auth("notifications.manage"),
+
+		ErrorWriter: errorWriter,
+		DeadLetters: deadLetters,
 	}.Register(mx)
 
 	messages.Routes{
@@ -145,9 +188,42 @@ func NewRouter(mx muxer, config Config) http.Handler {
 		RequestLogging:    requestLogging,
 		DatabaseAllocator: databaseAllocator,
 		NotificationsWriteOrEmailsWriteAuthenticator: auth("notifications.write", "emails.write"),
+		NotificationsAdminAuthenticator:              auth("notifications.admin"),
+
+		ErrorWriter:           errorWriter,
+		MessageFinder:         messageFinder,
+		MessageTimelineFinder: messageFinder,
+		MessageLister:         messageFinder,
+		MessageCanceller:      services.NewMessageCanceller(messagesRepo, messageEventsRepo),
+	}.Register(mx)
+
+	bounces.Routes{
+		RequestCounter:                   requestCounter,
+		RequestLogging:                   requestLogging,
+		DatabaseAllocator:                databaseAllocator,
+		NotificationsManageAuthenticator: auth("notifications.manage"),
+
+		ErrorWriter:    errorWriter,
+		BounceRecorder: services.NewBounceRecorder(messagesRepo, messageEventsRepo, suppressionsRepo),
+	}.Register(mx)
+
+	suppressions.Routes{
+		RequestCounter:                   requestCounter,
+		RequestLogging:                   requestLogging,
+		DatabaseAllocator:                databaseAllocator,
+		NotificationsManageAuthenticator: auth("notifications.manage"),
+
+		ErrorWriter:      errorWriter,
+		SuppressionsRepo: suppressionsRepo,
+	}.Register(mx)
+
+	unsubscribe.Routes{
+		RequestCounter:    requestCounter,
+		RequestLogging:    requestLogging,
+		DatabaseAllocator: databaseAllocator,
 
-		ErrorWriter:   errorWriter,
-		MessageFinder: messageFinder,
+		ErrorWriter:  errorWriter,
+		Unsubscriber: unsubscriber,
 	}.Register(mx)
 
 	templates.Routes{
@@ -165,6 +241,9 @@ func NewRouter(mx muxer, config Config) http.Handler {
 		TemplateDeleter:           templatesCollection,
 		TemplateLister:            templateLister,
 		TemplateAssociationLister: templatesCollection,
+		TemplatePreviewer:         templatePreviewer,
+		TemplateVersionLister:     templateVersioner,
+		TemplateRollbacker:        templateVersioner,
 	}.Register(mx)
 
 	notifications.Routes{
@@ -187,6 +266,7 @@ func NewRouter(mx muxer, config Config) http.Handler {
 		DatabaseAllocator:               databaseAllocator,
 		NotificationsWriteAuthenticator: auth("notifications.write"),
 		EmailsWriteAuthenticator:        auth("emails.write"),
+		RateLimiter:                     middleware.NewRateLimiter(rateLimiter),
 
 		ErrorWriter:          errorWriter,
 		Notify:               notifyObj,
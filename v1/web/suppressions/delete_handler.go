@@ -0,0 +1,31 @@
+package suppressions
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type DeleteHandler struct {
+	suppressions suppressionsRepo
+	errorWriter  errorWriter
+}
+
+func NewDeleteHandler(suppressions suppressionsRepo, errWriter errorWriter) DeleteHandler {
+	return DeleteHandler{
+		suppressions: suppressions,
+		errorWriter:  errWriter,
+	}
+}
+
+func (h DeleteHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	connection := context.Get("database").(DatabaseInterface).Connection()
+
+	err := h.suppressions.Delete(connection, parseEmail(req.URL.Path))
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,60 @@
+package suppressions_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/suppressions"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteHandler", func() {
+	var (
+		handler          suppressions.DeleteHandler
+		suppressionsRepo *mocks.SuppressionsRepo
+		errorWriter      *mocks.ErrorWriter
+		writer           *httptest.ResponseRecorder
+		request          *http.Request
+		conn             *mocks.Connection
+		context          stack.Context
+	)
+
+	BeforeEach(func() {
+		suppressionsRepo = mocks.NewSuppressionsRepo()
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		conn = mocks.NewConnection()
+		database := mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		var err error
+		request, err = http.NewRequest("DELETE", "/suppressions/bounced@example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = suppressions.NewDeleteHandler(suppressionsRepo, errorWriter)
+	})
+
+	It("removes the address from the suppression list", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(suppressionsRepo.DeleteCall.Receives.Connection).To(Equal(conn))
+		Expect(suppressionsRepo.DeleteCall.Receives.Email).To(Equal("bounced@example.com"))
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("delegates errors to the error writer", func() {
+		suppressionsRepo.DeleteCall.Returns.Error = models.NotFoundError{}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(models.NotFoundError{}))
+	})
+})
@@ -0,0 +1,31 @@
+package suppressions
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type GetHandler struct {
+	suppressions suppressionsRepo
+	errorWriter  errorWriter
+}
+
+func NewGetHandler(suppressions suppressionsRepo, errWriter errorWriter) GetHandler {
+	return GetHandler{
+		suppressions: suppressions,
+		errorWriter:  errWriter,
+	}
+}
+
+func (h GetHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	connection := context.Get("database").(DatabaseInterface).Connection()
+
+	suppression, err := h.suppressions.Find(connection, parseEmail(req.URL.Path))
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NewSuppressionOutput(suppression))
+}
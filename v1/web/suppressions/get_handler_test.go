@@ -0,0 +1,72 @@
+package suppressions_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/suppressions"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetHandler", func() {
+	var (
+		handler          suppressions.GetHandler
+		suppressionsRepo *mocks.SuppressionsRepo
+		errorWriter      *mocks.ErrorWriter
+		writer           *httptest.ResponseRecorder
+		request          *http.Request
+		conn             *mocks.Connection
+		context          stack.Context
+	)
+
+	BeforeEach(func() {
+		suppressionsRepo = mocks.NewSuppressionsRepo()
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		conn = mocks.NewConnection()
+		database := mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		var err error
+		request, err = http.NewRequest("GET", "/suppressions/bounced@example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = suppressions.NewGetHandler(suppressionsRepo, errorWriter)
+	})
+
+	It("writes out the suppressed address", func() {
+		suppressionsRepo.FindCall.Returns.Suppression = models.Suppression{
+			Email:     "bounced@example.com",
+			Reason:    "mailbox unavailable",
+			CreatedAt: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(suppressionsRepo.FindCall.Receives.Connection).To(Equal(conn))
+		Expect(suppressionsRepo.FindCall.Receives.Email).To(Equal("bounced@example.com"))
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{
+			"email": "bounced@example.com",
+			"reason": "mailbox unavailable",
+			"created_at": "2015-01-01T00:00:00Z"
+		}`))
+	})
+
+	It("delegates errors to the error writer", func() {
+		suppressionsRepo.FindCall.Returns.Error = models.NotFoundError{}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(models.NotFoundError{}))
+	})
+})
@@ -0,0 +1,40 @@
+package suppressions
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type ListHandler struct {
+	suppressions suppressionsRepo
+	errorWriter  errorWriter
+}
+
+func NewListHandler(suppressions suppressionsRepo, errWriter errorWriter) ListHandler {
+	return ListHandler{
+		suppressions: suppressions,
+		errorWriter:  errWriter,
+	}
+}
+
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	connection := context.Get("database").(DatabaseInterface).Connection()
+
+	suppressions, err := h.suppressions.FindAll(connection)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	var document struct {
+		Suppressions []SuppressionOutput `json:"suppressions"`
+	}
+	document.Suppressions = []SuppressionOutput{}
+
+	for _, suppression := range suppressions {
+		document.Suppressions = append(document.Suppressions, NewSuppressionOutput(suppression))
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
@@ -0,0 +1,85 @@
+package suppressions_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/suppressions"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListHandler", func() {
+	var (
+		handler          suppressions.ListHandler
+		suppressionsRepo *mocks.SuppressionsRepo
+		errorWriter      *mocks.ErrorWriter
+		writer           *httptest.ResponseRecorder
+		request          *http.Request
+		conn             *mocks.Connection
+		context          stack.Context
+	)
+
+	BeforeEach(func() {
+		suppressionsRepo = mocks.NewSuppressionsRepo()
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		conn = mocks.NewConnection()
+		database := mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		var err error
+		request, err = http.NewRequest("GET", "/suppressions", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = suppressions.NewListHandler(suppressionsRepo, errorWriter)
+	})
+
+	It("writes out the list of suppressed addresses", func() {
+		suppressionsRepo.FindAllCall.Returns.Suppressions = []models.Suppression{
+			{
+				Email:     "bounced@example.com",
+				Reason:    `550 "5.1.1 mailbox unavailable"`,
+				CreatedAt: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(suppressionsRepo.FindAllCall.Receives.Connection).To(Equal(conn))
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{
+			"suppressions": [
+				{
+					"email": "bounced@example.com",
+					"reason": "550 \"5.1.1 mailbox unavailable\"",
+					"created_at": "2015-01-01T00:00:00Z"
+				}
+			]
+		}`))
+	})
+
+	It("writes out an empty list when there are no suppressed addresses", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{"suppressions": []}`))
+	})
+
+	It("delegates errors to the error writer", func() {
+		suppressionsRepo.FindAllCall.Returns.Error = errors.New("database exploded")
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError("database exploded"))
+	})
+})
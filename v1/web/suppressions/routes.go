@@ -0,0 +1,23 @@
+package suppressions
+
+import "github.com/ryanmoran/stack"
+
+type muxer interface {
+	Handle(method, path string, handler stack.Handler, middleware ...stack.Middleware)
+}
+
+type Routes struct {
+	RequestCounter                   stack.Middleware
+	RequestLogging                   stack.Middleware
+	DatabaseAllocator                stack.Middleware
+	NotificationsManageAuthenticator stack.Middleware
+
+	ErrorWriter      errorWriter
+	SuppressionsRepo suppressionsRepo
+}
+
+func (r Routes) Register(m muxer) {
+	m.Handle("GET", "/suppressions", NewListHandler(r.SuppressionsRepo, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator, r.DatabaseAllocator)
+	m.Handle("GET", "/suppressions/{email}", NewGetHandler(r.SuppressionsRepo, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator, r.DatabaseAllocator)
+	m.Handle("DELETE", "/suppressions/{email}", NewDeleteHandler(r.SuppressionsRepo, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator, r.DatabaseAllocator)
+}
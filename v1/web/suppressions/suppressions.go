@@ -0,0 +1,48 @@
+package suppressions
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type errorWriter interface {
+	Write(writer http.ResponseWriter, err error)
+}
+
+type suppressionsRepo interface {
+	Find(connection models.ConnectionInterface, email string) (models.Suppression, error)
+	FindAll(connection models.ConnectionInterface) ([]models.Suppression, error)
+	Delete(connection models.ConnectionInterface, email string) error
+}
+
+type SuppressionOutput struct {
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewSuppressionOutput(suppression models.Suppression) SuppressionOutput {
+	return SuppressionOutput{
+		Email:     suppression.Email,
+		Reason:    suppression.Reason,
+		CreatedAt: suppression.CreatedAt,
+	}
+}
+
+func parseEmail(path string) string {
+	return strings.Split(path, "/suppressions/")[1]
+}
+
+func writeJSON(w http.ResponseWriter, status int, object interface{}) {
+	output, err := json.Marshal(object)
+	if err != nil {
+		panic(err) // No JSON we write into a response should ever panic
+	}
+
+	w.WriteHeader(status)
+	w.Write(output)
+}
@@ -39,11 +39,13 @@ func (h CreateHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, conte
 	connection := context.Get("database").(DatabaseInterface).Connection()
 
 	template, err := h.creator.Create(connection, collections.Template{
-		Name:     templateParams.Name,
-		Text:     templateParams.Text,
-		HTML:     templateParams.HTML,
-		Subject:  templateParams.Subject,
-		Metadata: string(templateParams.Metadata),
+		Name:          templateParams.Name,
+		Text:          templateParams.Text,
+		HTML:          templateParams.HTML,
+		Subject:       templateParams.Subject,
+		Metadata:      string(templateParams.Metadata),
+		Localizations: templateParams.ToModel().Localizations,
+		Strict:        templateParams.Strict,
 	})
 	if err != nil {
 		h.errorWriter.Write(w, webutil.TemplateCreateError{})
@@ -50,6 +50,7 @@ var _ = Describe("CreateHandler", func() {
 				"text":    "Message to: {{.To}}. Raptor Alert.",
 				"html":    "<p>{{.ClientID}} you should run.</p>",
 				"subject": "Raptor Containment Unit Breached",
+				"strict":  true,
 			})
 			Expect(err).NotTo(HaveOccurred())
 
@@ -71,11 +72,13 @@ var _ = Describe("CreateHandler", func() {
 
 			Expect(creator.CreateCall.Receives.Connection).To(Equal(connection))
 			Expect(creator.CreateCall.Receives.Template).To(Equal(collections.Template{
-				Name:     "Emergency Template",
-				Text:     "Message to: {{.To}}. Raptor Alert.",
-				HTML:     "<p>{{.ClientID}} you should run.</p>",
-				Subject:  "Raptor Containment Unit Breached",
-				Metadata: "{}",
+				Name:          "Emergency Template",
+				Text:          "Message to: {{.To}}. Raptor Alert.",
+				HTML:          "<p>{{.ClientID}} you should run.</p>",
+				Subject:       "Raptor Containment Unit Breached",
+				Metadata:      "{}",
+				Localizations: "{}",
+				Strict:        true,
 			}))
 
 			Expect(writer.Code).To(Equal(http.StatusCreated))
@@ -38,12 +38,20 @@ func (h GetDefaultHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, c
 		panic(err)
 	}
 
+	localizations, err := parseLocalizations(template.Localizations)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
 	templateOutput := TemplateOutput{
-		Name:     template.Name,
-		Subject:  template.Subject,
-		HTML:     template.HTML,
-		Text:     template.Text,
-		Metadata: metadata,
+		Name:          template.Name,
+		Subject:       template.Subject,
+		HTML:          template.HTML,
+		Text:          template.Text,
+		Metadata:      metadata,
+		Localizations: localizations,
+		Strict:        template.Strict,
 	}
 
 	writeJSON(w, http.StatusOK, templateOutput)
@@ -0,0 +1,100 @@
+package templates
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type PreviewOutput struct {
+	Subject string            `json:"subject"`
+	Text    string            `json:"text"`
+	HTML    string            `json:"html"`
+	Errors  map[string]string `json:"errors"`
+}
+
+type templatePreviewer interface {
+	Preview(template models.Template, context common.MessageContext) services.TemplatePreview
+}
+
+type PreviewHandler struct {
+	finder      templateFinder
+	previewer   templatePreviewer
+	errorWriter errorWriter
+}
+
+func NewPreviewHandler(finder templateFinder, previewer templatePreviewer, errWriter errorWriter) PreviewHandler {
+	return PreviewHandler{
+		finder:      finder,
+		previewer:   previewer,
+		errorWriter: errWriter,
+	}
+}
+
+func (h PreviewHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	params, err := NewPreviewParams(req.Body)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	template, err := h.finder.FindByID(context.Get("database").(DatabaseInterface), h.parseTemplateID(req.URL.Path))
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writePreview(w, h.previewer.Preview(template, params.MessageContext()))
+}
+
+func (h PreviewHandler) parseTemplateID(path string) string {
+	r := regexp.MustCompile(`\/templates\/(.*)\/preview`)
+	matches := r.FindStringSubmatch(path)
+
+	return matches[1]
+}
+
+type PreviewDraftHandler struct {
+	previewer   templatePreviewer
+	errorWriter errorWriter
+}
+
+func NewPreviewDraftHandler(previewer templatePreviewer, errWriter errorWriter) PreviewDraftHandler {
+	return PreviewDraftHandler{
+		previewer:   previewer,
+		errorWriter: errWriter,
+	}
+}
+
+func (h PreviewDraftHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	params, err := NewPreviewParams(req.Body)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	template := models.Template{
+		Subject: params.Subject,
+		Text:    params.Text,
+		HTML:    params.HTML,
+		Strict:  params.Strict,
+	}
+	if template.Subject == "" {
+		template.Subject = "{{.Subject}}"
+	}
+
+	writePreview(w, h.previewer.Preview(template, params.MessageContext()))
+}
+
+func writePreview(w http.ResponseWriter, preview services.TemplatePreview) {
+	writeJSON(w, http.StatusOK, PreviewOutput{
+		Subject: preview.Subject,
+		Text:    preview.Text,
+		HTML:    preview.HTML,
+		Errors:  preview.Errors,
+	})
+}
@@ -0,0 +1,164 @@
+package templates_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreviewHandler", func() {
+	var (
+		handler     templates.PreviewHandler
+		writer      *httptest.ResponseRecorder
+		context     stack.Context
+		finder      *mocks.TemplateFinder
+		previewer   *mocks.TemplatePreviewer
+		errorWriter *mocks.ErrorWriter
+		database    *mocks.Database
+	)
+
+	BeforeEach(func() {
+		finder = mocks.NewTemplateFinder()
+		finder.FindByIDCall.Returns.Template = models.Template{
+			Subject: "Alert: {{.Subject}}",
+			Text:    "{{.Text}}",
+			HTML:    "<p>{{.HTML}}</p>",
+		}
+
+		previewer = mocks.NewTemplatePreviewer()
+		previewer.PreviewCall.Returns.Preview = services.TemplatePreview{
+			Subject: "Alert: Instance down",
+			Text:    "Your instance is down",
+			HTML:    "<p>Your instance is down</p>",
+			Errors:  map[string]string{},
+		}
+
+		writer = httptest.NewRecorder()
+		errorWriter = mocks.NewErrorWriter()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = templates.NewPreviewHandler(finder, previewer, errorWriter)
+	})
+
+	It("renders the saved template against the given context", func() {
+		request, err := http.NewRequest("POST", "/templates/some-template-id/preview", bytes.NewBufferString(`{"context":{"subject":"Instance down"}}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"subject": "Alert: Instance down",
+			"text": "Your instance is down",
+			"html": "<p>Your instance is down</p>",
+			"errors": {}
+		}`))
+
+		Expect(finder.FindByIDCall.Receives.Database).To(Equal(database))
+		Expect(finder.FindByIDCall.Receives.TemplateID).To(Equal("some-template-id"))
+		Expect(previewer.PreviewCall.Receives.Template).To(Equal(finder.FindByIDCall.Returns.Template))
+		Expect(previewer.PreviewCall.Receives.Context.Subject).To(Equal("Instance down"))
+	})
+
+	It("renders against a sample context when no body is given", func() {
+		request, err := http.NewRequest("POST", "/templates/some-template-id/preview", bytes.NewBuffer([]byte{}))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(previewer.PreviewCall.Receives.Context.Subject).To(Equal("Sample subject"))
+	})
+
+	It("writes an error when the template cannot be found", func() {
+		finder.FindByIDCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		request, err := http.NewRequest("POST", "/templates/missing-template-id/preview", bytes.NewBuffer([]byte{}))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+	})
+
+	It("writes a parse error when the body is not valid JSON", func() {
+		request, err := http.NewRequest("POST", "/templates/some-template-id/preview", bytes.NewBufferString(`{"context":`))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(webutil.ParseError{}))
+	})
+})
+
+var _ = Describe("PreviewDraftHandler", func() {
+	var (
+		handler     templates.PreviewDraftHandler
+		writer      *httptest.ResponseRecorder
+		previewer   *mocks.TemplatePreviewer
+		errorWriter *mocks.ErrorWriter
+	)
+
+	BeforeEach(func() {
+		previewer = mocks.NewTemplatePreviewer()
+		previewer.PreviewCall.Returns.Preview = services.TemplatePreview{
+			Subject: "Sample subject",
+			Errors:  map[string]string{"html": errors.New("template: unexpected EOF").Error()},
+		}
+
+		writer = httptest.NewRecorder()
+		errorWriter = mocks.NewErrorWriter()
+
+		handler = templates.NewPreviewDraftHandler(previewer, errorWriter)
+	})
+
+	It("renders the unsaved template and reports its errors", func() {
+		request, err := http.NewRequest("POST", "/templates/preview", bytes.NewBufferString(`{"text":"{{.Text}}","html":"<p>{{if .HTML}}</p>"}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, stack.NewContext())
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"subject": "Sample subject",
+			"text": "",
+			"html": "",
+			"errors": {
+				"html": "template: unexpected EOF"
+			}
+		}`))
+
+		Expect(previewer.PreviewCall.Receives.Template).To(Equal(models.Template{
+			Subject: "{{.Subject}}",
+			Text:    "{{.Text}}",
+			HTML:    "<p>{{if .HTML}}</p>",
+		}))
+	})
+
+	It("renders the unsaved template strictly when asked to", func() {
+		request, err := http.NewRequest("POST", "/templates/preview", bytes.NewBufferString(`{"text":"{{.Text}} in {{.Space}}","strict":true}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, stack.NewContext())
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(previewer.PreviewCall.Receives.Template).To(Equal(models.Template{
+			Subject: "{{.Subject}}",
+			Text:    "{{.Text}} in {{.Space}}",
+			Strict:  true,
+		}))
+	})
+})
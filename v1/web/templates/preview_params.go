@@ -0,0 +1,95 @@
+package templates
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+)
+
+// PreviewContext holds the values a template is rendered against. Any field
+// left empty is filled in with a sample value.
+type PreviewContext struct {
+	From              string `json:"from"`
+	ReplyTo           string `json:"reply_to"`
+	To                string `json:"to"`
+	Subject           string `json:"subject"`
+	Text              string `json:"text"`
+	HTML              string `json:"html"`
+	KindDescription   string `json:"kind_description"`
+	SourceDescription string `json:"source_description"`
+	UserGUID          string `json:"user_guid"`
+	ClientID          string `json:"client_id"`
+	MessageID         string `json:"message_id"`
+	Space             string `json:"space"`
+	SpaceGUID         string `json:"space_guid"`
+	Organization      string `json:"organization"`
+	OrganizationGUID  string `json:"organization_guid"`
+	OrganizationRole  string `json:"organization_role"`
+	Scope             string `json:"scope"`
+	Endorsement       string `json:"endorsement"`
+	Domain            string `json:"domain"`
+	UnsubscribeID     string `json:"unsubscribe_id"`
+}
+
+type PreviewParams struct {
+	Subject string         `json:"subject"`
+	Text    string         `json:"text"`
+	HTML    string         `json:"html"`
+	Strict  bool           `json:"strict"`
+	Context PreviewContext `json:"context"`
+}
+
+func NewPreviewParams(body io.ReadCloser) (PreviewParams, error) {
+	defer body.Close()
+
+	var params PreviewParams
+	err := json.NewDecoder(body).Decode(&params)
+	if err != nil && err != io.EOF {
+		return PreviewParams{}, webutil.ParseError{}
+	}
+
+	return params, nil
+}
+
+func (p PreviewParams) MessageContext() common.MessageContext {
+	c := p.Context
+
+	return common.MessageContext{
+		From:    valueOrSample(c.From, "no-reply@example.com"),
+		ReplyTo: c.ReplyTo,
+		To:      valueOrSample(c.To, "user@example.com"),
+		Subject: valueOrSample(c.Subject, "Sample subject"),
+		Text:    valueOrSample(c.Text, "This is the sample text of a notification."),
+		HTML:    valueOrSample(c.HTML, "<p>This is the sample HTML of a notification.</p>"),
+		HTMLComponents: common.HTML{
+			BodyContent: valueOrSample(c.HTML, "<p>This is the sample HTML of a notification.</p>"),
+			Doctype:     "<!DOCTYPE html>",
+		},
+		KindDescription:   valueOrSample(c.KindDescription, "Sample Kind"),
+		SourceDescription: valueOrSample(c.SourceDescription, "Sample Source"),
+		UserGUID:          valueOrSample(c.UserGUID, "sample-user-guid"),
+		ClientID:          valueOrSample(c.ClientID, "sample-client"),
+		MessageID:         valueOrSample(c.MessageID, "sample-message-id"),
+		Space:             valueOrSample(c.Space, "sample-space"),
+		SpaceGUID:         valueOrSample(c.SpaceGUID, "sample-space-guid"),
+		Organization:      valueOrSample(c.Organization, "sample-organization"),
+		OrganizationGUID:  valueOrSample(c.OrganizationGUID, "sample-organization-guid"),
+		OrganizationRole:  c.OrganizationRole,
+		Scope:             c.Scope,
+		Endorsement:       valueOrSample(c.Endorsement, "This message was sent directly to you."),
+		Domain:            valueOrSample(c.Domain, "notifications.example.com"),
+		UnsubscribeID:     valueOrSample(c.UnsubscribeID, "sample-unsubscribe-id"),
+		RequestReceived:   time.Now(),
+	}
+}
+
+func valueOrSample(value, sample string) string {
+	if value == "" {
+		return sample
+	}
+
+	return value
+}
@@ -0,0 +1,50 @@
+package templates_test
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreviewParams", func() {
+	Describe("MessageContext", func() {
+		It("uses the values given in the context", func() {
+			params, err := templates.NewPreviewParams(ioutil.NopCloser(bytes.NewBufferString(`{
+				"context": {
+					"subject": "Instance down",
+					"html": "<b>down</b>",
+					"organization": "my-org"
+				}
+			}`)))
+			Expect(err).NotTo(HaveOccurred())
+
+			context := params.MessageContext()
+			Expect(context.Subject).To(Equal("Instance down"))
+			Expect(context.HTML).To(Equal("<b>down</b>"))
+			Expect(context.HTMLComponents.BodyContent).To(Equal("<b>down</b>"))
+			Expect(context.Organization).To(Equal("my-org"))
+		})
+
+		It("fills in sample values for everything else", func() {
+			params, err := templates.NewPreviewParams(ioutil.NopCloser(bytes.NewBuffer([]byte{})))
+			Expect(err).NotTo(HaveOccurred())
+
+			context := params.MessageContext()
+			Expect(context.Subject).To(Equal("Sample subject"))
+			Expect(context.Text).NotTo(BeEmpty())
+			Expect(context.HTML).NotTo(BeEmpty())
+			Expect(context.Space).To(Equal("sample-space"))
+			Expect(context.Organization).To(Equal("sample-organization"))
+		})
+	})
+
+	It("returns a parse error when the body is not valid JSON", func() {
+		_, err := templates.NewPreviewParams(ioutil.NopCloser(bytes.NewBufferString("{")))
+		Expect(err).To(BeAssignableToTypeOf(webutil.ParseError{}))
+	})
+})
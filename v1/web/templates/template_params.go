@@ -2,21 +2,25 @@ package templates
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"text/template"
 
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 	"github.com/cloudfoundry-incubator/notifications/valiant"
 )
 
 type TemplateParams struct {
-	Name     string          `json:"name" validate-required:"true"`
-	Text     string          `json:"text"`
-	HTML     string          `json:"html" validate-required:"true"`
-	Subject  string          `json:"subject"`
-	Metadata json.RawMessage `json:"metadata"`
+	Name          string                                 `json:"name" validate-required:"true"`
+	Text          string                                 `json:"text"`
+	HTML          string                                 `json:"html" validate-required:"true"`
+	Subject       string                                 `json:"subject"`
+	Metadata      json.RawMessage                        `json:"metadata"`
+	Localizations map[string]models.TemplateLocalization `json:"localizations"`
+	Strict        bool                                   `json:"strict"`
 }
 
 func NewTemplateParams(body io.ReadCloser) (TemplateParams, error) {
@@ -44,6 +48,11 @@ func NewTemplateParams(body io.ReadCloser) (TemplateParams, error) {
 		return TemplateParams{}, err
 	}
 
+	err = template.normalizeLocalizations()
+	if err != nil {
+		return TemplateParams{}, err
+	}
+
 	template.setDefaults()
 
 	return template, nil
@@ -56,23 +65,75 @@ func (t TemplateParams) validateSyntax() error {
 		"HTML":    t.HTML,
 	}
 
+	for locale, localization := range t.Localizations {
+		toValidate[locale+" Subject"] = localization.Subject
+		toValidate[locale+" Text"] = localization.Text
+		toValidate[locale+" HTML"] = localization.HTML
+	}
+
+	context := dryRunContext()
 	for field, contents := range toValidate {
-		_, err := template.New("test").Parse(contents)
+		_, err := template.New("test").Funcs(common.TemplateFuncs()).Parse(contents)
 		if err != nil {
 			return webutil.ValidationError{Err: fmt.Errorf("%s syntax is malformed please check your braces", field)}
 		}
+
+		_, err = common.RenderTemplate(field, contents, context, t.Strict)
+		if err != nil {
+			return webutil.ValidationError{Err: fmt.Errorf("%s could not be rendered: %s", field, errors.Unwrap(err))}
+		}
+	}
+
+	return nil
+}
+
+// dryRunContext is the preview sample context with every value provided, so
+// that a template is only rejected for something no message could render.
+func dryRunContext() common.MessageContext {
+	context := PreviewParams{}.MessageContext()
+	context.ReplyTo = "reply-to@example.com"
+	context.OrganizationRole = "OrgManager"
+	context.Scope = "sample.scope"
+
+	return context
+}
+
+func (t *TemplateParams) normalizeLocalizations() error {
+	localizations := map[string]models.TemplateLocalization{}
+	for locale, localization := range t.Localizations {
+		if !models.ValidLocale(locale) {
+			return webutil.ValidationError{Err: fmt.Errorf("%q is not a valid locale", locale)}
+		}
+
+		localizations[models.NormalizeLocale(locale)] = localization
 	}
+	t.Localizations = localizations
 
 	return nil
 }
 
+func (t TemplateParams) localizationsJSON() string {
+	if len(t.Localizations) == 0 {
+		return "{}"
+	}
+
+	localizations, err := json.Marshal(t.Localizations)
+	if err != nil {
+		panic(err) // a map of strings always marshals
+	}
+
+	return string(localizations)
+}
+
 func (t TemplateParams) ToModel() models.Template {
 	return models.Template{
-		Name:     t.Name,
-		Text:     t.Text,
-		HTML:     t.HTML,
-		Subject:  t.Subject,
-		Metadata: string(t.Metadata),
+		Name:          t.Name,
+		Text:          t.Text,
+		HTML:          t.HTML,
+		Subject:       t.Subject,
+		Metadata:      string(t.Metadata),
+		Localizations: t.localizationsJSON(),
+		Strict:        t.Strict,
 	}
 }
 
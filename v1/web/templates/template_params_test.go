@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 
@@ -101,6 +102,127 @@ var _ = Describe("TemplateParams", func() {
 						Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New("HTML syntax is malformed please check your braces")}))
 					})
 				})
+
+				Context("when a localization has invalid syntax", func() {
+					It("returns a validation error", func() {
+						body := buildTemplateRequestBody(templates.TemplateParams{
+							Name: "Template name",
+							HTML: "<h1> Amazing </h1>",
+							Localizations: map[string]models.TemplateLocalization{
+								"fr": {Text: "Vous {{.BAD}"},
+							},
+						})
+						_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+						Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New("fr Text syntax is malformed please check your braces")}))
+					})
+				})
+			})
+
+			Context("when the template uses template functions", func() {
+				It("accepts the functions notification templates provide", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name:    "Template name",
+						Subject: "{{.Subject | truncate 50 | upper}}",
+						Text:    `Sent {{.RequestReceived | formatTimeIn "Europe/Paris" "2 Jan 2006 15:04 MST"}} to {{.Space | default "you"}}`,
+						HTML:    `{{markdown .HTML}} <a href="https://{{.Domain}}/spaces/{{pathEscape .SpaceGUID}}">Space</a>`,
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("rejects functions that do not exist", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name: "Template name",
+						Text: "{{shout .Text}}",
+						HTML: "<h1> Amazing </h1>",
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New("Text syntax is malformed please check your braces")}))
+				})
+
+				It("rejects functions given the wrong arguments", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name: "Template name",
+						Text: `{{.RequestReceived | formatTimeIn "Nowhere/Special" "15:04"}}`,
+						HTML: "<h1> Amazing </h1>",
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).To(MatchError(ContainSubstring(`unknown time zone "Nowhere/Special"`)))
+				})
+			})
+
+			Context("when the template cannot be rendered", func() {
+				It("returns a validation error naming the field", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name: "Template name",
+						Text: "Sent to {{.Nope}}",
+						HTML: "<h1> Amazing </h1>",
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).To(BeAssignableToTypeOf(webutil.ValidationError{}))
+					Expect(err).To(MatchError(ContainSubstring("Text could not be rendered")))
+					Expect(err).To(MatchError(ContainSubstring("can't evaluate field Nope")))
+				})
+
+				It("dry-runs the localizations too", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name: "Template name",
+						HTML: "<h1> Amazing </h1>",
+						Localizations: map[string]models.TemplateLocalization{
+							"fr": {Subject: "{{index .Subject 100}}"},
+						},
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).To(MatchError(ContainSubstring("fr Subject could not be rendered")))
+				})
+
+				It("accepts a strict template that refers to every value a message can provide", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name:    "Template name",
+						Subject: "{{.Subject}} for {{.Scope}}",
+						Text:    "{{.Text}} {{.ReplyTo}} {{.OrganizationRole}} {{.Space}} {{.Organization}}",
+						HTML:    "<p>{{.HTML}}</p>",
+						Strict:  true,
+					})
+					parameters, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(parameters.Strict).To(BeTrue())
+				})
+			})
+
+			Context("when the template has localizations", func() {
+				It("normalizes the locales", func() {
+					body, err := json.Marshal(map[string]interface{}{
+						"name": "Foo Bar Baz",
+						"html": "<p>its foobar</p>",
+						"localizations": map[string]interface{}{
+							"pt_BR": map[string]string{"subject": "Assunto", "html": "<p>ola</p>"},
+							"FR":    map[string]string{"text": "bonjour"},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					parameters, err := templates.NewTemplateParams(ioutil.NopCloser(bytes.NewBuffer(body)))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(parameters.Localizations).To(Equal(map[string]models.TemplateLocalization{
+						"pt-br": {Subject: "Assunto", HTML: "<p>ola</p>"},
+						"fr":    {Text: "bonjour"},
+					}))
+				})
+
+				It("rejects locales that are malformed", func() {
+					body, err := json.Marshal(map[string]interface{}{
+						"name": "Foo Bar Baz",
+						"html": "<p>its foobar</p>",
+						"localizations": map[string]interface{}{
+							"not a locale": map[string]string{"text": "hello"},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = templates.NewTemplateParams(ioutil.NopCloser(bytes.NewBuffer(body)))
+					Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New(`"not a locale" is not a valid locale`)}))
+				})
 			})
 		})
 	})
@@ -113,6 +235,7 @@ var _ = Describe("TemplateParams", func() {
 				HTML:     "<p>its foobar</p>",
 				Subject:  "Foobar Yah",
 				Metadata: json.RawMessage(`{"some_property": "some_value"}`),
+				Strict:   true,
 			}
 			templateModel := templateParams.ToModel()
 
@@ -121,6 +244,8 @@ var _ = Describe("TemplateParams", func() {
 			Expect(templateModel.HTML).To(Equal("<p>its foobar</p>"))
 			Expect(templateModel.Subject).To(Equal("Foobar Yah"))
 			Expect(templateModel.Metadata).To(MatchJSON(`{"some_property": "some_value"}`))
+			Expect(templateModel.Localizations).To(MatchJSON(`{}`))
+			Expect(templateModel.Strict).To(BeTrue())
 			Expect(templateModel.CreatedAt).To(BeZero())
 			Expect(templateModel.UpdatedAt).To(BeZero())
 		})
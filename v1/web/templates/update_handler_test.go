@@ -52,11 +52,12 @@ var _ = Describe("UpdateHandler", func() {
 			Expect(updater.UpdateCall.Receives.Database).To(Equal(database))
 			Expect(updater.UpdateCall.Receives.TemplateID).To(Equal("a-template-id"))
 			Expect(updater.UpdateCall.Receives.Template).To(Equal(models.Template{
-				Name:     "An Interesting Template",
-				Subject:  "very interesting subject",
-				Text:     "Here's the msg {{.Text}}",
-				HTML:     "<p>turkey gobble</p>",
-				Metadata: "{}",
+				Name:          "An Interesting Template",
+				Subject:       "very interesting subject",
+				Text:          "Here's the msg {{.Text}}",
+				HTML:          "<p>turkey gobble</p>",
+				Metadata:      "{}",
+				Localizations: "{}",
 			}))
 		})
 
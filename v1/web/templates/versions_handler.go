@@ -0,0 +1,144 @@
+package templates
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+type TemplateVersionOutput struct {
+	Version       int                                    `json:"version"`
+	Name          string                                 `json:"name"`
+	Subject       string                                 `json:"subject"`
+	HTML          string                                 `json:"html"`
+	Text          string                                 `json:"text"`
+	Metadata      map[string]interface{}                 `json:"metadata"`
+	Localizations map[string]models.TemplateLocalization `json:"localizations,omitempty"`
+	Strict        bool                                   `json:"strict,omitempty"`
+	CreatedAt     time.Time                              `json:"created_at"`
+}
+
+type templateVersionLister interface {
+	ListVersions(database services.DatabaseInterface, templateID string) ([]models.TemplateVersion, error)
+}
+
+type templateRollbacker interface {
+	Rollback(database services.DatabaseInterface, templateID string, version int) (models.Template, error)
+}
+
+type ListVersionsHandler struct {
+	lister      templateVersionLister
+	errorWriter errorWriter
+}
+
+func NewListVersionsHandler(lister templateVersionLister, errWriter errorWriter) ListVersionsHandler {
+	return ListVersionsHandler{
+		lister:      lister,
+		errorWriter: errWriter,
+	}
+}
+
+func (h ListVersionsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	r := regexp.MustCompile(`\/templates\/(.*)\/versions`)
+	templateID := r.FindStringSubmatch(req.URL.Path)[1]
+
+	versions, err := h.lister.ListVersions(context.Get("database").(DatabaseInterface), templateID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	output := []TemplateVersionOutput{}
+	for _, version := range versions {
+		var metadata map[string]interface{}
+		err = json.Unmarshal([]byte(version.Metadata), &metadata)
+		if err != nil {
+			h.errorWriter.Write(w, err)
+			return
+		}
+
+		localizations, err := parseLocalizations(version.Localizations)
+		if err != nil {
+			h.errorWriter.Write(w, err)
+			return
+		}
+
+		output = append(output, TemplateVersionOutput{
+			Version:       version.Version,
+			Name:          version.Name,
+			Subject:       version.Subject,
+			HTML:          version.HTML,
+			Text:          version.Text,
+			Metadata:      metadata,
+			Localizations: localizations,
+			Strict:        version.Strict,
+			CreatedAt:     version.CreatedAt,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]TemplateVersionOutput{
+		"versions": output,
+	})
+}
+
+type RollbackHandler struct {
+	rollbacker  templateRollbacker
+	errorWriter errorWriter
+}
+
+func NewRollbackHandler(rollbacker templateRollbacker, errWriter errorWriter) RollbackHandler {
+	return RollbackHandler{
+		rollbacker:  rollbacker,
+		errorWriter: errWriter,
+	}
+}
+
+func (h RollbackHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	r := regexp.MustCompile(`\/templates\/(.*)\/versions\/(.*)\/rollback`)
+	matches := r.FindStringSubmatch(req.URL.Path)
+
+	version, err := strconv.Atoi(matches[2])
+	if err != nil || version < 1 {
+		h.errorWriter.Write(w, webutil.ValidationError{Err: errors.New("version must be a positive integer")})
+		return
+	}
+
+	template, err := h.rollbacker.Rollback(context.Get("database").(DatabaseInterface), matches[1], version)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	var metadata map[string]interface{}
+	err = json.Unmarshal([]byte(template.Metadata), &metadata)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	localizations, err := parseLocalizations(template.Localizations)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, TemplateVersionOutput{
+		Version:       template.Version,
+		Name:          template.Name,
+		Subject:       template.Subject,
+		HTML:          template.HTML,
+		Text:          template.Text,
+		Metadata:      metadata,
+		Localizations: localizations,
+		Strict:        template.Strict,
+		CreatedAt:     template.UpdatedAt,
+	})
+}
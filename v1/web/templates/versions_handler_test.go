@@ -0,0 +1,189 @@
+package templates_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListVersionsHandler", func() {
+	var (
+		handler     templates.ListVersionsHandler
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+		versioner   *mocks.TemplateVersioner
+		errorWriter *mocks.ErrorWriter
+		database    *mocks.Database
+		context     stack.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		versioner = mocks.NewTemplateVersioner()
+		versioner.ListVersionsCall.Returns.Versions = []models.TemplateVersion{
+			{
+				TemplateID: "banana-template",
+				Version:    2,
+				Name:       "Banana",
+				Subject:    "{{.Subject}} again",
+				Text:       "text",
+				HTML:       "<p>html</p>",
+				Metadata:   `{"color": "yellow"}`,
+				CreatedAt:  time.Date(2015, time.March, 1, 12, 0, 0, 0, time.UTC),
+			},
+			{
+				TemplateID: "banana-template",
+				Version:    1,
+				Name:       "Banana",
+				Subject:    "{{.Subject}}",
+				Text:       "text",
+				HTML:       "<p>html</p>",
+				Metadata:   "{}",
+				CreatedAt:  time.Date(2015, time.February, 1, 12, 0, 0, 0, time.UTC),
+			},
+		}
+		errorWriter = mocks.NewErrorWriter()
+
+		writer = httptest.NewRecorder()
+		request, err = http.NewRequest("GET", "/templates/banana-template/versions", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = templates.NewListVersionsHandler(versioner, errorWriter)
+	})
+
+	It("returns the versions of the template", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"versions": [
+				{
+					"version": 2,
+					"name": "Banana",
+					"subject": "{{.Subject}} again",
+					"text": "text",
+					"html": "<p>html</p>",
+					"metadata": {"color": "yellow"},
+					"created_at": "2015-03-01T12:00:00Z"
+				},
+				{
+					"version": 1,
+					"name": "Banana",
+					"subject": "{{.Subject}}",
+					"text": "text",
+					"html": "<p>html</p>",
+					"metadata": {},
+					"created_at": "2015-02-01T12:00:00Z"
+				}
+			]
+		}`))
+
+		Expect(versioner.ListVersionsCall.Receives.Database).To(Equal(database))
+		Expect(versioner.ListVersionsCall.Receives.TemplateID).To(Equal("banana-template"))
+	})
+
+	Context("when the versions cannot be listed", func() {
+		It("writes the error", func() {
+			versioner.ListVersionsCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+		})
+	})
+})
+
+var _ = Describe("RollbackHandler", func() {
+	var (
+		handler     templates.RollbackHandler
+		writer      *httptest.ResponseRecorder
+		versioner   *mocks.TemplateVersioner
+		errorWriter *mocks.ErrorWriter
+		database    *mocks.Database
+		context     stack.Context
+	)
+
+	BeforeEach(func() {
+		versioner = mocks.NewTemplateVersioner()
+		versioner.RollbackCall.Returns.Template = models.Template{
+			ID:        "banana-template",
+			Version:   3,
+			Name:      "Banana",
+			Subject:   "{{.Subject}}",
+			Text:      "text",
+			HTML:      "<p>html</p>",
+			Metadata:  "{}",
+			UpdatedAt: time.Date(2015, time.April, 1, 12, 0, 0, 0, time.UTC),
+		}
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = templates.NewRollbackHandler(versioner, errorWriter)
+	})
+
+	It("rolls the template back to the requested version", func() {
+		request, err := http.NewRequest("POST", "/templates/banana-template/versions/1/rollback", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"version": 3,
+			"name": "Banana",
+			"subject": "{{.Subject}}",
+			"text": "text",
+			"html": "<p>html</p>",
+			"metadata": {},
+			"created_at": "2015-04-01T12:00:00Z"
+		}`))
+
+		Expect(versioner.RollbackCall.Receives.Database).To(Equal(database))
+		Expect(versioner.RollbackCall.Receives.TemplateID).To(Equal("banana-template"))
+		Expect(versioner.RollbackCall.Receives.Version).To(Equal(1))
+	})
+
+	Context("when the version is not a positive integer", func() {
+		It("writes a validation error", func() {
+			request, err := http.NewRequest("POST", "/templates/banana-template/versions/latest/rollback", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(webutil.ValidationError{}))
+			Expect(versioner.RollbackCall.Receives.TemplateID).To(BeEmpty())
+		})
+	})
+
+	Context("when the rollback fails", func() {
+		It("writes the error", func() {
+			versioner.RollbackCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			request, err := http.NewRequest("POST", "/templates/banana-template/versions/9/rollback", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+		})
+	})
+})
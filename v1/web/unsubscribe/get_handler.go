@@ -0,0 +1,71 @@
+package unsubscribe
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+type GetHandler struct {
+	unsubscriber unsubscriber
+	errorWriter  errorWriter
+}
+
+func NewGetHandler(unsubscriber unsubscriber, errWriter errorWriter) GetHandler {
+	return GetHandler{
+		unsubscriber: unsubscriber,
+		errorWriter:  errWriter,
+	}
+}
+
+func (h GetHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	unsubscribeID := strings.Split(req.URL.Path, "/unsubscribe/")[1]
+	connection := context.Get("database").(DatabaseInterface).Connection()
+
+	link, err := h.unsubscriber.Find(connection, unsubscribeID)
+	if err != nil {
+		writeError(h.errorWriter, w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, linkDocument(link))
+}
+
+type document struct {
+	ClientID        string `json:"client_id"`
+	KindID          string `json:"kind_id"`
+	KindDescription string `json:"kind_description"`
+	Critical        bool   `json:"critical"`
+}
+
+func linkDocument(link services.UnsubscribeLink) document {
+	return document{
+		ClientID:        link.ClientID,
+		KindID:          link.KindID,
+		KindDescription: link.KindDescription,
+		Critical:        link.Critical,
+	}
+}
+
+func writeError(errorWriter errorWriter, w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case services.MissingKindOrClientError, services.CriticalKindError:
+		errorWriter.Write(w, webutil.ValidationError{Err: err})
+	default:
+		errorWriter.Write(w, err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, object interface{}) {
+	output, err := json.Marshal(object)
+	if err != nil {
+		panic(err) // No JSON we write into a response should ever panic
+	}
+
+	w.WriteHeader(status)
+	w.Write(output)
+}
@@ -0,0 +1,83 @@
+package unsubscribe_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/unsubscribe"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetHandler", func() {
+	var (
+		handler      unsubscribe.GetHandler
+		errorWriter  *mocks.ErrorWriter
+		writer       *httptest.ResponseRecorder
+		request      *http.Request
+		unsubscriber *mocks.Unsubscriber
+		database     *mocks.Database
+		conn         *mocks.Connection
+		context      stack.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		errorWriter = mocks.NewErrorWriter()
+		unsubscriber = mocks.NewUnsubscriber()
+		unsubscriber.FindCall.Returns.Link = services.UnsubscribeLink{
+			UserGUID:        "some-user",
+			ClientID:        "some-client",
+			KindID:          "some-kind",
+			KindDescription: "Some Kind",
+		}
+
+		writer = httptest.NewRecorder()
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		request, err = http.NewRequest("GET", "/unsubscribe/some-unsubscribe-id", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = unsubscribe.NewGetHandler(unsubscriber, errorWriter)
+	})
+
+	It("describes what the link unsubscribes from", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{
+			"client_id": "some-client",
+			"kind_id": "some-kind",
+			"kind_description": "Some Kind",
+			"critical": false
+		}`))
+
+		Expect(unsubscriber.FindCall.Receives.Connection).To(Equal(conn))
+		Expect(unsubscriber.FindCall.Receives.UnsubscribeID).To(Equal("some-unsubscribe-id"))
+	})
+
+	It("writes critical kind and missing kind errors as validation errors", func() {
+		unsubscriber.FindCall.Returns.Error = services.MissingKindOrClientError{Err: errors.New("missing")}
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(webutil.ValidationError{Err: services.MissingKindOrClientError{Err: errors.New("missing")}}))
+	})
+
+	It("delegates other errors to the error writer", func() {
+		unsubscriber.FindCall.Returns.Error = services.InvalidUnsubscribeIDError{}
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(services.InvalidUnsubscribeIDError{}))
+	})
+})
@@ -0,0 +1,34 @@
+package unsubscribe
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ryanmoran/stack"
+)
+
+type PostHandler struct {
+	unsubscriber unsubscriber
+	errorWriter  errorWriter
+}
+
+func NewPostHandler(unsubscriber unsubscriber, errWriter errorWriter) PostHandler {
+	return PostHandler{
+		unsubscriber: unsubscriber,
+		errorWriter:  errWriter,
+	}
+}
+
+func (h PostHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	unsubscribeID := strings.Split(req.URL.Path, "/unsubscribe/")[1]
+	connection := context.Get("database").(DatabaseInterface).Connection()
+	global := req.FormValue("global") == "true"
+
+	link, err := h.unsubscriber.Unsubscribe(connection, unsubscribeID, global)
+	if err != nil {
+		writeError(h.errorWriter, w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, linkDocument(link))
+}
@@ -0,0 +1,120 @@
+package unsubscribe_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/unsubscribe"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PostHandler", func() {
+	var (
+		handler      unsubscribe.PostHandler
+		errorWriter  *mocks.ErrorWriter
+		writer       *httptest.ResponseRecorder
+		request      *http.Request
+		unsubscriber *mocks.Unsubscriber
+		database     *mocks.Database
+		conn         *mocks.Connection
+		context      stack.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		errorWriter = mocks.NewErrorWriter()
+		unsubscriber = mocks.NewUnsubscriber()
+		unsubscriber.UnsubscribeCall.Returns.Link = services.UnsubscribeLink{
+			UserGUID:        "some-user",
+			ClientID:        "some-client",
+			KindID:          "some-kind",
+			KindDescription: "Some Kind",
+		}
+
+		writer = httptest.NewRecorder()
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		request, err = http.NewRequest("POST", "/unsubscribe/some-unsubscribe-id", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = unsubscribe.NewPostHandler(unsubscriber, errorWriter)
+	})
+
+	It("unsubscribes the user from the kind", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{
+			"client_id": "some-client",
+			"kind_id": "some-kind",
+			"kind_description": "Some Kind",
+			"critical": false
+		}`))
+
+		Expect(unsubscriber.UnsubscribeCall.Receives.Connection).To(Equal(conn))
+		Expect(unsubscriber.UnsubscribeCall.Receives.UnsubscribeID).To(Equal("some-unsubscribe-id"))
+		Expect(unsubscriber.UnsubscribeCall.Receives.Global).To(BeFalse())
+	})
+
+	It("unsubscribes the user from everything when the form asks for it", func() {
+		var err error
+		request, err = http.NewRequest("POST", "/unsubscribe/some-unsubscribe-id", strings.NewReader("global=true"))
+		Expect(err).NotTo(HaveOccurred())
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(unsubscriber.UnsubscribeCall.Receives.Global).To(BeTrue())
+	})
+
+	It("unsubscribes the user from everything when the query asks for it", func() {
+		var err error
+		request, err = http.NewRequest("POST", "/unsubscribe/some-unsubscribe-id?global=true", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(unsubscriber.UnsubscribeCall.Receives.Global).To(BeTrue())
+	})
+
+	It("unsubscribes the user from the kind on a one-click List-Unsubscribe post", func() {
+		var err error
+		request, err = http.NewRequest("POST", "/unsubscribe/some-unsubscribe-id", strings.NewReader("List-Unsubscribe=One-Click"))
+		Expect(err).NotTo(HaveOccurred())
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(unsubscriber.UnsubscribeCall.Receives.UnsubscribeID).To(Equal("some-unsubscribe-id"))
+		Expect(unsubscriber.UnsubscribeCall.Receives.Global).To(BeFalse())
+	})
+
+	It("writes critical kind errors as validation errors", func() {
+		unsubscriber.UnsubscribeCall.Returns.Error = services.CriticalKindError{Err: errors.New("critical")}
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(webutil.ValidationError{Err: services.CriticalKindError{Err: errors.New("critical")}}))
+	})
+
+	It("delegates other errors to the error writer", func() {
+		unsubscriber.UnsubscribeCall.Returns.Error = errors.New("BOOM!")
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(errors.New("BOOM!")))
+	})
+})
@@ -0,0 +1,35 @@
+package unsubscribe
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type muxer interface {
+	Handle(method, path string, handler stack.Handler, middleware ...stack.Middleware)
+}
+
+type errorWriter interface {
+	Write(writer http.ResponseWriter, err error)
+}
+
+type unsubscriber interface {
+	Find(connection services.ConnectionInterface, unsubscribeID string) (services.UnsubscribeLink, error)
+	Unsubscribe(connection services.ConnectionInterface, unsubscribeID string, global bool) (services.UnsubscribeLink, error)
+}
+
+type Routes struct {
+	RequestCounter    stack.Middleware
+	RequestLogging    stack.Middleware
+	DatabaseAllocator stack.Middleware
+
+	ErrorWriter  errorWriter
+	Unsubscriber unsubscriber
+}
+
+func (r Routes) Register(m muxer) {
+	m.Handle("GET", "/unsubscribe/{unsubscribe_id}", NewGetHandler(r.Unsubscriber, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.DatabaseAllocator)
+	m.Handle("POST", "/unsubscribe/{unsubscribe_id}", NewPostHandler(r.Unsubscriber, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.DatabaseAllocator)
+}
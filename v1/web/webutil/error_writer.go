@@ -3,13 +3,18 @@ package webutil
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
 	"github.com/cloudfoundry-incubator/notifications/v1/collections"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
 )
 
+var QueueFullRetryAfter = 30 * time.Second
+
 type ErrorWriter struct{}
 
 func NewErrorWriter() ErrorWriter {
@@ -18,18 +23,24 @@ func NewErrorWriter() ErrorWriter {
 
 func (writer ErrorWriter) Write(w http.ResponseWriter, err error) {
 	switch err.(type) {
-	case UAAScopesError, CriticalNotificationError, collections.TemplateAssignmentError, MissingUserTokenError, ValidationError:
+	case UAAScopesError, CriticalNotificationError, collections.TemplateAssignmentError, MissingUserTokenError, ValidationError, services.IdempotencyKeyConflictError, services.UncorrelatedBounceError, services.RecipientsExceedRateLimitError:
 		w.WriteHeader(422)
 	case services.CCDownError:
 		w.WriteHeader(http.StatusBadGateway)
-	case services.CCNotFoundError, models.NotFoundError, cf.NotFoundError:
+	case services.CCNotFoundError, models.NotFoundError, cf.NotFoundError, gobble.DeadLetterNotFoundError, services.InvalidUnsubscribeIDError:
 		w.WriteHeader(http.StatusNotFound)
 	case ParseError, SchemaError:
 		w.WriteHeader(http.StatusBadRequest)
-	case models.DuplicateError:
+	case models.DuplicateError, services.MessageNotCancellableError, services.IdempotencyKeyInProgressError:
 		w.WriteHeader(http.StatusConflict)
 	case services.DefaultScopeError:
 		w.WriteHeader(http.StatusNotAcceptable)
+	case services.RateLimitExceededError:
+		w.Header().Set("Retry-After", strconv.Itoa(err.(services.RateLimitExceededError).RetryAfterSeconds()))
+		w.WriteHeader(http.StatusTooManyRequests)
+	case gobble.QueueFullError:
+		w.Header().Set("Retry-After", strconv.Itoa(int(QueueFullRetryAfter.Seconds())))
+		w.WriteHeader(http.StatusServiceUnavailable)
 	default:
 		w.WriteHeader(http.StatusInternalServerError)
 	}
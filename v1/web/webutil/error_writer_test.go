@@ -4,8 +4,10 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
 	"github.com/cloudfoundry-incubator/notifications/v1/collections"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
@@ -106,6 +108,64 @@ var _ = Describe("ErrorWriter", func() {
 		}`))
 	})
 
+	It("returns a 409 when a message can no longer be cancelled", func() {
+		writer.Write(recorder, services.MessageNotCancellableError{MessageID: "some-message-id", Status: "delivered"})
+		Expect(recorder.Code).To(Equal(409))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["Message \"some-message-id\" cannot be cancelled because it is delivered"]
+		}`))
+	})
+
+	It("returns a 422 when an idempotency key is reused for a different request", func() {
+		writer.Write(recorder, services.IdempotencyKeyConflictError{Key: "some-key"})
+		Expect(recorder.Code).To(Equal(422))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["Idempotency-Key \"some-key\" has already been used for a different request"]
+		}`))
+	})
+
+	It("returns a 422 when a bounce cannot be matched to a message", func() {
+		writer.Write(recorder, services.UncorrelatedBounceError{})
+		Expect(recorder.Code).To(Equal(422))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["The delivery status notification does not include the X-CF-Notification-ID of the original message"]
+		}`))
+	})
+
+	It("returns a 429 with a Retry-After header when a client exceeds its rate limit", func() {
+		writer.Write(recorder, services.RateLimitExceededError{
+			ClientID:   "some-client",
+			Limit:      "1000 recipients per hour",
+			RetryAfter: 90 * time.Second,
+		})
+		Expect(recorder.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(recorder.Header().Get("Retry-After")).To(Equal("90"))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["Client \"some-client\" has exceeded its rate limit of 1000 recipients per hour"]
+		}`))
+	})
+
+	It("returns a 422 without a Retry-After header when a send has more recipients than the hourly limit", func() {
+		writer.Write(recorder, services.RecipientsExceedRateLimitError{
+			ClientID:          "some-client",
+			Recipients:        1001,
+			RecipientsPerHour: 1000,
+		})
+		Expect(recorder.Code).To(Equal(422))
+		Expect(recorder.Header().Get("Retry-After")).To(BeEmpty())
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["Client \"some-client\" cannot notify 1001 recipients at once, it is limited to 1000 recipients_per_hour"]
+		}`))
+	})
+
+	It("returns a 409 when a request with the same idempotency key is still running", func() {
+		writer.Write(recorder, services.IdempotencyKeyInProgressError{Key: "some-key"})
+		Expect(recorder.Code).To(Equal(409))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["A request with Idempotency-Key \"some-key\" is still being processed"]
+		}`))
+	})
+
 	It("returns a 404 when a record cannot be found", func() {
 		writer.Write(recorder, models.NotFoundError{Err: errors.New("not found")})
 		Expect(recorder.Code).To(Equal(404))
@@ -114,6 +174,31 @@ var _ = Describe("ErrorWriter", func() {
 		}`))
 	})
 
+	It("returns a 404 when a dead letter cannot be found", func() {
+		writer.Write(recorder, gobble.DeadLetterNotFoundError{ID: 42})
+		Expect(recorder.Code).To(Equal(404))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["Dead letter with ID 42 could not be found"]
+		}`))
+	})
+
+	It("returns a 404 when an unsubscribe link is not valid", func() {
+		writer.Write(recorder, services.InvalidUnsubscribeIDError{})
+		Expect(recorder.Code).To(Equal(404))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["The unsubscribe link is not valid"]
+		}`))
+	})
+
+	It("returns a 503 with a Retry-After header when the queue is full", func() {
+		writer.Write(recorder, gobble.QueueFullError{Length: 5000, MaxLength: 5000})
+		Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(recorder.Header().Get("Retry-After")).To(Equal("30"))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["the queue is full (5000 of 5000 jobs), try again later"]
+		}`))
+	})
+
 	It("returns a 406 when a record cannot be found", func() {
 		writer.Write(recorder, services.DefaultScopeError{})
 		Expect(recorder.Code).To(Equal(406))
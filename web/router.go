@@ -19,6 +19,11 @@ func NewRouter(config Config) http.Handler {
 		CORSOrigin:        config.CORSOrigin,
 		SQLDB:             config.SQLDB,
 		MaxQueueLength:    config.MaxQueueLength,
+		IdempotencyKeyTTL: config.IdempotencyKeyTTL,
+		EncryptionKey:     config.EncryptionKey,
+
+		RateLimitRequestsPerMinute: config.RateLimitRequestsPerMinute,
+		RateLimitRecipientsPerHour: config.RateLimitRecipientsPerHour,
 	})
 
 	return VersionRouter{
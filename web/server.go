@@ -1,6 +1,7 @@
 package web
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 
@@ -18,10 +19,15 @@ type Config struct {
 	CORSOrigin           string
 	QueueWaitMaxDuration int
 	MaxQueueLength       int
+	IdempotencyKeyTTL    int
+	EncryptionKey        []byte
 	SQLDB                *sql.DB
 	Queue                gobble.QueueInterface
 	Logger               lager.Logger
 
+	RateLimitRequestsPerMinute int
+	RateLimitRecipientsPerHour int
+
 	UAATokenValidator *uaa.TokenValidator
 	UAAHost           string
 	UAAClientID       string
@@ -30,16 +36,34 @@ type Config struct {
 	CCHost            string
 }
 
-type Server struct{}
+type Server struct {
+	server *http.Server
+}
 
 func NewServer() Server {
-	return Server{}
+	return Server{
+		server: &http.Server{},
+	}
 }
 
-func (s Server) Run(config Config) {
+func (s Server) Run(config Config) error {
 	config.Logger.Info("listen-and-serve", lager.Data{
 		"port": config.Port,
 	})
 
-	http.ListenAndServe(fmt.Sprintf(":%d", config.Port), NewRouter(config))
+	s.server.Addr = fmt.Sprintf(":%d", config.Port)
+	s.server.Handler = NewRouter(config)
+
+	err := s.server.ListenAndServe()
+	if err == http.ErrServerClosed {
+		return nil
+	}
+
+	return err
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests to
+// complete until the context is done.
+func (s Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
 }